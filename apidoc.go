@@ -7,13 +7,17 @@ package apidoc
 
 import (
 	"bytes"
+	"context"
 	"log"
 	"net/http"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
+	"github.com/issue9/version"
 	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
 
 	"github.com/caixw/apidoc/v7/build"
 	"github.com/caixw/apidoc/v7/core"
@@ -39,7 +43,20 @@ type Config = build.Config
 // 如果不调用此函数，则默认会采用 internal/locale.DefaultLocaleID 的值。
 // 如果想采用当前系统的本地化信息，可以使用
 // github.com/issue9/localeutil.SystemLanguageTag 函数。
-func SetLocale(tag language.Tag) { locale.SetTag(tag) }
+//
+// tag 无法与 Locales 中的任何一个值相匹配时返回错误，且不会修改当前的本地化 ID。
+func SetLocale(tag language.Tag) error {
+	if _, _, c := language.NewMatcher(locale.Tags()).Match(tag); c == language.No {
+		return locale.NewError(locale.ErrUnsupportedLocale, tag)
+	}
+	locale.SetTag(tag)
+	return nil
+}
+
+// SetLocaleOrDefault 设置当前的本地化 ID
+//
+// 与 SetLocale 不同，tag 不被支持时，会采用与其最接近的值，而不是返回错误。
+func SetLocaleOrDefault(tag language.Tag) { locale.SetTag(tag) }
 
 // Locale 获取当前设置的本地化 ID
 func Locale() language.Tag { return locale.Tag() }
@@ -47,6 +64,47 @@ func Locale() language.Tag { return locale.Tag() }
 // Locales 返回当前所有支持的本地化信息
 func Locales() []language.Tag { return locale.Tags() }
 
+// 文字的书写方向
+const (
+	DirectionLTR = "ltr"
+	DirectionRTL = "rtl"
+)
+
+// 从右到左书写的语言
+var rtlBases = []string{"ar", "dv", "fa", "he", "ks", "ku", "ps", "sd", "ug", "ur", "yi"}
+
+// LocaleMeta 本地化的描述信息
+type LocaleMeta struct {
+	Tag        language.Tag `json:"tag"`
+	NativeName string       `json:"nativeName"` // 以该语言自身表示的名称
+	Direction  string       `json:"direction"`  // 文字的书写方向，可以是 ltr 或是 rtl
+}
+
+// LocaleInfo 返回 tag 以自身语言表示的名称以及文字的书写方向
+func LocaleInfo(tag language.Tag) (nativeName, direction string) {
+	direction = DirectionLTR
+	base, _ := tag.Base()
+	for _, b := range rtlBases {
+		if base.String() == b {
+			direction = DirectionRTL
+			break
+		}
+	}
+
+	return display.Self.Name(tag), direction
+}
+
+// AllLocales 返回当前所有支持的本地化的描述信息
+func AllLocales() []LocaleMeta {
+	tags := Locales()
+	metas := make([]LocaleMeta, 0, len(tags))
+	for _, tag := range tags {
+		name, dir := LocaleInfo(tag)
+		metas = append(metas, LocaleMeta{Tag: tag, NativeName: name, Direction: dir})
+	}
+	return metas
+}
+
 // Version 当前程序的版本号
 //
 // full 表示是否需要在版本号中包含编译日期和编译时的 Git 记录 ID。
@@ -57,6 +115,41 @@ func Version(full bool) string {
 	return core.Version()
 }
 
+// VersionCompare 比较当前程序的版本号与 other
+//
+// 当前版本号较大时返回 1，相等返回 0，否则返回 -1。
+// other 必须是合法的 semver 格式，否则返回错误信息。
+func VersionCompare(other string) (int, error) {
+	return compareVersion(core.Version(), other)
+}
+
+// VersionAtLeast 当前程序的版本号是否不低于 min
+//
+// min 必须是合法的 semver 格式，否则返回错误信息。
+func VersionAtLeast(min string) (bool, error) {
+	ret, err := VersionCompare(min)
+	if err != nil {
+		return false, err
+	}
+	return ret >= 0, nil
+}
+
+func compareVersion(curr, other string) (int, error) {
+	ret, err := version.SemVerCompare(curr, other)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case ret > 0:
+		return 1, nil
+	case ret < 0:
+		return -1, nil
+	default:
+		return 0, nil
+	}
+}
+
 // Build 解析文档并输出文档内容
 //
 // 如果是文档语法错误，则相关的错误信息会反馈给 h，由 h 处理错误信息；
@@ -67,6 +160,14 @@ func Build(h *core.MessageHandler, o *build.Output, i ...*build.Input) error {
 	return build.Build(h, o, i...)
 }
 
+// BuildAll 解析文档并输出文档内容，同时返回所有的文档错误
+//
+// 文档错误依然会输出至 h，同时也会收集在 errs 中返回；
+// 如果是配置项（o 和 i）有问题，则以 err 返回错误信息。
+func BuildAll(h *core.MessageHandler, o *build.Output, i ...*build.Input) (errs []error, err error) {
+	return build.BuildAll(h, o, i...)
+}
+
 // Buffer 生成文档内容并返回
 //
 // 如果是文档语法错误，则相关的错误信息会反馈给 h，由 h 处理错误信息；
@@ -77,36 +178,87 @@ func Buffer(h *core.MessageHandler, o *build.Output, i ...*build.Input) (*bytes.
 	return build.Buffer(h, o, i...)
 }
 
+// CachedBuffer 返回一个带缓存功能的 Buffer 函数
+//
+// 输入文件的内容有修改或是超过 ttl 之后，缓存才会失效，ttl 小于等于 0 表示不会过期。
+// 返回的函数可以在多个 goroutine 中同时调用，且同一时间只会有一个解析文档的操作。
+func CachedBuffer(h *core.MessageHandler, ttl time.Duration, o *build.Output, i ...*build.Input) func() (*bytes.Buffer, error) {
+	return build.CachedBuffer(h, ttl, o, i...)
+}
+
 // CheckSyntax 测试文档语法
 func CheckSyntax(h *core.MessageHandler, i ...*build.Input) error {
 	return build.CheckSyntax(h, i...)
 }
 
+// CheckSyntaxResult 测试文档语法并返回错误和警告信息的数量
+func CheckSyntaxResult(h *core.MessageHandler, i ...*build.Input) (errs, warns int, err error) {
+	return build.CheckSyntaxResult(h, i...)
+}
+
 // ServeLSP 提供 language server protocol 服务
 //
 // header 表示传递内容是否带报头；
 // t 表示允许连接的类型，目前可以是 tcp、udp、stdio 和 unix；
 // timeout 表示服务端每次读取客户端时的超时时间，如果为 0 表示不会超时。
 // 超时并不会出错，而是重新开始读取数据，防止被读取一直阻塞，无法结束进程；
-func ServeLSP(header bool, t, addr string, timeout time.Duration, info, erro *log.Logger) error {
-	return lsp.Serve(header, t, addr, timeout, info, erro)
+// maxConnections 表示 tcp 和 unix 模式下允许的最大连接数量，0 表示不限制；
+// ctx 被取消时，会关闭所有的连接并返回。
+func ServeLSP(ctx context.Context, header bool, t, addr string, timeout time.Duration, maxConnections int, info, erro *log.Logger) error {
+	return lsp.Serve(ctx, header, t, addr, timeout, maxConnections, info, erro)
 }
 
 // Static 为 dir 指向的路径内容搭建一个静态文件服务
 //
 // dir 为静态文件的根目录，一般指向 /docs
 // 用于搭建一个本地版本的 https://apidoc.tools，默认页为 index.xml。
-// 如果 dir 值为空，则会采用内置的文档内容作为静态文件服务的内容。
+// 如果 dir 值为空，则会采用内置的文档内容作为静态文件服务的内容，
+// 也可以是由 core.FSURI 生成的指向 fs.FS 的地址。
+// 采用内置的文档内容时，如果内置内容不完整，会直接 panic。
 //
 // stylesheet 表示是否只展示 XSL 及相关的内容。
+// 无论 dir 和 stylesheet 为何值，都可以通过 /apidoc.xsd 访问文档的 XML Schema。
 //
 // 用户可以通过以下代码搭建一个简易的 https://apidoc.tools 网站：
 //  http.Handle("/apidoc", apidoc.Static(...))
 func Static(dir core.URI, stylesheet bool, erro *log.Logger) http.Handler {
+	if dir == "" {
+		if err := docs.Validate(); err != nil {
+			panic(locale.NewError(locale.ErrIncompleteDocs, err))
+		}
+	}
 	return docs.Handler(dir, stylesheet, erro)
 }
 
+// StaticWithHealth 在 Static 的基础上添加健康检测的地址
+//
+// 访问 healthPath 时，以 JSON 格式返回 {"status":"ok"}、当前程序的版本号以及服务的启动时间，
+// 可用于部署环境中的健康检测，healthPath 为空表示采用 /healthz。其它参数与 Static 相同。
+func StaticWithHealth(dir core.URI, stylesheet bool, healthPath string, erro *log.Logger) http.Handler {
+	return docs.WithHealth(Static(dir, stylesheet, erro), healthPath)
+}
+
+// StaticBuffer 在 Static 的基础上将 docBuf 作为文档内容输出
+//
+// docBuf 为已经生成的文档内容，比如由 Buffer 返回的内容，访问地址为 docURL；
+// 其它 xsl 和 css 等内容则采用内置的数据。
+// stylesheet 和 erro 的作用与 Static 相同。
+//
+// 相当于以下代码的简写：
+//  (&apidoc.Server{Path: docURL, Stylesheet: stylesheet, Erro: erro}).Buffer(docBuf.Bytes())
+func StaticBuffer(docBuf *bytes.Buffer, docURL string, stylesheet bool, erro *log.Logger) http.Handler {
+	srv := &Server{Path: docURL, Stylesheet: stylesheet, Erro: erro}
+	return srv.Buffer(docBuf.Bytes())
+}
+
 // Server 用于生成查看文档中间件的配置项
+//
+// 文档内容可以是本地文件：
+//  h, err := (&apidoc.Server{Path: "/apidoc.xml"}).File("./apidoc.xml")
+// 也可以是内存中的数据，比如由 //go:embed 嵌入的文档内容：
+//  //go:embed apidoc.xml
+//  var data []byte
+//  h := (&apidoc.Server{Path: "/apidoc.xml"}).Buffer(data)
 type Server struct {
 	Status      int         // 默认值为 200
 	Path        string      // 文档在路由中的地址，默认值为 apidoc.xml
@@ -114,6 +266,12 @@ type Server struct {
 	Dir         core.URI    // 除文档不之外的附加项，比如 xsl，css 等内容的所在位置，如果为空表示采用内嵌的数据；
 	Stylesheet  bool        // 是否只采用 Dir 中的 xsl 和 css 等样式数据，而忽略其它文件
 	Erro        *log.Logger // 服务出错时的错误信息输出通道，默认采用 log.Default()
+
+	// 中间件在路由中的前缀，比如 /api-docs
+	//
+	// 访问时会去掉请求地址中的前缀，Path 为去掉前缀之后的地址，
+	// 同时文档中 xml-stylesheet 指令的地址也会加上此前缀。
+	Prefix string
 }
 
 func (srv *Server) sanitize() {
@@ -132,14 +290,40 @@ func (srv *Server) sanitize() {
 	if srv.Erro == nil {
 		srv.Erro = log.Default()
 	}
+
+	if srv.Prefix != "" {
+		srv.Prefix = "/" + strings.Trim(srv.Prefix, "/")
+		if srv.Prefix == "/" {
+			srv.Prefix = ""
+		}
+	}
 }
 
 // Buffer 将 buf 作为文档内容生成中间件
 func (srv *Server) Buffer(buf []byte) http.Handler {
+	return srv.BufferContext(context.Background(), buf)
+}
+
+// BufferContext 将 buf 作为文档内容生成中间件
+//
+// 在 ctx 结束之后，所有的请求都将返回 503，
+// 可用于在服务关闭的过程中拒绝新的请求。
+func (srv *Server) BufferContext(ctx context.Context, buf []byte) http.Handler {
 	srv.sanitize()
 
-	buf = addStylesheet(buf)
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	prefix := "./"
+	if srv.Prefix != "" {
+		prefix = srv.Prefix
+	}
+	buf = addStylesheet(buf, prefix)
+
+	static := Static(srv.Dir, srv.Stylesheet, srv.Erro)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ctx.Err() != nil {
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+
 		if r.URL.Path == srv.Path {
 			w.Header().Set("Content-Type", srv.ContentType)
 			w.WriteHeader(srv.Status)
@@ -147,13 +331,26 @@ func (srv *Server) Buffer(buf []byte) http.Handler {
 			return
 		}
 
-		Static(srv.Dir, srv.Stylesheet, srv.Erro).ServeHTTP(w, r)
+		static.ServeHTTP(w, r)
 	})
+
+	if srv.Prefix == "" {
+		return h
+	}
+	return http.StripPrefix(srv.Prefix, h)
 }
 
 // File 将 path 指向的内容作为文档内容生成中间件
 func (srv *Server) File(path core.URI) (http.Handler, error) {
-	data, err := path.ReadAll(nil)
+	return srv.FileContext(context.Background(), path)
+}
+
+// FileContext 将 path 指向的内容作为文档内容生成中间件
+//
+// ctx 的作用可参考 BufferContext，读取远程的 path 时，
+// 也会采用 ctx 中通过 core.WithRetryOptions 指定的重试设置。
+func (srv *Server) FileContext(ctx context.Context, path core.URI) (http.Handler, error) {
+	data, err := path.ReadAllContext(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -166,15 +363,19 @@ func (srv *Server) File(path core.URI) (http.Handler, error) {
 		srv.Path = "/" + filepath.Base(file)
 	}
 
-	return srv.Buffer(data), nil
+	return srv.BufferContext(ctx, data), nil
 }
 
 // 用于查找 <?xml 指令
 var procInst = regexp.MustCompile(`<\?xml .+ ?>`)
 
-func addStylesheet(data []byte) []byte {
+// 为 data 添加 xml-stylesheet 指令，prefix 为 xsl 和 css 文件地址的前缀。
+//
+// css 用于在不支持 xsl 的环境中提供基本的样式。
+func addStylesheet(data []byte, prefix string) []byte {
 	pi := `
-<?xml-stylesheet type="text/xsl" href="` + docs.StylesheetURL("./") + `"?>`
+<?xml-stylesheet type="text/xsl" href="` + docs.StylesheetURL(prefix) + `"?>
+<?xml-stylesheet type="text/css" href="` + docs.AssetURL(prefix, "apidoc.css") + `"?>`
 
 	if rslt := procInst.Find(data); len(rslt) > 0 {
 		return procInst.ReplaceAll(data, append(rslt, []byte(pi)...))
@@ -3,6 +3,8 @@
 package apidoc
 
 import (
+	"bytes"
+	"context"
 	"log"
 	"net/http"
 	"testing"
@@ -10,6 +12,7 @@ import (
 	"github.com/issue9/assert/v2"
 	"github.com/issue9/assert/v2/rest"
 	"github.com/issue9/version"
+	"golang.org/x/text/language"
 
 	"github.com/caixw/apidoc/v7/internal/ast/asttest"
 	"github.com/caixw/apidoc/v7/internal/docs"
@@ -24,11 +27,128 @@ func TestVersion(t *testing.T) {
 	a.True(version.SemVerValid(LSPVersion))
 }
 
+func TestVersionCompare(t *testing.T) {
+	a := assert.New(t, false)
+
+	ret, err := VersionCompare(Version(false))
+	a.NotError(err).Equal(ret, 0)
+
+	ret, err = VersionCompare("0.1.0")
+	a.NotError(err).Equal(ret, 1)
+
+	ret, err = VersionCompare("1000.0.0")
+	a.NotError(err).Equal(ret, -1)
+
+	ret, err = VersionCompare("invalid")
+	a.Error(err).Equal(ret, 0)
+
+	ret, err = compareVersion("1.10.0", "1.2.0")
+	a.NotError(err).Equal(ret, 1)
+
+	ret, err = compareVersion("1.0.0-alpha", "1.0.0")
+	a.NotError(err).Equal(ret, -1)
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	a := assert.New(t, false)
+
+	ok, err := VersionAtLeast(Version(false))
+	a.NotError(err).True(ok)
+
+	ok, err = VersionAtLeast("0.1.0")
+	a.NotError(err).True(ok)
+
+	ok, err = VersionAtLeast("1000.0.0")
+	a.NotError(err).False(ok)
+
+	ok, err = VersionAtLeast("invalid")
+	a.Error(err).False(ok)
+}
+
+func TestSetLocale(t *testing.T) {
+	a := assert.New(t, false)
+	old := Locale()
+	t.Cleanup(func() { SetLocaleOrDefault(old) })
+
+	a.NotError(SetLocale(language.MustParse("cmn-Hant")))
+	a.Equal(Locale(), language.MustParse("cmn-Hant"))
+
+	a.NotError(SetLocale(language.MustParse("zh-CN")))
+	curr := Locale()
+
+	// 不支持的值不会修改当前的本地化 ID
+	a.Error(SetLocale(language.Japanese))
+	a.Equal(Locale(), curr)
+
+	SetLocaleOrDefault(language.Japanese)
+	a.Contains(Locales(), Locale())
+}
+
+func TestLocaleInfo(t *testing.T) {
+	a := assert.New(t, false)
+
+	name, dir := LocaleInfo(language.SimplifiedChinese)
+	a.Equal(name, "简体中文").Equal(dir, DirectionLTR)
+
+	name, dir = LocaleInfo(language.Arabic)
+	a.NotEmpty(name).Equal(dir, DirectionRTL)
+
+	_, dir = LocaleInfo(language.MustParse("he-IL"))
+	a.Equal(dir, DirectionRTL)
+
+	metas := AllLocales()
+	a.Equal(len(metas), len(Locales()))
+	for index, meta := range metas {
+		a.Equal(meta.Tag, Locales()[index]).
+			NotEmpty(meta.NativeName).
+			Equal(meta.Direction, DirectionLTR)
+	}
+}
+
 func TestStatic(t *testing.T) {
 	a := assert.New(t, false)
 	srv := rest.NewServer(a, Static(docs.Dir(), false, log.Default()), nil)
 
 	srv.Get("/icon.svg").Do(nil).Status(http.StatusOK)
+
+	// 内置文档
+	srv = rest.NewServer(a, Static("", false, log.Default()), nil)
+	srv.Get("/icon.svg").Do(nil).Status(http.StatusOK)
+}
+
+func TestStaticWithHealth(t *testing.T) {
+	a := assert.New(t, false)
+	srv := rest.NewServer(a, StaticWithHealth("", false, "", log.Default()), nil)
+
+	srv.Get("/healthz").Do(nil).
+		Status(http.StatusOK).
+		Header("content-type", "application/json").
+		BodyFunc(func(a *assert.Assertion, body []byte) {
+			a.True(bytes.Contains(body, []byte(`"status":"ok"`))).
+				True(bytes.Contains(body, []byte(Version(false))))
+		})
+	srv.Get("/icon.svg").Do(nil).Status(http.StatusOK)
+}
+
+func TestStaticBuffer(t *testing.T) {
+	a := assert.New(t, false)
+	buf := bytes.NewBuffer(asttest.XML(a))
+
+	srv := rest.NewServer(a, StaticBuffer(buf, "/test/apidoc.xml", false, log.Default()), nil)
+	srv.Get("/test/apidoc.xml").Do(nil).
+		Status(http.StatusOK).
+		Header("content-type", "application/xml").
+		BodyFunc(func(a *assert.Assertion, body []byte) {
+			a.True(bytes.Contains(body, []byte("xml-stylesheet")))
+		})
+	srv.Get("/v6/apidoc.xsl").Do(nil).Status(http.StatusOK)
+	srv.Get("/index.xml").Do(nil).Status(http.StatusOK)
+
+	// stylesheet
+	srv = rest.NewServer(a, StaticBuffer(buf, "/apidoc.xml", true, log.Default()), nil)
+	srv.Get("/apidoc.xml").Do(nil).Status(http.StatusOK)
+	srv.Get("/v6/apidoc.xsl").Do(nil).Status(http.StatusOK)
+	srv.Get("/index.xml").Do(nil).Status(http.StatusNotFound)
 }
 
 func TestView_Buffer(t *testing.T) {
@@ -63,6 +183,21 @@ func TestView_Buffer(t *testing.T) {
 	srv.Get("/v6/apidoc.xsl").Do(nil).Status(http.StatusOK)
 }
 
+func TestServer_BufferContext(t *testing.T) {
+	a := assert.New(t, false)
+	data := asttest.XML(a)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{Path: "/apidoc.xml", Prefix: "/docs"}
+	srv := rest.NewServer(a, s.BufferContext(ctx, data), nil)
+	srv.Get("/docs/apidoc.xml").Do(nil).Status(http.StatusOK)
+	srv.Get("/docs/v6/apidoc.xsl").Do(nil).Status(http.StatusOK)
+
+	cancel()
+	srv.Get("/docs/apidoc.xml").Do(nil).Status(http.StatusServiceUnavailable)
+	srv.Get("/docs/v6/apidoc.xsl").Do(nil).Status(http.StatusServiceUnavailable)
+}
+
 func TestView_File(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -99,6 +234,35 @@ func TestView_File(t *testing.T) {
 		Status(http.StatusAccepted)
 }
 
+func TestServer_Prefix(t *testing.T) {
+	a := assert.New(t, false)
+	data := asttest.XML(a)
+
+	s := &Server{
+		Path:   "/apidoc.xml",
+		Prefix: "api-docs/",
+	}
+	srv := rest.NewServer(a, s.Buffer(data), nil)
+	a.Equal(s.Prefix, "/api-docs")
+
+	srv.Get("/api-docs/apidoc.xml").Do(nil).
+		Status(http.StatusOK).
+		BodyFunc(func(a *assert.Assertion, body []byte) {
+			a.True(bytes.Contains(body, []byte(`href="/api-docs/v6/apidoc.xsl"`)))
+		})
+	srv.Get("/api-docs/v6/apidoc.xsl").Do(nil).Status(http.StatusOK)
+	srv.Get("/api-docs/index.xml").Do(nil).Status(http.StatusOK)
+
+	srv.Get("/apidoc.xml").Do(nil).Status(http.StatusNotFound)
+	srv.Get("/v6/apidoc.xsl").Do(nil).Status(http.StatusNotFound)
+
+	// 仅有 / 的前缀等同于空值
+	s = &Server{Prefix: "/"}
+	srv = rest.NewServer(a, s.Buffer(data), nil)
+	a.Empty(s.Prefix)
+	srv.Get("/apidoc.xml").Do(nil).Status(http.StatusOK)
+}
+
 func TestAddStylesheet(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -109,24 +273,27 @@ func TestAddStylesheet(t *testing.T) {
 		{
 			input: "",
 			output: `
-<?xml-stylesheet type="text/xsl" href="./v6/apidoc.xsl"?>`,
+<?xml-stylesheet type="text/xsl" href="./v6/apidoc.xsl"?>
+<?xml-stylesheet type="text/css" href="./v6/apidoc.css"?>`,
 		},
 		{
 			input: `<?xml version="1.0"?>`,
 			output: `<?xml version="1.0"?>
-<?xml-stylesheet type="text/xsl" href="./v6/apidoc.xsl"?>`,
+<?xml-stylesheet type="text/xsl" href="./v6/apidoc.xsl"?>
+<?xml-stylesheet type="text/css" href="./v6/apidoc.css"?>`,
 		},
 		{
 			input: `<?xml version="1.0"?>
 <?xml-stylesheet href="xxx"?>`,
 			output: `<?xml version="1.0"?>
 <?xml-stylesheet type="text/xsl" href="./v6/apidoc.xsl"?>
+<?xml-stylesheet type="text/css" href="./v6/apidoc.css"?>
 <?xml-stylesheet href="xxx"?>`,
 		},
 	}
 
 	for index, item := range data {
-		output := string(addStylesheet([]byte(item.input)))
+		output := string(addStylesheet([]byte(item.input), "./"))
 		a.Equal(output, item.output, "not equal at %d\nv1: %s\nv2:%s", index, item.output, output)
 	}
 }
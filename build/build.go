@@ -5,36 +5,79 @@ package build
 
 import (
 	"bytes"
+	"os"
 
 	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
 // Build 解析文档并输出文档内容
 //
 // 如果是配置文件有问题，则直接返回错误信息，文档错误则输出至 h 对象。
 func Build(h *core.MessageHandler, o *Output, i ...*Input) error {
-	d, err := parse(h, i...)
+	_, err := doBuild(h, o, nil, i...)
+	return err
+}
+
+// BuildAll 解析文档并输出文档内容，同时返回所有的文档错误
+//
+// 文档错误依然会输出至 h 对象，同时也会收集在 errs 中返回，
+// 其中的每个 *core.Error 都带有各自的定位信息，方便 IDE 等工具一次性展示所有的错误。
+// 如果是配置文件有问题，则直接以 err 返回错误信息。
+func BuildAll(h *core.MessageHandler, o *Output, i ...*Input) (errs []error, err error) {
+	collector := core.NewMessageHandler(func(msg *core.Message) {
+		if msg.Type == core.Erro {
+			if e, ok := msg.Message.(error); ok {
+				errs = append(errs, e)
+			}
+		}
+		h.Message(msg.Type, msg.Message)
+	})
+
+	_, err = doBuild(collector, o, nil, i...)
+	collector.Stop()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return errs, nil
+}
+
+// 返回值中的 *ast.APIDoc 为生成文档时采用的文档对象
+func doBuild(h *core.MessageHandler, o *Output, l *Lint, i ...*Input) (*ast.APIDoc, error) {
+	if err := o.checkWritable(); err != nil {
+		return nil, err
+	}
+
+	d, err := parse(h, l, false, i...)
+	if err != nil {
+		return nil, err
 	}
 	if err = o.sanitize(); err != nil {
-		return err
+		return nil, err
 	}
 
-	buf, err := o.buffer(d)
+	buf, err := o.buffer(h, d)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return o.Path.WriteAll(buf.Bytes())
+	path, err := o.outputPath(d)
+	if err != nil {
+		return nil, err
+	}
+	return d, path.WriteAll(buf.Bytes(), os.ModePerm)
 }
 
 // Buffer 生成文档内容并返回
 //
 // 如果是配置文件有问题，则直接返回错误信息，文档错误则输出至 h 对象。
 func Buffer(h *core.MessageHandler, o *Output, i ...*Input) (*bytes.Buffer, error) {
-	d, err := parse(h, i...)
+	return doBuffer(h, o, nil, i...)
+}
+
+func doBuffer(h *core.MessageHandler, o *Output, l *Lint, i ...*Input) (*bytes.Buffer, error) {
+	d, err := parse(h, l, false, i...)
 	if err != nil {
 		return nil, err
 	}
@@ -42,18 +85,63 @@ func Buffer(h *core.MessageHandler, o *Output, i ...*Input) (*bytes.Buffer, erro
 		return nil, err
 	}
 
-	return o.buffer(d)
+	return o.buffer(h, d)
 }
 
 // CheckSyntax 测试文档语法
 //
 // 如果是配置文件有问题，则直接返回错误信息，文档错误则输出至 h 对象。
 func CheckSyntax(h *core.MessageHandler, i ...*Input) error {
-	_, err := parse(h, i...)
+	_, _, err := CheckSyntaxResult(h, i...)
 	return err
 }
 
-func parse(h *core.MessageHandler, i ...*Input) (*ast.APIDoc, error) {
+// CheckSyntaxResult 测试文档语法并返回错误和警告信息的数量
+//
+// 错误和警告信息依然会输出至 h 对象，返回值仅是对其数量的统计。
+// 检测完成之后会向 h 输出统计信息：没有错误时为 core.Succ 类型，否则为 core.Info 类型。
+// 如果是配置文件有问题，则直接返回错误信息。
+func CheckSyntaxResult(h *core.MessageHandler, i ...*Input) (errs, warns int, err error) {
+	return checkSyntax(h, nil, i...)
+}
+
+func checkSyntax(h *core.MessageHandler, l *Lint, i ...*Input) (errs, warns int, err error) {
+	counter := core.NewCollectMessageHandler(func(msg *core.Message) {
+		h.Message(msg.Type, msg.Message)
+	})
+
+	d, err := parse(counter, l, true, i...)
+	counter.Stop()
+	if err != nil {
+		return 0, 0, err
+	}
+	errs, warns = counter.Count()
+
+	files, apis := countFiles(d), len(d.APIs)
+	if errs == 0 {
+		h.Locale(core.Succ, locale.TestSuccess, files, apis)
+	} else {
+		h.Locale(core.Info, locale.SyntaxPartial, files, apis)
+	}
+
+	return errs, warns, nil
+}
+
+// 统计 d 中的内容分布在多少个文件中
+func countFiles(d *ast.APIDoc) int {
+	uris := make(map[core.URI]struct{}, len(d.APIs)+1)
+	if d.URI != "" {
+		uris[d.URI] = struct{}{}
+	}
+	for _, api := range d.APIs {
+		uris[api.URI] = struct{}{}
+	}
+	return len(uris)
+}
+
+// l 为额外的规范性检测，为空表示不检测；
+// syntax 表示是否为语法检测，该模式下会输出额外的提示信息，比如被注释的 api 元素。
+func parse(h *core.MessageHandler, l *Lint, syntax bool, i ...*Input) (*ast.APIDoc, error) {
 	for _, item := range i {
 		if err := item.sanitize(); err != nil {
 			return nil, err
@@ -62,8 +150,27 @@ func parse(h *core.MessageHandler, i ...*Input) (*ast.APIDoc, error) {
 
 	d := &ast.APIDoc{}
 	d.ParseBlocks(h, func(blocks chan core.Block) {
-		ParseInputs(blocks, h, i...)
+		if !syntax {
+			ParseInputs(blocks, h, i...)
+			return
+		}
+
+		all := make(chan core.Block, 50)
+		done := make(chan struct{})
+		go func() {
+			for b := range all {
+				for _, loc := range ast.CommentedAPIs(h, b) {
+					h.Info(loc.NewError(locale.CommentedAPI))
+				}
+				blocks <- b
+			}
+			close(done)
+		}()
+		ParseInputs(all, h, i...)
+		close(all)
+		<-done
 	})
+	l.check(h, d)
 
 	return d, nil
 }
@@ -3,11 +3,15 @@
 package build
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/issue9/assert/v2"
 
+	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
 func TestParse(t *testing.T) {
@@ -27,7 +31,7 @@ func TestParse(t *testing.T) {
 	}
 
 	rslt := messagetest.NewMessageHandler()
-	doc, err := parse(rslt.Handler, php, c)
+	doc, err := parse(rslt.Handler, nil, false, php, c)
 	a.NotError(err).NotNil(doc)
 	rslt.Handler.Stop()
 	a.Empty(rslt.Errors)
@@ -37,3 +41,107 @@ func TestParse(t *testing.T) {
 	api := doc.APIs[0]
 	a.Equal(api.Method.V(), "GET")
 }
+
+func TestBuildAll(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	a.NotError(os.WriteFile(filepath.Join(dir, "doc.go"), []byte("// <apidoc version=\"1.1.1\"><title>title</title></apidoc>\n"), os.ModePerm))
+	a.NotError(os.WriteFile(filepath.Join(dir, "api1.go"), []byte("// <api method=\"GET\">\n"), os.ModePerm))
+	a.NotError(os.WriteFile(filepath.Join(dir, "api2.go"), []byte("// <api method=\"POST\">\n"), os.ModePerm))
+	i := &Input{Lang: "go", Dir: core.FileURI(dir)}
+	o := &Output{Path: core.FileURI(filepath.Join(dir, "apidoc.xml"))}
+
+	rslt := messagetest.NewMessageHandler()
+	errs, err := BuildAll(rslt.Handler, o, i)
+	rslt.Handler.Stop()
+	a.NotError(err).
+		Equal(len(errs), len(rslt.Errors)).
+		True(len(errs) >= 3)
+
+	uris := make(map[core.URI]struct{}, len(errs))
+	for _, e := range errs {
+		serr, ok := e.(*core.Error)
+		a.True(ok)
+		uris[serr.Location.URI] = struct{}{}
+	}
+	a.Equal(len(uris), 3) // doc.go 缺少 mimetype，api1.go 和 api2.go 缺少结束标签
+
+	// 配置错误
+	rslt = messagetest.NewMessageHandler()
+	errs, err = BuildAll(rslt.Handler, o, &Input{Lang: "not-exists", Dir: core.FileURI(dir)})
+	rslt.Handler.Stop()
+	a.Error(err).Nil(errs)
+}
+
+func TestCheckSyntaxResult(t *testing.T) {
+	a := assert.New(t, false)
+
+	c := &Input{
+		Lang:      "c++",
+		Dir:       "./testdata",
+		Recursive: true,
+	}
+	rslt := messagetest.NewMessageHandler()
+	errs, warns, err := CheckSyntaxResult(rslt.Handler, c)
+	rslt.Handler.Stop()
+	a.NotError(err).
+		Equal(errs, len(rslt.Errors)).
+		Equal(warns, len(rslt.Warns))
+
+	// 语法错误
+	dir := t.TempDir()
+	a.NotError(os.WriteFile(filepath.Join(dir, "api.go"), []byte("// <api method=\"GET\">\n"), os.ModePerm))
+	g := &Input{Lang: "go", Dir: core.FileURI(dir)}
+	rslt = messagetest.NewMessageHandler()
+	errs, _, err = CheckSyntaxResult(rslt.Handler, g)
+	rslt.Handler.Stop()
+	a.NotError(err).
+		True(errs > 0).
+		Equal(errs, len(rslt.Errors)).
+		Empty(rslt.Successes).
+		Equal(rslt.Infos, []interface{}{locale.New(locale.SyntaxPartial, 1, 1)})
+
+	// 被注释的 api
+	a.NotError(os.WriteFile(filepath.Join(dir, "api.go"), []byte("// <!-- <api method=\"GET\"></api> -->\n"), os.ModePerm))
+	rslt = messagetest.NewMessageHandler()
+	errs, warns, err = CheckSyntaxResult(rslt.Handler, &Input{Lang: "go", Dir: core.FileURI(dir)})
+	rslt.Handler.Stop()
+	a.NotError(err).
+		Equal(errs, 0).
+		Equal(warns, 0).
+		Length(rslt.Infos, 1).
+		Equal(rslt.Successes, []interface{}{locale.New(locale.TestSuccess, 0, 0)})
+
+	// 统计信息
+	dir = t.TempDir()
+	a.NotError(os.WriteFile(filepath.Join(dir, "doc.go"), []byte(`// <apidoc version="1.0.0">
+// <title>title</title>
+// <mimetype>application/json</mimetype>
+// </apidoc>
+
+// <api method="GET">
+// <path path="/users" />
+// <response status="200" type="string" />
+// </api>
+`), os.ModePerm))
+	a.NotError(os.WriteFile(filepath.Join(dir, "api.go"), []byte(`// <api method="POST">
+// <path path="/users" />
+// <response status="201" type="string" />
+// </api>
+`), os.ModePerm))
+	rslt = messagetest.NewMessageHandler()
+	errs, _, err = CheckSyntaxResult(rslt.Handler, &Input{Lang: "go", Dir: core.FileURI(dir)})
+	rslt.Handler.Stop()
+	a.NotError(err).
+		Equal(errs, 0).
+		Equal(rslt.Successes, []interface{}{locale.New(locale.TestSuccess, 2, 2)})
+
+	// 配置项错误
+	rslt = messagetest.NewMessageHandler()
+	errs, warns, err = CheckSyntaxResult(rslt.Handler, &Input{})
+	rslt.Handler.Stop()
+	a.Error(err).Equal(errs, 0).Equal(warns, 0).
+		Empty(rslt.Successes).
+		Empty(rslt.Infos)
+}
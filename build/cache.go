@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/caixw/apidoc/v7/core"
+)
+
+// 缓存的内容
+type cacheEntry struct {
+	done    chan struct{} // 生成完成之后关闭
+	data    []byte
+	err     error
+	expires time.Time
+}
+
+// 仅保存最新键值的缓存
+//
+// 输入文件修改之后，旧键值的内容不会再被用到，
+// 所以在保存新的键值时会直接丢弃旧的内容，缓存的大小不会随修改次数增长。
+type bufferCache struct {
+	mux   sync.Mutex
+	key   string
+	entry *cacheEntry
+}
+
+// 获取 key 对应的缓存项
+//
+// 如果 key 与当前缓存的键值不同，则以新的缓存项替换当前内容，并返回 false，
+// 调用方需要负责生成内容并关闭其 done。
+func (c *bufferCache) load(key string) (e *cacheEntry, loaded bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.entry != nil && c.key == key {
+		return c.entry, true
+	}
+
+	c.key = key
+	c.entry = &cacheEntry{done: make(chan struct{})}
+	return c.entry, false
+}
+
+// 如果 e 是当前的缓存项，则将其删除。
+func (c *bufferCache) remove(e *cacheEntry) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.entry == e {
+		c.key = ""
+		c.entry = nil
+	}
+}
+
+// CachedBuffer 返回一个带缓存功能的 Buffer 函数
+//
+// 缓存以所有输入文件的路径及其修改时间作为键值，新增、删除或修改文件之后缓存自动失效，
+// 同时缓存内容在 ttl 之后也会过期，ttl 小于等于 0 表示不会过期。
+// 只会保留最新键值的内容。
+// 返回的函数可以在多个 goroutine 中同时调用，相同键值的多个调用只会解析一次文档，
+// 且同一时间只会有一个解析文档的操作。
+// 只有在真正解析文档时，才会向 h 输出信息。
+//
+// 一般用于在 HTTP 服务中动态生成文档内容。
+func CachedBuffer(h *core.MessageHandler, ttl time.Duration, o *Output, i ...*Input) func() (*bytes.Buffer, error) {
+	cache := &bufferCache{}
+
+	// Input.sanitize 并不是并发安全的，需要在所有操作之前完成。
+	var sanitizeOnce sync.Once
+	var sanitizeErr error
+
+	// Output.sanitize 同样不是并发安全的，同一时间只允许一个生成操作。
+	var buildMux sync.Mutex
+	build := func() (*bytes.Buffer, error) {
+		buildMux.Lock()
+		defer buildMux.Unlock()
+		if err := refreshInputs(i...); err != nil {
+			return nil, err
+		}
+		return doBuffer(h, o, nil, i...)
+	}
+
+	return func() (*bytes.Buffer, error) {
+		sanitizeOnce.Do(func() {
+			for _, item := range i {
+				if sanitizeErr = item.sanitize(); sanitizeErr != nil {
+					return
+				}
+			}
+		})
+		if sanitizeErr != nil {
+			return nil, sanitizeErr
+		}
+
+		key, ok := inputsKey(i...)
+		if !ok { // 有文件无法访问，不缓存。
+			return build()
+		}
+
+		for {
+			e, loaded := cache.load(key)
+			if loaded {
+				<-e.done
+				if e.err != nil {
+					return nil, e.err
+				}
+				if ttl <= 0 || time.Now().Before(e.expires) {
+					return bytes.NewBuffer(append([]byte(nil), e.data...)), nil
+				}
+
+				cache.remove(e) // 已经过期，删除之后重新生成。
+				continue
+			}
+
+			buf, err := build()
+			if err != nil {
+				e.err = err
+				close(e.done)
+				cache.remove(e) // 出错的内容不缓存
+				return nil, err
+			}
+			e.data = append([]byte(nil), buf.Bytes()...)
+			e.expires = time.Now().Add(ttl)
+			close(e.done)
+
+			return buf, nil
+		}
+	}
+}
+
+// 根据所有输入文件的路径及修改时间生成缓存的键值
+//
+// 每次都会按 Input 的规则重新查找文件，新增或删除的文件同样会改变键值。
+// i 必须是已经调用过 sanitize 的对象，如果有文件无法访问，则返回 false。
+func inputsKey(i ...*Input) (key string, ok bool) {
+	hash := sha256.New()
+	for _, item := range i {
+		if item.reader != nil { // 内容在第一次使用时已经读取，不会再改变。
+			continue
+		}
+
+		paths, _, err := item.walk()
+		if err != nil {
+			return "", false
+		}
+
+		for _, p := range paths {
+			file, err := p.File()
+			if err != nil {
+				return "", false
+			}
+
+			stat, err := os.Stat(file)
+			if err != nil {
+				return "", false
+			}
+
+			hash.Write([]byte(p))
+			hash.Write([]byte{0})
+			hash.Write([]byte(strconv.FormatInt(stat.ModTime().UnixNano(), 10)))
+			hash.Write([]byte{0})
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), true
+}
+
+// 重新查找 i 中的文件列表
+//
+// i 必须是已经调用过 sanitize 的对象，由 InputFromReader 创建的对象不作处理。
+func refreshInputs(i ...*Input) error {
+	for _, item := range i {
+		if item.reader != nil {
+			continue
+		}
+		if err := item.recursivePath(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
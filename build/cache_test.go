@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+)
+
+func TestBufferCache(t *testing.T) {
+	a := assert.New(t, false)
+
+	c := &bufferCache{}
+	e1, loaded := c.load("k1")
+	a.False(loaded).NotNil(e1)
+	e, loaded := c.load("k1")
+	a.True(loaded).Equal(e, e1)
+
+	// 新的键值会替换旧的内容
+	e2, loaded := c.load("k2")
+	a.False(loaded).NotEqual(e2, e1).
+		Equal(c.key, "k2").Equal(c.entry, e2)
+	e, loaded = c.load("k1")
+	a.False(loaded).NotEqual(e, e1)
+
+	// 只删除当前的缓存项
+	c.remove(e2)
+	a.Equal(c.key, "k1").Equal(c.entry, e)
+	c.remove(e)
+	a.Equal(c.key, "").Nil(c.entry)
+}
+
+func TestCachedBuffer(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "doc.go")
+	write := func(title string, mod time.Time) {
+		a.NotError(os.WriteFile(path, []byte(`// <apidoc version="1.0.0">
+// <title>`+title+`</title>
+// <mimetype>application/json</mimetype>
+// </apidoc>
+`), os.ModePerm))
+		a.NotError(os.Chtimes(path, mod, mod))
+	}
+	mod := time.Now().Add(-time.Hour)
+	write("title1", mod)
+
+	rslt := messagetest.NewMessageHandler()
+	i := &Input{Lang: "go", Dir: core.FileURI(dir)}
+	o := &Output{Path: core.FileURI(filepath.Join(dir, "apidoc.xml"))}
+	buffer := CachedBuffer(rslt.Handler, 200*time.Millisecond, o, i)
+	get := func() string {
+		buf, err := buffer()
+		a.NotError(err).NotNil(buf)
+		return buf.String()
+	}
+
+	// 并发访问
+	bufs := make([]string, 10)
+	wg := &sync.WaitGroup{}
+	for index := range bufs {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			bufs[index] = get()
+		}(index)
+	}
+	wg.Wait()
+	for _, buf := range bufs {
+		a.Contains(buf, "title1").Equal(buf, bufs[0])
+	}
+
+	// 修改返回的内容，不影响缓存
+	buf, err := buffer()
+	a.NotError(err)
+	buf.Reset()
+	a.Contains(get(), "title1")
+
+	// 修改时间未变化，采用缓存内容
+	write("title2", mod)
+	a.Contains(get(), "title1")
+
+	// 修改时间变化，缓存失效
+	mod = mod.Add(time.Minute)
+	write("title2", mod)
+	a.Contains(get(), "title2")
+
+	// 新增文件，缓存失效
+	a.NotError(os.WriteFile(filepath.Join(dir, "api.go"), []byte(`// <api method="GET" summary="new-api">
+// <path path="/users" />
+// <response status="200" type="string" />
+// </api>
+`), os.ModePerm))
+	a.Contains(get(), "new-api")
+
+	// 过期
+	write("title3", mod)
+	a.Contains(get(), "title2")
+	time.Sleep(300 * time.Millisecond)
+	a.Contains(get(), "title3")
+
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	// 配置错误
+	rslt = messagetest.NewMessageHandler()
+	buffer = CachedBuffer(rslt.Handler, 0, o, &Input{Lang: "not-exists", Dir: core.FileURI(dir)})
+	buf, err = buffer()
+	a.Error(err).Nil(buf)
+	rslt.Handler.Stop()
+}
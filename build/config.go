@@ -4,8 +4,16 @@ package build
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/issue9/version"
 	"gopkg.in/yaml.v3"
@@ -21,11 +29,16 @@ import (
 // 直到找到第一个相符的文件，或是在没有时出错。
 //
 // 在生成配置文件时，会直接拿第一个元素的值作为文件名。
+// JSON 是 YAML 的子集，所以 .apidoc.json 同样可以按 YAML 的方式进行解析。
 var allowConfigFilenames = []string{
 	".apidoc.yaml",
 	".apidoc.yml",
+	jsonConfigFilename,
 }
 
+// SaveJSON 生成的配置文件名
+const jsonConfigFilename = ".apidoc.json"
+
 // Config 配置文件映身的结构
 type Config struct {
 	// 文档的版本信息
@@ -40,6 +53,37 @@ type Config struct {
 
 	// 输出配置项
 	Output *Output `yaml:"output"`
+
+	// 语法之外的规范性检测
+	Lint *Lint `yaml:"lint,omitempty"`
+
+	// 需要合并到当前配置中的其它配置文件
+	//
+	// 相对路径以当前配置文件所在的目录为基准，加载时按顺序通过 MergeWith 合并，
+	// 被合并的配置文件中的 overrides 字段会被忽略，
+	// 其中的 inputs.dir 和 output.path 等相对路径以其自身所在的目录为基准。
+	Overrides []string `yaml:"overrides,omitempty"`
+
+	// 配置文件的创建时间
+	//
+	// 由 Save 等方法在第一次保存时写入，之后不会再修改。
+	CreatedAt time.Time `yaml:"created-at,omitempty"`
+
+	// 配置文件的最后保存时间
+	//
+	// 每次调用 Save 等方法时都会更新为当前时间。
+	UpdatedAt time.Time `yaml:"updated-at,omitempty"`
+
+	// 是否缓存 Buffer 的结果
+	//
+	// 缓存以所有输入文件的路径及修改时间作为键值，新增、删除或修改文件之后缓存会自动失效，
+	// 也可以调用 Invalidate 手动清除缓存。
+	Cache bool `yaml:"-"`
+
+	// 文件有修改时需要重新生成缓存，sync.Once 无法重置，所以采用 sync.Mutex。
+	cacheMux sync.Mutex
+	cache    []byte
+	cacheKey string
 }
 
 // LoadConfig 加载指定目录下的配置文件
@@ -69,6 +113,27 @@ func LoadConfig(wd core.URI) (*Config, error) {
 	return nil, core.WithError(os.ErrNotExist).WithField(field)
 }
 
+// LoadConfigJSON 从 r 中加载 JSON 格式的配置内容
+//
+// 字段名称与 YAML 格式的配置文件相同，但不会处理 overrides 字段。
+// wd 为配置内容中相对路径的基准目录，与 LoadConfig 相同，
+// 返回之前会检测各个字段的值是否合法，并将相对路径转换成基于 wd 的路径。
+func LoadConfigJSON(wd core.URI, r io.Reader) (*Config, error) {
+	if scheme, _ := wd.Parse(); scheme != "" && scheme != core.SchemeFile {
+		return nil, locale.NewError(locale.ErrInvalidURIScheme, scheme)
+	}
+
+	cfg := &Config{}
+	if err := json.NewDecoder(r).Decode(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.sanitize(wd); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func loadFile(wd, path core.URI) (*Config, error) {
 	data, err := path.ReadAll(nil)
 	if err != nil {
@@ -80,6 +145,34 @@ func loadFile(wd, path core.URI) (*Config, error) {
 		return nil, (core.Location{URI: path}).WithError(err)
 	}
 
+	for index, o := range cfg.Overrides {
+		field := "overrides[" + strconv.Itoa(index) + "]"
+
+		p, err := abs(core.URI(o), wd)
+		if err != nil {
+			return nil, (core.Location{URI: path}).WithError(err).WithField(field)
+		}
+
+		data, err := p.ReadAll(nil)
+		if err != nil {
+			return nil, (core.Location{URI: path}).WithError(err).WithField(field)
+		}
+
+		other := &Config{}
+		if err = yaml.Unmarshal(data, other); err != nil {
+			return nil, (core.Location{URI: p}).WithError(err)
+		}
+
+		// 覆盖文件中的相对路径以其自身所在的目录为基准
+		if err = other.absPaths(p); err != nil {
+			return nil, err
+		}
+
+		if err = cfg.MergeWith(other); err != nil {
+			return nil, (core.Location{URI: p}).WithError(err).WithField("version")
+		}
+	}
+
 	if err := cfg.sanitize(wd); err != nil {
 		return nil, err
 	}
@@ -87,6 +180,32 @@ func loadFile(wd, path core.URI) (*Config, error) {
 	return cfg, nil
 }
 
+// 将 cfg 中非空的相对路径转换成以 file 所在目录为基准的绝对路径
+func (cfg *Config) absPaths(file core.URI) error {
+	path, err := file.File()
+	if err != nil {
+		return (core.Location{URI: file}).WithError(err)
+	}
+	dir := core.FileURI(filepath.Dir(path))
+
+	for index, i := range cfg.Inputs {
+		if i == nil || i.Dir == "" {
+			continue
+		}
+		if i.Dir, err = abs(i.Dir, dir); err != nil {
+			return (core.Location{URI: file}).WithError(err).WithField("inputs[" + strconv.Itoa(index) + "].dir")
+		}
+	}
+
+	if cfg.Output != nil && cfg.Output.Path != "" && !isRemote(cfg.Output.Path) {
+		if cfg.Output.Path, err = abs(cfg.Output.Path, dir); err != nil {
+			return (core.Location{URI: file}).WithError(err).WithField("output.path")
+		}
+	}
+
+	return nil
+}
+
 // file 表示出错时的文件定位
 func (cfg *Config) sanitize(wd core.URI) error {
 	file := wd.Append(allowConfigFilenames[0])
@@ -118,59 +237,499 @@ func (cfg *Config) sanitize(wd core.URI) error {
 		if i.Dir, err = abs(i.Dir, wd); err != nil {
 			return (core.Location{URI: file}).WithError(err).WithField(field + ".path")
 		}
+	}
+	if err := sanitizeInputs(cfg.Inputs); err != nil {
+		if serr, ok := err.(*core.Error); ok {
+			serr.Location.URI = file
+		}
+		return err
+	}
+
+	if !isRemote(cfg.Output.Path) {
+		if cfg.Output.Path, err = abs(cfg.Output.Path, wd); err != nil {
+			return (core.Location{URI: file}).WithError(err).WithField("output.path")
+		}
+	}
+	cfg.Output.wd = wd
+	return cfg.Output.sanitize()
+}
+
+// 检测 inputs 中的每一个元素，返回的错误信息中 Field 以 inputs[index] 开头。
+func sanitizeInputs(inputs []*Input) error {
+	for index, i := range inputs {
+		field := "inputs[" + strconv.Itoa(index) + "]"
+
+		if i == nil {
+			return core.NewError(locale.ErrIsEmpty, field).WithField(field)
+		}
 
 		if err := i.sanitize(); err != nil {
 			if serr, ok := err.(*core.Error); ok {
-				serr.Location.URI = file
-				serr.Field = field + serr.Field
+				serr.Field = field + "." + serr.Field
 			}
 			return err
 		}
 	}
+	return nil
+}
+
+// ToInputs 返回经过检测的 Inputs 副本
+//
+// 返回值可以直接传递给 Build、Buffer 等函数，对其的修改不会影响 cfg 本身。
+// 相对路径以程序的工作目录为基准。
+func (cfg *Config) ToInputs() ([]*Input, error) {
+	if len(cfg.Inputs) == 0 {
+		return nil, core.NewError(locale.ErrIsEmpty, "inputs").WithField("inputs")
+	}
 
-	if cfg.Output.Path, err = abs(cfg.Output.Path, wd); err != nil {
-		return (core.Location{URI: file}).WithError(err).WithField("output.path")
+	inputs := make([]*Input, 0, len(cfg.Inputs))
+	for _, i := range cfg.Inputs {
+		inputs = append(inputs, i.clone())
 	}
-	return cfg.Output.sanitize()
+
+	if err := sanitizeInputs(inputs); err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+// ToOutput 返回经过检测的 Output 副本
+//
+// 返回值可以直接传递给 Build、Buffer 等函数，对其的修改不会影响 cfg 本身。
+func (cfg *Config) ToOutput() (*Output, error) {
+	if cfg.Output == nil {
+		return nil, core.NewError(locale.ErrIsEmpty, "output").WithField("output")
+	}
+
+	o := cfg.Output.clone()
+	if err := o.sanitize(); err != nil {
+		if serr, ok := err.(*core.Error); ok {
+			serr.Field = "output." + serr.Field
+		}
+		return nil, err
+	}
+	return o, nil
+}
+
+// MarshalJSON json.Marshaler
+//
+// 字段名称与 YAML 格式的配置文件相同。
+func (cfg *Config) MarshalJSON() ([]byte, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err = yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON json.Unmarshaler
+//
+// 字段名称与 YAML 格式的配置文件相同。data 必须是合法的 JSON 内容，
+// 不接受其它 YAML 格式的内容。
+func (cfg *Config) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, cfg)
+}
+
+// Clone 返回当前配置的深层复制
+//
+// 返回的对象与 cfg 之间不再有共享的切片和指针，可以安全地修改。
+// 已经处理过的状态也会一并复制，但 Buffer 的缓存内容不会被复制。
+func (cfg *Config) Clone() *Config {
+	c := &Config{
+		Version:   cfg.Version,
+		Overrides: cloneStrings(cfg.Overrides),
+		CreatedAt: cfg.CreatedAt,
+		UpdatedAt: cfg.UpdatedAt,
+		Cache:     cfg.Cache,
+	}
+
+	if cfg.Inputs != nil {
+		c.Inputs = make([]*Input, 0, len(cfg.Inputs))
+		for _, i := range cfg.Inputs {
+			c.Inputs = append(c.Inputs, i.clone())
+		}
+	}
+
+	if cfg.Output != nil {
+		c.Output = cfg.Output.clone()
+	}
+
+	if cfg.Lint != nil {
+		l := *cfg.Lint
+		c.Lint = &l
+	}
+
+	return c
+}
+
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(s)), s...)
+}
+
+// MergeWith 将 other 的内容合并到当前配置中
+//
+// 合并规则如下：
+//  - 标量值以 other 中的非零值为准；
+//  - Inputs 追加至当前的 Inputs 之后；
+//  - Output.Tags 和 Output.SkipServers 取两者的并集；
+//  - Lint 中的各项检测，只要有一方启用即启用；
+//  - Overrides 不参与合并；
+// 如果两者的版本号不兼容，则返回错误。
+func (cfg *Config) MergeWith(other *Config) error {
+	if other == nil {
+		return nil
+	}
+
+	if other.Version != "" {
+		if cfg.Version != "" {
+			compatible, err := version.SemVerCompatible(cfg.Version, other.Version)
+			if err != nil {
+				return err
+			}
+			if !compatible {
+				return locale.NewError(locale.VersionInCompatible)
+			}
+		}
+		cfg.Version = other.Version
+	}
+
+	cfg.Inputs = append(cfg.Inputs, other.Inputs...)
+
+	if other.Output != nil {
+		if cfg.Output == nil {
+			cfg.Output = &Output{}
+		}
+		cfg.Output.mergeWith(other.Output)
+	}
+
+	if other.Lint != nil {
+		if cfg.Lint == nil {
+			cfg.Lint = &Lint{}
+		}
+		cfg.Lint.NamingConventions = cfg.Lint.NamingConventions || other.Lint.NamingConventions
+	}
+
+	return nil
+}
+
+// Normalize 将配置内容转换成规范的格式
+//
+// 处理规则如下：
+//  - Inputs 按 Lang 排序，Lang 相同的再按 Dir 排序；
+//  - Output.Tags 和 Output.SkipServers 按字母顺序排序；
+//  - 去掉可选字段中的零值，比如空的切片、未启用任何检测的 Lint 等；
+//  - 去掉与默认值相同的字段，比如 Output.Sort、Output.Indent 和 Output.Style；
+// 处理之前会检测各个字段的值是否合法，但不会检测 inputs 中的目录是否存在。
+// 多次调用的结果是相同的，可以在 Save 之前调用，使生成的文件在版本控制中保持稳定的内容。
+func (cfg *Config) Normalize() error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+
+	sort.SliceStable(cfg.Inputs, func(i, j int) bool {
+		ii, jj := cfg.Inputs[i], cfg.Inputs[j]
+		if ii.Lang != jj.Lang {
+			return ii.Lang < jj.Lang
+		}
+		return ii.Dir < jj.Dir
+	})
+	for _, i := range cfg.Inputs {
+		i.Exts = emptyToNil(i.Exts)
+		i.Ignores = emptyToNil(i.Ignores)
+		if len(i.LangAlias) == 0 {
+			i.LangAlias = nil
+		}
+	}
+
+	o := cfg.Output
+	o.Tags = emptyToNil(o.Tags)
+	o.SkipServers = emptyToNil(o.SkipServers)
+	sort.Strings(o.Tags)
+	sort.Strings(o.SkipServers)
+	// 去掉与默认值相同的字段
+	if o.CheckWritable != nil && *o.CheckWritable {
+		o.CheckWritable = nil
+	}
+	if o.Sort == SortPath {
+		o.Sort = ""
+	}
+	if o.Indent == "\t" {
+		o.Indent = ""
+	}
+	if o.Style != "" && o.Style == o.defaultStyle() {
+		o.Style = ""
+	}
+
+	if cfg.Lint != nil && !cfg.Lint.NamingConventions {
+		cfg.Lint = nil
+	}
+	cfg.Overrides = emptyToNil(cfg.Overrides)
+
+	return nil
+}
+
+// 检测配置项的值是否合法
+//
+// 与 sanitize 不同，不会修改任何内容，也不需要工作目录，
+// 所以不会检测 inputs 中的目录是否存在。
+func (cfg *Config) validate() error {
+	compatible, err := version.SemVerCompatible(ast.Version, cfg.Version)
+	if err != nil {
+		return core.WithError(err).WithField("version")
+	}
+	if !compatible {
+		return core.NewError(locale.VersionInCompatible).WithField("version")
+	}
+
+	if len(cfg.Inputs) == 0 {
+		return core.NewError(locale.ErrIsEmpty, "inputs").WithField("inputs")
+	}
+	for index, i := range cfg.Inputs {
+		field := "inputs[" + strconv.Itoa(index) + "]"
+		if i == nil {
+			return core.NewError(locale.ErrIsEmpty, field).WithField(field)
+		}
+		if err := i.validate(); err != nil {
+			if serr, ok := err.(*core.Error); ok {
+				serr.Field = field + "." + serr.Field
+			}
+			return err
+		}
+	}
+
+	if cfg.Output == nil {
+		return core.NewError(locale.ErrIsEmpty, "output").WithField("output")
+	}
+	if err := cfg.Output.clone().sanitize(); err != nil {
+		if serr, ok := err.(*core.Error); ok {
+			serr.Field = "output." + serr.Field
+		}
+		return err
+	}
+
+	return nil
+}
+
+func emptyToNil(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+// SaveOptions Config.SaveWithOptions 的设置项
+type SaveOptions struct {
+	// 如果目标文件已经存在，在覆盖之前将其内容保存至同目录下的 <filename>.bak 文件。
+	Backup bool
+
+	// 先写入同目录下的临时文件，再通过重命名替换目标文件，
+	// 防止进程中断等原因造成目标文件内容损坏。
+	AtomicWrite bool
 }
 
 // Save 将内容保存至 wd 目录下的 .apidoc.yaml 文件
 //
 // 保存时会将各个与路径相关的字段尽量改成与 wd 相关的相对路径。
-func (cfg *Config) Save(wd core.URI) (err error) {
-	for _, input := range cfg.Inputs { // 调整成相对路径
-		if input.Dir, err = rel(input.Dir, wd); err != nil {
-			return err
+// 文件会以原子方式写入，如果需要备份原有的文件，可以使用 SaveWithOptions。
+func (cfg *Config) Save(wd core.URI) error {
+	return cfg.SaveWithOptions(wd, &SaveOptions{AtomicWrite: true})
+}
+
+// SaveWithOptions 将内容保存至 wd 目录下的 .apidoc.yaml 文件
+//
+// o 为空表示直接覆盖目标文件，其它与 Save 相同。
+// wd 为 http 或 https 地址时，以 PUT 请求写入，o 中的设置不再有效。
+func (cfg *Config) SaveWithOptions(wd core.URI, o *SaveOptions) error {
+	return cfg.save(wd, allowConfigFilenames[0], yaml.Marshal, o)
+}
+
+// SaveJSON 将内容以 JSON 格式保存至 wd 目录下的 .apidoc.json 文件
+//
+// 除了文件格式之外，其它与 Save 相同。
+func (cfg *Config) SaveJSON(wd core.URI) error {
+	marshal := func(v interface{}) ([]byte, error) {
+		data, err := json.MarshalIndent(v, "", "\t")
+		if err != nil {
+			return nil, err
 		}
+		return append(data, '\n'), nil
 	}
+	return cfg.save(wd, jsonConfigFilename, marshal, &SaveOptions{AtomicWrite: true})
+}
 
-	if cfg.Output.Path != "" { // 调整成相对路径
-		if cfg.Output.Path, err = rel(cfg.Output.Path, wd); err != nil {
-			return err
+// 将 cfg 保存至 wd 下的 filename 文件
+//
+// 路径的调整在 cfg 的副本上进行，cfg 中只有 CreatedAt 和 UpdatedAt 会被修改。
+func (cfg *Config) save(wd core.URI, filename string, marshal func(interface{}) ([]byte, error), o *SaveOptions) (err error) {
+	c := cfg.Clone()
+
+	if !isRemote(wd) { // 远程地址无法计算相对路径
+		for _, input := range c.Inputs { // 调整成相对路径
+			if input.Dir, err = rel(input.Dir, wd); err != nil {
+				return err
+			}
+		}
+
+		if c.Output.Path != "" && !isRemote(c.Output.Path) { // 调整成相对路径
+			if c.Output.Path, err = rel(c.Output.Path, wd); err != nil {
+				return err
+			}
 		}
 	}
 
-	data, err := yaml.Marshal(cfg)
+	now := time.Now().Truncate(time.Second) // 精确到秒，输出为 RFC3339 格式
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = now
+	}
+
+	c.UpdatedAt = now
+
+	data, err := marshal(c)
+	if err != nil {
+		return err
+	}
+
+	uri := wd.Append(filename)
+
+	if o == nil || (!o.Backup && !o.AtomicWrite) || isRemote(uri) { // 远程地址不支持备份和原子写入
+		err = uri.WriteAll(data, os.ModePerm)
+	} else {
+		var path string
+		if path, err = uri.File(); err == nil {
+			err = writeFile(path, data, o)
+		}
+	}
 	if err != nil {
 		return err
 	}
-	return wd.Append(allowConfigFilenames[0]).WriteAll(data)
+
+	cfg.CreatedAt, cfg.UpdatedAt = c.CreatedAt, c.UpdatedAt
+	return nil
+}
+
+
+// 根据 o 的设置将 data 写入 path
+func writeFile(path string, data []byte, o *SaveOptions) error {
+	mode := fs.FileMode(0o644)
+	stat, err := os.Stat(path)
+	switch {
+	case err == nil:
+		mode = stat.Mode().Perm()
+		if o.Backup {
+			old, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if err = os.WriteFile(path+".bak", old, mode); err != nil {
+				return err
+			}
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	if !o.AtomicWrite {
+		return os.WriteFile(path, data, mode)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp) // 重命名成功之后，该操作会返回错误，可以忽略。
+
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
 }
 
 // Build 解析文档并输出文档内容
 //
 // 具体信息可参考 Build 函数的相关文档。
 func (cfg *Config) Build(h *core.MessageHandler) {
-	if err := Build(h, cfg.Output, cfg.Inputs...); err != nil {
+	cfg.BuildStats(h)
+}
+
+// BuildStats 与 Build 相同，但会返回文档的统计信息
+func (cfg *Config) BuildStats(h *core.MessageHandler) *Stats {
+	d, err := doBuild(h, cfg.Output, cfg.Lint, cfg.Inputs...)
+	if err != nil {
 		panic(err) // 由 loadConfig 保证配置项的正确，如果还出错则直接 panic
 	}
+
+	stats := Stats(d.Stats())
+	return &stats
 }
 
 // Buffer 根据 wd 目录下的配置文件生成文档内容并保存至内存
 //
 // 具体信息可参考 Buffer 函数的相关文档。
+//
+// 如果 Cache 为 true，在输入文件没有修改的情况下，会直接返回之前的结果，
+// 此时不会再向 h 输出任何信息。
 func (cfg *Config) Buffer(h *core.MessageHandler) *bytes.Buffer {
-	buf, err := Buffer(h, cfg.Output, cfg.Inputs...)
+	if !cfg.Cache {
+		return cfg.buffer(h)
+	}
+
+	cfg.cacheMux.Lock()
+	defer cfg.cacheMux.Unlock()
+
+	key, ok := inputsKey(cfg.Inputs...)
+	if ok && cfg.cache != nil && key == cfg.cacheKey {
+		return bytes.NewBuffer(append([]byte(nil), cfg.cache...))
+	}
+
+	if ok { // 文件列表可能已经改变，需要与键值保持一致。
+		if err := refreshInputs(cfg.Inputs...); err != nil {
+			h.Error(err)
+			ok = false
+		}
+	}
+	buf := cfg.buffer(h)
+	if ok {
+		cfg.cache = append([]byte(nil), buf.Bytes()...)
+		cfg.cacheKey = key
+	} else {
+		cfg.cache = nil
+	}
+	return buf
+}
+
+// Invalidate 清除 Buffer 的缓存内容
+func (cfg *Config) Invalidate() {
+	cfg.cacheMux.Lock()
+	cfg.cache = nil
+	cfg.cacheMux.Unlock()
+}
+
+func (cfg *Config) buffer(h *core.MessageHandler) *bytes.Buffer {
+	buf, err := doBuffer(h, cfg.Output, cfg.Lint, cfg.Inputs...)
 	if err != nil {
 		panic(err) // 由 loadConfig 保证配置项的正确，如果还出错则直接 panic
 	}
@@ -180,7 +739,16 @@ func (cfg *Config) Buffer(h *core.MessageHandler) *bytes.Buffer {
 
 // CheckSyntax 执行对语法内容的测试
 func (cfg *Config) CheckSyntax(h *core.MessageHandler) {
-	if err := CheckSyntax(h, cfg.Inputs...); err != nil {
+	cfg.CheckSyntaxResult(h)
+}
+
+// CheckSyntaxResult 执行对语法内容的测试并返回错误和警告信息的数量
+//
+// 具体信息可参考 CheckSyntaxResult 函数的相关文档。
+func (cfg *Config) CheckSyntaxResult(h *core.MessageHandler) (errs, warns int) {
+	errs, warns, err := checkSyntax(h, cfg.Lint, cfg.Inputs...)
+	if err != nil {
 		panic(err) // 由 loadConfig 保证配置项的正确，如果还出错则直接 panic
 	}
+	return errs, warns
 }
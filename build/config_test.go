@@ -3,14 +3,24 @@
 package build
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/issue9/assert/v2"
 	"gopkg.in/yaml.v3"
 
 	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/docs"
 )
 
@@ -43,6 +53,62 @@ func TestLoadConfig(t *testing.T) {
 	a.Error(err).Nil(cfg)
 }
 
+func TestLoadConfigJSON(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	wd := core.FileURI(dir)
+	a.NotError(os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), os.ModePerm))
+
+	cfg := &Config{
+		Version: ast.Version,
+		Inputs:  []*Input{{Lang: "go", Dir: ".", Recursive: true, Exts: []string{".go"}}},
+		Output:  &Output{Path: "apidoc.xml", Tags: []string{"t1"}, Namespace: true},
+	}
+	data, err := json.Marshal(cfg)
+	a.NotError(err).NotNil(data)
+	a.Contains(string(data), `"inputs"`).NotContains(string(data), `"Inputs"`)
+
+	// 相对路径以 wd 为基准
+	cfg2, err := LoadConfigJSON(wd, bytes.NewReader(data))
+	a.NotError(err).NotNil(cfg2)
+	a.Equal(cfg2.Inputs[0].Dir, wd).
+		Equal(cfg2.Output.Path, wd.Append("apidoc.xml")).
+		Equal(cfg2.Output.Tags, []string{"t1"})
+
+	// 与 LoadConfig 的结果相同
+	a.NotError(os.WriteFile(filepath.Join(dir, jsonConfigFilename), data, os.ModePerm))
+	cfg3, err := LoadConfig(wd)
+	a.NotError(err).NotNil(cfg3)
+	a.Equal(cfg3.Inputs[0].Dir, cfg2.Inputs[0].Dir).Equal(cfg3.Output.Path, cfg2.Output.Path)
+
+	// 可以正常构建
+	rslt := messagetest.NewMessageHandler()
+	cfg2.Build(rslt.Handler)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	// 目录不存在
+	cfg2, err = LoadConfigJSON(wd, strings.NewReader(`{"version":"`+ast.Version+`","inputs":[{"lang":"go","dir":"./not-exists"}],"output":{"path":"./apidoc.xml"}}`))
+	a.Error(err).Nil(cfg2)
+
+	// 语法错误
+	cfg2, err = LoadConfigJSON(wd, strings.NewReader(`{"version":`))
+	a.Error(err).Nil(cfg2)
+
+	// 缺少 output
+	cfg2, err = LoadConfigJSON(wd, strings.NewReader(`{"version":"`+ast.Version+`","inputs":[{"lang":"go","dir":"."}]}`))
+	a.Error(err).Nil(cfg2)
+
+	// 远程目录
+	cfg2, err = LoadConfigJSON("https://example.com", bytes.NewReader(data))
+	a.Error(err).Nil(cfg2)
+
+	// 非 JSON 格式的内容
+	a.Error(json.Unmarshal([]byte("version: "+ast.Version), &Config{}))
+	a.Error((&Config{}).UnmarshalJSON([]byte("version: " + ast.Version)))
+}
+
 func TestLoadFile(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -53,6 +119,266 @@ func TestLoadFile(t *testing.T) {
 	a.Error(err).Nil(cfg)
 }
 
+func TestLoadFile_overrides(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	wd := core.FileURI(dir)
+	a.NotError(wd.Append("main.go").WriteAll([]byte("package main"), os.ModePerm))
+	a.NotError(wd.Append("index.php").WriteAll([]byte("<?php"), os.ModePerm))
+	a.NotError(wd.Append(allowConfigFilenames[0]).WriteAll([]byte(`version: `+ast.Version+`
+inputs:
+  - lang: go
+    dir: .
+output:
+  path: apidoc.xml
+  tags: [t1]
+overrides:
+  - local.yaml
+`), os.ModePerm))
+	a.NotError(wd.Append("local.yaml").WriteAll([]byte(`inputs:
+  - lang: php
+    dir: .
+output:
+  type: openapi+json
+  tags: [t1, t2]
+`), os.ModePerm))
+
+	cfg, err := loadFile(wd, wd.Append(allowConfigFilenames[0]))
+	a.NotError(err).NotNil(cfg)
+	a.Equal(2, len(cfg.Inputs)).
+		Equal(cfg.Inputs[1].Lang, "php").
+		Equal(cfg.Output.Type, OpenapiJSON).
+		Equal(cfg.Output.Tags, []string{"t1", "t2"}).
+		Equal(cfg.Output.Path, wd.Append("apidoc.xml"))
+
+	// 覆盖文件中的相对路径以其自身所在的目录为基准
+	conf := wd.Append("conf")
+	a.NotError(os.Mkdir(filepath.Join(dir, "conf"), os.ModePerm))
+	a.NotError(conf.Append("index.php").WriteAll([]byte("<?php"), os.ModePerm))
+	a.NotError(conf.Append("local.yaml").WriteAll([]byte(`inputs:
+  - lang: php
+    dir: .
+output:
+  path: apidoc.xml
+`), os.ModePerm))
+	a.NotError(wd.Append(allowConfigFilenames[0]).WriteAll([]byte(`version: `+ast.Version+`
+inputs:
+  - lang: go
+    dir: .
+output:
+  path: apidoc.xml
+overrides:
+  - conf/local.yaml
+`), os.ModePerm))
+	cfg, err = loadFile(wd, wd.Append(allowConfigFilenames[0]))
+	a.NotError(err).NotNil(cfg)
+	a.Equal(2, len(cfg.Inputs)).
+		Equal(cfg.Inputs[0].Dir, wd).
+		Equal(cfg.Inputs[1].Dir, conf).
+		Equal(cfg.Output.Path, conf.Append("apidoc.xml"))
+
+	// 不存在的文件
+	a.NotError(os.Remove(filepath.Join(dir, "conf", "local.yaml")))
+	cfg, err = loadFile(wd, wd.Append(allowConfigFilenames[0]))
+	a.Error(err).Nil(cfg)
+}
+
+func TestConfig_MergeWith(t *testing.T) {
+	a := assert.New(t, false)
+
+	cfg := &Config{
+		Version: "6.0.0",
+		Inputs:  []*Input{{Lang: "go"}},
+		Output: &Output{
+			Type:        APIDocXML,
+			Path:        "apidoc.xml",
+			Namespace:   true,
+			Tags:        []string{"t1", "t2"},
+			SkipServers: []string{"s1"},
+		},
+	}
+	a.NotError(cfg.MergeWith(nil))
+	a.NotError(cfg.MergeWith(&Config{
+		Version: "6.1.0",
+		Inputs:  []*Input{{Lang: "php"}},
+		Output: &Output{
+			Type:         OpenapiYAML,
+			PathTemplate: "{{.Title}}.yaml",
+			Style:        "./apidoc.xsl",
+			Tags:         []string{"t2", "t3"},
+			SkipServers:  []string{"s2"},
+		},
+	}))
+	a.Equal(cfg.Version, "6.1.0").
+		Equal(2, len(cfg.Inputs)).
+		Equal(cfg.Inputs[0].Lang, "go").
+		Equal(cfg.Inputs[1].Lang, "php").
+		Equal(cfg.Output, &Output{
+			Type:         OpenapiYAML,
+			Path:         "apidoc.xml", // 未冲突的字段保持不变
+			PathTemplate: "{{.Title}}.yaml",
+			Style:        "./apidoc.xsl",
+			Namespace:    true,
+			Tags:         []string{"t1", "t2", "t3"},
+			SkipServers:  []string{"s1", "s2"},
+		})
+
+	// 当前配置的 output 为空
+	cfg = &Config{}
+	a.NotError(cfg.MergeWith(&Config{Output: &Output{Path: "apidoc.xml"}}))
+	a.Equal(cfg.Output, &Output{Path: "apidoc.xml"}).Empty(cfg.Version)
+
+	// lint
+	cfg = &Config{}
+	a.NotError(cfg.MergeWith(&Config{Lint: &Lint{NamingConventions: true}}))
+	a.NotError(cfg.MergeWith(&Config{Lint: &Lint{}}))
+	a.True(cfg.Lint.NamingConventions)
+
+	// 版本不兼容
+	cfg = &Config{Version: "6.0.0"}
+	a.Error(cfg.MergeWith(&Config{Version: "7.0.0"}))
+	a.Error(cfg.MergeWith(&Config{Version: "invalid"}))
+	a.Equal(cfg.Version, "6.0.0")
+}
+
+func TestConfig_Clone(t *testing.T) {
+	a := assert.New(t, false)
+
+	cfg := &Config{
+		Version: ast.Version,
+		Inputs: []*Input{{
+			Lang:      "go",
+			Dir:       "./testdata",
+			Exts:      []string{".go"},
+			Ignores:   []string{"vendor"},
+			LangAlias: map[string]string{".tpl": "go"},
+		}},
+		Output: &Output{
+			Path:        "./apidoc.xml",
+			Tags:        []string{"t1"},
+			SkipServers: []string{"s1"},
+		},
+		Lint:      &Lint{NamingConventions: true},
+		Overrides: []string{"local.yaml"},
+	}
+
+	c := cfg.Clone()
+	a.Equal(c, cfg)
+
+	c.Inputs[0].Lang = "php"
+	c.Inputs[0].Exts[0] = ".php"
+	c.Inputs[0].Ignores = append(c.Inputs[0].Ignores, "node_modules")
+	c.Inputs[0].LangAlias[".tpl"] = "php"
+	c.Inputs = append(c.Inputs, &Input{})
+	c.Output.Tags[0] = "t2"
+	c.Output.SkipServers[0] = "s2"
+	c.Output.Path = "./openapi.json"
+	c.Lint.NamingConventions = false
+	c.Overrides[0] = "dev.yaml"
+
+	a.Equal(1, len(cfg.Inputs)).
+		Equal(cfg.Inputs[0].Lang, "go").
+		Equal(cfg.Inputs[0].Exts, []string{".go"}).
+		Equal(cfg.Inputs[0].Ignores, []string{"vendor"}).
+		Equal(cfg.Inputs[0].LangAlias, map[string]string{".tpl": "go"}).
+		Equal(cfg.Output.Tags, []string{"t1"}).
+		Equal(cfg.Output.SkipServers, []string{"s1"}).
+		Equal(cfg.Output.Path, "./apidoc.xml").
+		True(cfg.Lint.NamingConventions).
+		Equal(cfg.Overrides, []string{"local.yaml"})
+
+	// 空值
+	c = (&Config{}).Clone()
+	a.Nil(c.Inputs).Nil(c.Output).Nil(c.Lint)
+}
+
+func TestConfig_Normalize(t *testing.T) {
+	a := assert.New(t, false)
+
+	checkWritable := true
+	cfg := &Config{
+		Version: ast.Version,
+		Inputs: []*Input{
+			{Lang: "php", Dir: "./php", Exts: []string{}},
+			{Lang: "go", Dir: "./go2", LangAlias: map[string]string{}},
+			{Lang: "go", Dir: "./go1", Ignores: []string{}},
+		},
+		Output: &Output{
+			Path:          "./apidoc.xml",
+			Tags:          []string{"t2", "t1", "t3"},
+			SkipServers:   []string{},
+			CheckWritable: &checkWritable,
+			Sort:          SortPath,
+			Indent:        "\t",
+			Style:         docs.StylesheetURL(core.OfficialURL),
+		},
+		Lint:      &Lint{},
+		Overrides: []string{},
+	}
+
+	a.NotError(cfg.Normalize())
+	a.Equal(cfg, &Config{
+		Version: ast.Version,
+		Inputs: []*Input{
+			{Lang: "go", Dir: "./go1"},
+			{Lang: "go", Dir: "./go2"},
+			{Lang: "php", Dir: "./php"},
+		},
+		Output: &Output{
+			Path: "./apidoc.xml",
+			Tags: []string{"t1", "t2", "t3"},
+		},
+	})
+	data1, err := yaml.Marshal(cfg)
+	a.NotError(err)
+
+	// 多次调用的结果相同
+	a.NotError(cfg.Normalize())
+	data2, err := yaml.Marshal(cfg)
+	a.NotError(err).Equal(string(data1), string(data2))
+
+	// 非默认值的可选字段不会被去掉
+	checkWritable = false
+	cfg.Output.CheckWritable = &checkWritable
+	cfg.Lint = &Lint{NamingConventions: true}
+	cfg.Output.Sort = SortMethod
+	cfg.Output.Indent = "  "
+	a.NotError(cfg.Normalize())
+	a.NotNil(cfg.Output.CheckWritable).NotNil(cfg.Lint).
+		Equal(cfg.Output.Sort, SortMethod).
+		Equal(cfg.Output.Indent, "  ")
+
+	// 无效的配置项
+	a.Error((&Config{Version: "1.0.0"}).Normalize())
+	a.Error((&Config{Version: ast.Version}).Normalize())
+	a.Error((&Config{Version: ast.Version, Inputs: []*Input{{Lang: "not-exists"}}, Output: &Output{}}).Normalize())
+	a.Error((&Config{Version: ast.Version, Inputs: []*Input{{Lang: "go"}}}).Normalize())
+
+	// 无效的 output 和 inputs 字段不会被规范化
+	cfg = &Config{
+		Version: ast.Version,
+		Inputs:  []*Input{{Lang: "go", Dir: "./go"}},
+		Output:  &Output{Path: "./apidoc.xml", Sort: "invalid", Indent: "\t"},
+	}
+	err = cfg.Normalize()
+	a.Error(err)
+	serr, ok := err.(*core.Error)
+	a.True(ok).Equal(serr.Field, "output.sort").
+		Equal(cfg.Output.Indent, "\t") // 出错时不会修改内容
+
+	cfg.Output.Sort = ""
+	cfg.Output.Type = "invalid"
+	a.Error(cfg.Normalize())
+
+	cfg.Output.Type = ""
+	cfg.Inputs[0].Encoding = "not-exists"
+	err = cfg.Normalize()
+	a.Error(err)
+	serr, ok = err.(*core.Error)
+	a.True(ok).Equal(serr.Field, "inputs[0].encoding")
+}
+
 func TestConfig_sanitize(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -89,24 +415,233 @@ func TestConfig_sanitize(t *testing.T) {
 		Equal(err2.Field, "output")
 }
 
+func TestConfig_ToInputs(t *testing.T) {
+	a := assert.New(t, false)
+
+	cfg, err := LoadConfig(docs.Dir().Append("example"))
+	a.NotError(err).NotNil(cfg)
+
+	inputs, err := cfg.ToInputs()
+	a.NotError(err).Length(inputs, len(cfg.Inputs))
+	for index, i := range inputs {
+		a.True(i.sanitized).
+			True(i != cfg.Inputs[index]). // 返回的是副本
+			Equal(i.Dir, cfg.Inputs[index].Dir)
+	}
+
+	cfg = &Config{Inputs: []*Input{{Lang: "go", Dir: "./not-exists"}}}
+	inputs, err = cfg.ToInputs()
+	a.Error(err).Nil(inputs)
+	err2, ok := err.(*core.Error)
+	a.True(ok).Equal(err2.Field, "inputs[0].dir")
+	a.False(cfg.Inputs[0].sanitized)
+
+	cfg = &Config{}
+	inputs, err = cfg.ToInputs()
+	a.Error(err).Nil(inputs)
+}
+
+func TestConfig_ToOutput(t *testing.T) {
+	a := assert.New(t, false)
+
+	cfg := &Config{Output: &Output{Path: "./apidoc.xml"}}
+	o, err := cfg.ToOutput()
+	a.NotError(err).NotNil(o)
+	a.Equal(o.Type, APIDocXML).
+		Empty(cfg.Output.Type) // 不影响原对象
+
+	cfg.Output.Sort = "invalid"
+	o, err = cfg.ToOutput()
+	a.Error(err).Nil(o)
+	err2, ok := err.(*core.Error)
+	a.True(ok).Equal(err2.Field, "output.sort")
+
+	cfg = &Config{}
+	o, err = cfg.ToOutput()
+	a.Error(err).Nil(o)
+}
+
 func TestConfig_Save(t *testing.T) {
 	a := assert.New(t, false)
 
-	wd := core.FileURI("./")
-	a.NotEmpty(wd)
+	dir := t.TempDir()
+	a.NotError(os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), os.ModePerm))
+	wd := core.FileURI(dir)
 	cfg, err := DetectConfig(wd, true)
 	a.NotError(err).NotNil(cfg)
+	inputDir, outputPath := cfg.Inputs[0].Dir, cfg.Output.Path
 	a.NotError(cfg.Save(wd))
 
+	// 保存不会修改 cfg 中的路径
+	a.Equal(cfg.Inputs[0].Dir, inputDir).Equal(cfg.Output.Path, outputPath)
+
 	// 通过 save 保存的路径应该是相对路径
 	cfg = &Config{}
-	data, err := ioutil.ReadFile("./" + allowConfigFilenames[0])
+	data, err := ioutil.ReadFile(filepath.Join(dir, allowConfigFilenames[0]))
 	a.NotError(err).NotNil(data)
 	a.NotError(yaml.Unmarshal(data, cfg))
 	a.Equal(".", cfg.Inputs[0].Dir)
 	a.Equal("apidoc.xml", cfg.Output.Path)
 }
 
+func TestConfig_SaveWithOptions(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	wd := core.FileURI(dir)
+	path := filepath.Join(dir, allowConfigFilenames[0])
+	bak := path + ".bak"
+	load := func(p string) *Config {
+		data, err := os.ReadFile(p)
+		a.NotError(err).NotNil(data)
+		cfg := &Config{}
+		a.NotError(yaml.Unmarshal(data, cfg))
+		return cfg
+	}
+	newConfig := func(v string) *Config {
+		return &Config{Version: v, Inputs: []*Input{{Lang: "go", Dir: wd}}, Output: &Output{Path: wd.Append("apidoc.xml")}}
+	}
+
+	// 文件不存在，不会生成备份文件
+	a.NotError(newConfig("7.0.0").Save(wd))
+	a.Equal(load(path).Version, "7.0.0")
+	a.FileNotExists(bak)
+
+	// Save 默认不备份
+	a.NotError(newConfig("7.0.1").Save(wd))
+	a.Equal(load(path).Version, "7.0.1")
+	a.FileNotExists(bak)
+
+	// 备份原有的内容
+	a.NotError(newConfig("7.0.2").SaveWithOptions(wd, &SaveOptions{Backup: true, AtomicWrite: true}))
+	a.Equal(load(path).Version, "7.0.2").
+		Equal(load(bak).Version, "7.0.1")
+
+	// 不备份
+	a.NotError(newConfig("7.0.3").SaveWithOptions(wd, &SaveOptions{AtomicWrite: true}))
+	a.Equal(load(path).Version, "7.0.3").
+		Equal(load(bak).Version, "7.0.1")
+
+	// 仅备份
+	a.NotError(newConfig("7.0.4").SaveWithOptions(wd, &SaveOptions{Backup: true}))
+	a.Equal(load(path).Version, "7.0.4").
+		Equal(load(bak).Version, "7.0.3")
+
+	// 直接写入
+	a.NotError(newConfig("7.0.5").SaveWithOptions(wd, nil))
+	a.Equal(load(path).Version, "7.0.5").
+		Equal(load(bak).Version, "7.0.3")
+
+	// 不会残留临时文件
+	entries, err := os.ReadDir(dir)
+	a.NotError(err).Length(entries, 2)
+
+	// 远程地址，以 PUT 请求写入
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/"+allowConfigFilenames[0] {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		a.NotError(err)
+		body = data
+	}))
+	defer srv.Close()
+	a.NotError(newConfig("7.0.6").SaveWithOptions(core.URI(srv.URL), &SaveOptions{Backup: true, AtomicWrite: true}))
+	remote := &Config{}
+	a.NotError(yaml.Unmarshal(body, remote))
+	a.Equal(remote.Version, "7.0.6").Equal(remote.Inputs[0].Dir, wd) // 无法计算相对路径
+	a.Error(newConfig("7.0.7").Save(core.URI(srv.URL + "/not-exists")))
+}
+
+func TestConfig_SaveJSON(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	wd := core.FileURI(dir)
+	a.NotError(wd.Append("main.go").WriteAll([]byte("package main"), os.ModePerm))
+	cfg := &Config{
+		Version: ast.Version,
+		Inputs:  []*Input{{Lang: "go", Dir: wd, Recursive: true}},
+		Output:  &Output{Path: wd.Append("apidoc.xml"), Tags: []string{"t1"}, Namespace: true},
+	}
+
+	a.NotError(cfg.Clone().SaveJSON(wd))
+	data, err := os.ReadFile(filepath.Join(dir, jsonConfigFilename))
+	a.NotError(err).NotNil(data)
+	cfg2 := &Config{}
+	a.NotError(json.Unmarshal(data, cfg2))
+	a.Equal(cfg2.Inputs[0].Dir, ".").Equal(cfg2.Output.Path, "apidoc.xml")
+
+	// 与保存为 YAML 的内容相同
+	fromJSON, err := LoadConfig(wd)
+	a.NotError(err).NotNil(fromJSON)
+	a.NotError(os.Remove(filepath.Join(dir, jsonConfigFilename)))
+	a.NotError(cfg.Clone().Save(wd))
+	fromYAML, err := LoadConfig(wd)
+	a.NotError(err).NotNil(fromYAML)
+	fromYAML.CreatedAt, fromYAML.UpdatedAt = fromJSON.CreatedAt, fromJSON.UpdatedAt // 两次保存的时间可能不同
+	data1, err := yaml.Marshal(fromJSON)
+	a.NotError(err)
+	data2, err := yaml.Marshal(fromYAML)
+	a.NotError(err)
+	a.Equal(string(data1), string(data2))
+}
+
+func TestConfig_Save_time(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	wd := core.FileURI(dir)
+	a.NotError(os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), os.ModePerm))
+	load := func() *Config {
+		data, err := os.ReadFile(filepath.Join(dir, allowConfigFilenames[0]))
+		a.NotError(err).NotNil(data)
+		a.Contains(string(data), "created-at:").Contains(string(data), "updated-at:")
+		cfg := &Config{}
+		a.NotError(yaml.Unmarshal(data, cfg))
+		return cfg
+	}
+
+	cfg := &Config{Version: ast.Version, Inputs: []*Input{{Lang: "go", Dir: wd}}, Output: &Output{Path: wd.Append("apidoc.xml")}}
+	a.NotError(cfg.Save(wd))
+	saved := load()
+	a.False(saved.CreatedAt.IsZero()).
+		Equal(saved.CreatedAt, saved.UpdatedAt).
+		True(saved.CreatedAt.Equal(cfg.CreatedAt))
+	created := saved.CreatedAt
+
+	// 再次保存，只更新 UpdatedAt
+	cfg.UpdatedAt = created.Add(-time.Hour)
+	cfg.CreatedAt = created.Add(-time.Hour)
+	a.NotError(cfg.Save(wd))
+	saved = load()
+	a.True(saved.CreatedAt.Equal(created.Add(-time.Hour))).
+		False(saved.UpdatedAt.Before(created))
+
+	// 内容未变化，依然会更新 UpdatedAt 并重写文件
+	path := filepath.Join(dir, allowConfigFilenames[0])
+	data, err := os.ReadFile(path)
+	a.NotError(err)
+	updated := created.Add(-2 * time.Hour)
+	data = bytes.Replace(data, []byte(cfg.UpdatedAt.Format(time.RFC3339)), []byte(updated.Format(time.RFC3339)), 1)
+	a.NotError(os.WriteFile(path, data, os.ModePerm))
+	cfg.UpdatedAt = updated
+	a.NotError(cfg.Save(wd))
+	saved = load()
+	a.True(saved.UpdatedAt.After(updated)).
+		True(saved.UpdatedAt.Equal(cfg.UpdatedAt)).
+		True(saved.CreatedAt.Equal(cfg.CreatedAt))
+
+	// JSON 格式
+	a.NotError(cfg.SaveJSON(wd))
+	data, err = os.ReadFile(filepath.Join(dir, jsonConfigFilename))
+	a.NotError(err).Contains(string(data), `"created-at"`)
+	fromJSON, err := LoadConfigJSON(wd, bytes.NewReader(data))
+	a.NotError(err).True(fromJSON.CreatedAt.Equal(cfg.CreatedAt))
+}
+
 func TestConfig_CheckSyntax(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -116,7 +651,7 @@ func TestConfig_CheckSyntax(t *testing.T) {
 	rslt := messagetest.NewMessageHandler()
 	cfg.CheckSyntax(rslt.Handler)
 	rslt.Handler.Stop()
-	a.Empty(rslt.Errors)
+	a.Empty(rslt.Errors).Length(rslt.Successes, 1)
 }
 
 func TestConfig_Build(t *testing.T) {
@@ -124,6 +659,7 @@ func TestConfig_Build(t *testing.T) {
 
 	cfg, err := LoadConfig(docs.Dir().Append("example"))
 	a.NotError(err).NotNil(cfg)
+	cfg.Output.Path = core.FileURI(t.TempDir()).Append("index.xml") // 不修改 docs/example 下的内容
 
 	rslt := messagetest.NewMessageHandler()
 	cfg.Build(rslt.Handler)
@@ -131,6 +667,23 @@ func TestConfig_Build(t *testing.T) {
 	a.Empty(rslt.Errors)
 }
 
+func TestConfig_BuildStats(t *testing.T) {
+	a := assert.New(t, false)
+
+	cfg, err := LoadConfig(docs.Dir().Append("example"))
+	a.NotError(err).NotNil(cfg)
+	cfg.Output.Path = core.FileURI(t.TempDir()).Append("index.xml") // 不修改 docs/example 下的内容
+
+	rslt := messagetest.NewMessageHandler()
+	stats := cfg.BuildStats(rslt.Handler)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).
+		NotNil(stats).
+		True(stats.TotalAPIs > 0).
+		True(stats.TotalTags > 0).
+		NotEmpty(stats.APIsPerTag)
+}
+
 func TestConfig_Buffer(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -144,3 +697,65 @@ func TestConfig_Buffer(t *testing.T) {
 		Empty(rslt.Successes).
 		True(buf.Len() > 0)
 }
+
+func TestConfig_Buffer_cache(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "doc.go")
+	write := func(title string, mod time.Time) {
+		a.NotError(os.WriteFile(path, []byte(`// <apidoc version="1.0.0">
+// <title>`+title+`</title>
+// <mimetype>application/json</mimetype>
+// </apidoc>
+`), os.ModePerm))
+		a.NotError(os.Chtimes(path, mod, mod))
+	}
+	mod := time.Now().Add(-time.Hour)
+	write("title1", mod)
+
+	cfg := &Config{
+		Version: ast.Version,
+		Inputs:  []*Input{{Lang: "go", Dir: core.FileURI(dir)}},
+		Output:  &Output{Path: core.FileURI(filepath.Join(dir, "apidoc.xml"))},
+		Cache:   true,
+	}
+	a.NotError(cfg.sanitize(core.FileURI(dir)))
+
+	buffer := func() string {
+		rslt := messagetest.NewMessageHandler()
+		buf := cfg.Buffer(rslt.Handler)
+		rslt.Handler.Stop()
+		a.Empty(rslt.Errors)
+		return buf.String()
+	}
+	a.Contains(buffer(), "title1")
+
+	// 修改时间未变化，采用缓存内容
+	write("title2", mod)
+	a.Contains(buffer(), "title1")
+
+	// 修改时间变化，缓存失效
+	mod = mod.Add(time.Minute)
+	write("title2", mod)
+	a.Contains(buffer(), "title2")
+
+	// 手动清除缓存
+	write("title3", mod)
+	a.Contains(buffer(), "title2")
+	cfg.Invalidate()
+	a.Contains(buffer(), "title3")
+
+	// 新增文件，缓存失效
+	a.NotError(os.WriteFile(filepath.Join(dir, "api.go"), []byte(`// <api method="GET" summary="new-api">
+// <path path="/users" />
+// <response status="200" type="string" />
+// </api>
+`), os.ModePerm))
+	a.Contains(buffer(), "new-api")
+
+	// 不采用缓存
+	cfg.Cache = false
+	write("title4", mod)
+	a.Contains(buffer(), "title4")
+}
@@ -8,6 +8,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/issue9/sliceutil"
+
 	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/lang"
@@ -70,6 +72,11 @@ func detectInput(dir string, recursive bool) ([]*Input, error) {
 	return opts, nil
 }
 
+// 仅在文件数量最多时才会被检测的语言
+//
+// 比如 sql 文件在大部分项目中仅作为数据库的迁移脚本存在，并不包含文档内容。
+var dominantLangs = []string{"sql"}
+
 type language struct {
 	lang.Language
 	count int
@@ -77,7 +84,8 @@ type language struct {
 
 // 根据 exts 计算每个语言对应的文件数量，并按倒序返回
 //
-// exts 参数为从 detectExts 中获取的返回值
+// exts 参数为从 detectExts 中获取的返回值；
+// dominantLangs 中的语言只有在数量最多时才会返回。
 func detectLanguage(exts map[string]int) []*language {
 	langs := make([]*language, 0, len(exts))
 
@@ -107,6 +115,12 @@ func detectLanguage(exts map[string]int) []*language {
 		return langs[i].count > langs[j].count
 	})
 
+	if len(langs) > 1 {
+		langs = append(langs[:1], sliceutil.Delete(langs[1:], func(l *language) bool {
+			return sliceutil.Exists(dominantLangs, func(id string) bool { return id == l.ID })
+		})...)
+	}
+
 	return langs
 }
 
@@ -35,6 +35,33 @@ func TestDetectLanguage(t *testing.T) {
 		Equal(langs[1].count, 2)
 	a.Equal(langs[2].ID, "swift").
 		Equal(langs[2].count, 1)
+
+	// sql 仅在数量最多时才返回
+	exts[".sql"] = 4
+	langs = detectLanguage(exts)
+	a.Equal(len(langs), 3).
+		Equal(langs[0].ID, "c++").
+		Equal(langs[1].ID, "php").
+		Equal(langs[2].ID, "swift")
+
+	exts[".sql"] = 6
+	langs = detectLanguage(exts)
+	a.Equal(len(langs), 4).
+		Equal(langs[0].ID, "sql").
+		Equal(langs[0].count, 6).
+		Equal(langs[1].ID, "c++")
+
+	// 同一语言的多个扩展名
+	langs = detectLanguage(map[string]int{".scala": 2, ".sc": 2, ".go": 3})
+	a.Equal(len(langs), 2).
+		Equal(langs[0].ID, "scala").
+		Equal(langs[0].count, 4).
+		Equal(langs[1].ID, "go")
+
+	langs = detectLanguage(map[string]int{".groovy": 1, ".gradle": 2})
+	a.Equal(len(langs), 1).
+		Equal(langs[0].ID, "groovy").
+		Equal(langs[0].count, 3)
 }
 
 func TestDetectExts(t *testing.T) {
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/locale"
+)
+
+// EnvPrefix 环境变量的前缀
+const EnvPrefix = "APIDOC_"
+
+// ApplyEnvOverrides 以环境变量的值覆盖配置项
+//
+// 环境变量名称由 EnvPrefix 加上字段在配置文件中的名称组成，
+// 名称中的 - 替换为 _ 并转换为大写，比如 output.type 对应 APIDOC_OUTPUT_TYPE。
+// 目前支持以下环境变量：
+//  APIDOC_VERSION                       version
+//  APIDOC_OUTPUT_TYPE                   output.type
+//  APIDOC_OUTPUT_PATH                   output.path
+//  APIDOC_OUTPUT_PATH_TEMPLATE          output.path-template
+//  APIDOC_OUTPUT_TAGS                   output.tags，多个值以逗号分隔
+//  APIDOC_OUTPUT_SORT                   output.sort
+//  APIDOC_OUTPUT_SKIP_SERVERS           output.skip-servers，多个值以逗号分隔
+//  APIDOC_OUTPUT_EXCLUDE_DEPRECATED     output.exclude-deprecated
+//  APIDOC_OUTPUT_DEPRECATED_ONLY        output.deprecated-only
+//  APIDOC_OUTPUT_STYLE                  output.style
+//  APIDOC_OUTPUT_STYLESHEET_VERSION     output.stylesheet-version
+//  APIDOC_OUTPUT_NAMESPACE              output.namespace
+//  APIDOC_OUTPUT_NAMESPACE_PREFIX       output.namespace-prefix
+//  APIDOC_OUTPUT_INDENT                 output.indent
+//  APIDOC_OUTPUT_EXTRACT_BASE_PATH      output.extract-base-path
+//  APIDOC_OUTPUT_GENERATE_OPERATION_IDS output.generate-operation-ids
+//  APIDOC_OUTPUT_OPERATION_ID_STYLE     output.operation-id-style
+//  APIDOC_OUTPUT_DEDUPLICATE_SCHEMAS    output.deduplicate-schemas
+//  APIDOC_OUTPUT_OPENAPI_STRICT         output.openapi-strict
+//  APIDOC_OUTPUT_CHECK_WRITABLE         output.check-writable
+//  APIDOC_LINT_NAMING_CONVENTIONS       lint.naming-conventions
+//
+// 布尔值采用 strconv.ParseBool 进行转换，整数为十进制格式。
+// 未设置的环境变量以及其它以 EnvPrefix 开头的环境变量会被忽略，
+// 值无法转换成对应的类型时返回错误，此时 cfg 可能已经被部分修改。
+//
+// 与配置文件不同，output.path 中的相对路径以程序的工作目录为基准。
+func (cfg *Config) ApplyEnvOverrides() error {
+	if v, found := os.LookupEnv(EnvPrefix + "VERSION"); found {
+		cfg.Version = v
+	}
+
+	if cfg.Output == nil {
+		cfg.Output = &Output{}
+	}
+	if err := applyEnv(EnvPrefix+"OUTPUT_", reflect.ValueOf(cfg.Output).Elem()); err != nil {
+		return err
+	}
+
+	lint := cfg.Lint
+	if lint == nil {
+		lint = &Lint{}
+	}
+	if err := applyEnv(EnvPrefix+"LINT_", reflect.ValueOf(lint).Elem()); err != nil {
+		return err
+	}
+	if cfg.Lint != nil || *lint != (Lint{}) { // 未设置任何值时不需要生成 Lint 对象
+		cfg.Lint = lint
+	}
+
+	return nil
+}
+
+// 根据 yaml 结构体标签从环境变量中读取 v 中各个字段的值
+func applyEnv(prefix string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if !f.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		key := prefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		val, found := os.LookupEnv(key)
+		if !found {
+			continue
+		}
+
+		if err := setEnvValue(v.Field(i), val); err != nil {
+			return core.NewError(locale.ErrInvalidValue).WithField(key)
+		}
+	}
+
+	return nil
+}
+
+func setEnvValue(v reflect.Value, val string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return core.NewError(locale.ErrInvalidValue)
+		}
+		var items []string
+		for _, item := range strings.Split(val, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if err := setEnvValue(elem.Elem(), val); err != nil {
+			return err
+		}
+		v.Set(elem)
+	default:
+		return core.NewError(locale.ErrInvalidValue)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+)
+
+func TestConfig_ApplyEnvOverrides(t *testing.T) {
+	a := assert.New(t, false)
+
+	cfg := &Config{Version: "6.0.0", Output: &Output{Type: APIDocXML, Tags: []string{"t1"}}}
+	a.NotError(cfg.ApplyEnvOverrides())
+	a.Equal(cfg.Version, "6.0.0").
+		Equal(cfg.Output.Type, APIDocXML).
+		Equal(cfg.Output.Tags, []string{"t1"}).
+		Nil(cfg.Lint)
+
+	t.Setenv("APIDOC_VERSION", "6.1.0")
+	t.Setenv("APIDOC_OUTPUT_TYPE", OpenapiJSON)
+	t.Setenv("APIDOC_OUTPUT_PATH", "./openapi.json")
+	t.Setenv("APIDOC_OUTPUT_TAGS", "t1, t2,,t3")
+	t.Setenv("APIDOC_OUTPUT_NAMESPACE", "true")
+	t.Setenv("APIDOC_OUTPUT_CHECK_WRITABLE", "0")
+	t.Setenv("APIDOC_LINT_NAMING_CONVENTIONS", "1")
+	t.Setenv("APIDOC_NOT_EXISTS", "ignored")
+	a.NotError(cfg.ApplyEnvOverrides())
+	a.Equal(cfg.Version, "6.1.0").
+		Equal(cfg.Output.Type, OpenapiJSON).
+		Equal(cfg.Output.Path, core.URI("./openapi.json")).
+		Equal(cfg.Output.Tags, []string{"t1", "t2", "t3"}).
+		True(cfg.Output.Namespace).
+		NotNil(cfg.Output.CheckWritable).False(*cfg.Output.CheckWritable).
+		NotNil(cfg.Lint).True(cfg.Lint.NamingConventions)
+
+	// output 为空
+	cfg = &Config{}
+	a.NotError(cfg.ApplyEnvOverrides())
+	a.Equal(cfg.Output.Type, OpenapiJSON)
+
+	// 无效的值
+	t.Setenv("APIDOC_OUTPUT_NAMESPACE", "not-bool")
+	err := cfg.ApplyEnvOverrides()
+	a.Error(err)
+	serr, ok := err.(*core.Error)
+	a.True(ok).Equal(serr.Field, "APIDOC_OUTPUT_NAMESPACE")
+}
+
+// 保证 ApplyEnvOverrides 的文档中包含了所有支持的环境变量
+func TestApplyEnvOverrides_doc(t *testing.T) {
+	a := assert.New(t, false)
+
+	data, err := os.ReadFile("./env.go")
+	a.NotError(err).NotNil(data)
+	src := string(data)
+
+	for prefix, v := range map[string]interface{}{EnvPrefix + "OUTPUT_": Output{}, EnvPrefix + "LINT_": Lint{}} {
+		typ := reflect.TypeOf(v)
+		for i := 0; i < typ.NumField(); i++ {
+			name := strings.Split(typ.Field(i).Tag.Get("yaml"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			key := prefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+			a.True(strings.Contains(src, "//  "+key+" "), "文档中缺少 %s", key)
+		}
+	}
+}
@@ -3,39 +3,103 @@
 package build
 
 import (
+	"context"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/issue9/sliceutil"
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/ianaindex"
 
 	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/frontmatter"
 	"github.com/caixw/apidoc/v7/internal/lang"
 	"github.com/caixw/apidoc/v7/internal/locale"
+	"github.com/caixw/apidoc/v7/internal/structtag"
 )
 
 // Input 指定输入内容的相关信息。
 type Input struct {
 	Lang      string   `yaml:"lang"`                // 输入的目标语言，值为 internal/lang 中的 Language.ID
 	Dir       core.URI `yaml:"dir"`                 // 源代码目录
-	Exts      []string `yaml:"exts,omitempty"`      // 需要扫描的文件扩展名，为空则表示采用默认规则。
+	Exts      []string `yaml:"exts,omitempty"`      // 需要扫描的文件扩展名，不区分大小写，为空则表示采用默认规则。
 	Recursive bool     `yaml:"recursive,omitempty"` // 是否查找 Dir 的子目录
 	Encoding  string   `yaml:"encoding,omitempty"`  // 源文件的编码，默认为 UTF-8
 	Ignores   []string `yaml:"ignores,omitempty"`   // 忽略的文件或目录，比如 node_modules 等可在此指定
 
+	// 目标语言的版本号
+	//
+	// 部分语言在不同版本之间的语法有所差别，比如 python 的 2 和 3，
+	// 指定之后会采用该版本的解析规则，必须是 Lang 已经注册的版本。
+	// 为空表示采用默认的解析规则。仅对 Lang 有效，LangAlias 中的语言依然采用默认规则。
+	LangVersion string `yaml:"lang-version,omitempty"`
+
+	// 扩展名与语言的对应关系
+	//
+	// 键名为文件扩展名，键值为 internal/lang 中的 Language.ID。
+	// 匹配的文件会采用指定的语言进行解析，而不是 Lang。
+	// 比如将 .jinja 指定为 php，则 .jinja 文件中的注释会以 php 的规则进行解析。
+	// 此处指定的扩展名会自动添加到 Exts 中。
+	LangAlias map[string]string `yaml:"lang-alias,omitempty"`
+
+	// 是否解析文件头部以 YAML 格式表示的 front-matter 内容
+	//
+	// front-matter 以 --- 开始和结束，其中的内容会被转换成等价的 <api> 元素，
+	// 具体格式可参考 internal/frontmatter 包。
+	ParseFrontMatter bool `yaml:"parse-front-matter,omitempty"`
+
+	// 是否解析 Go 源码中结构体标签的内容
+	//
+	// 仅对采用 go 语言解析的文件有效，标签名为 apidoc，
+	// 其值的格式为 `apidoc:"GET /users summary"`，具体可参考 internal/structtag 包。
+	ParseStructTags bool `yaml:"parse-struct-tags,omitempty"`
+
+	// 最多处理的文件数量
+	//
+	// 查找到的文件数量超过此值时，多余的文件会被忽略并给出警告信息，
+	// 用于防止误将 dir 指向了一个庞大的目录。0 表示不作限制。
+	MaxFiles int `yaml:"max-files,omitempty"`
+
+	// 分析单个文件的最长时间
+	//
+	// 超时之后该文件剩余的内容将被忽略并给出警告信息，
+	// 用于防止格式错误的文件导致分析过程无法结束。0 表示不作限制。
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+
 	paths     []core.URI        // 根据 Dir、Exts、Ignores 和 Recursive 生成
+	truncated bool              // paths 是否因 MaxFiles 的限制而被截断
+	reader    io.Reader         // 由 InputFromReader 指定的内容
+	data      []byte            // 从 reader 中读取的内容，对应 paths 中唯一的元素
 	encoding  encoding.Encoding // 根据 Encoding 生成
 	sanitized bool
 }
 
+// InputFromReader 以 r 的内容作为输入源声明 Input 实例
+//
+// r 的内容会被当作一个名为 name 的文件进行解析，不会访问文件系统，
+// 一般用于测试。r 的内容在第一次使用时被读取，之后不再访问 r。
+func InputFromReader(lang, name string, r io.Reader) *Input {
+	return &Input{
+		Lang:   lang,
+		paths:  []core.URI{core.URI(name)},
+		reader: r,
+	}
+}
+
 func (o *Input) sanitize() error {
 	if o.sanitized {
 		return nil
 	}
 
+	if o.reader != nil {
+		return o.sanitizeReader()
+	}
+
 	if len(o.Dir) == 0 {
 		return core.NewError(locale.ErrIsEmpty, "dir").WithField("dir")
 	}
@@ -52,15 +116,11 @@ func (o *Input) sanitize() error {
 		return core.NewError(locale.ErrInvalidURIScheme, scheme).WithField("dir")
 	}
 
-	if len(o.Lang) == 0 {
-		return core.NewError(locale.ErrIsEmpty, "lang").WithField("lang")
+	if err = o.validate(); err != nil {
+		return err
 	}
 
 	language := lang.Get(o.Lang)
-	if language == nil {
-		return core.NewError(locale.ErrInvalidValue).WithField("lang")
-	}
-
 	if len(o.Exts) > 0 {
 		exts := make([]string, 0, len(o.Exts))
 		for _, ext := range o.Exts {
@@ -71,13 +131,36 @@ func (o *Input) sanitize() error {
 			if ext[0] != '.' {
 				ext = "." + ext
 			}
-			exts = append(exts, ext)
+			exts = append(exts, strings.ToLower(ext))
 		}
 		o.Exts = exts
 	} else {
 		o.Exts = language.Exts
 	}
 
+	if len(o.LangAlias) > 0 {
+		// Exts 可能与 language.Exts 共用底层数组，需要复制一份再追加内容。
+		o.Exts = append(make([]string, 0, len(o.Exts)+len(o.LangAlias)), o.Exts...)
+
+		alias := make(map[string]string, len(o.LangAlias))
+		for ext, l := range o.LangAlias {
+			if ext[0] != '.' {
+				ext = "." + ext
+			}
+			ext = strings.ToLower(ext)
+			alias[ext] = l
+
+			if !sliceutil.Exists(o.Exts, func(e string) bool { return e == ext }) {
+				o.Exts = append(o.Exts, ext)
+			}
+		}
+		o.LangAlias = alias
+	}
+
+	for i, pattern := range o.Ignores {
+		o.Ignores[i] = filepath.FromSlash(pattern)
+	}
+
 	if err = o.recursivePath(); err != nil {
 		return err
 	}
@@ -93,17 +176,119 @@ func (o *Input) sanitize() error {
 	return nil
 }
 
+// 检测与文件系统无关的字段是否合法，不会修改任何内容。
+func (o *Input) validate() error {
+	if o.MaxFiles < 0 {
+		return core.NewError(locale.ErrInvalidValue).WithField("max-files")
+	}
+
+	if o.Timeout < 0 {
+		return core.NewError(locale.ErrInvalidValue).WithField("timeout")
+	}
+
+	if len(o.Lang) == 0 {
+		return core.NewError(locale.ErrIsEmpty, "lang").WithField("lang")
+	}
+	if lang.Get(o.Lang) == nil {
+		return core.NewError(locale.ErrInvalidValue).WithField("lang")
+	}
+	if o.LangVersion != "" && lang.Get(o.Lang, o.LangVersion) == nil {
+		return core.NewError(locale.ErrInvalidValue).WithField("lang-version")
+	}
+
+	for ext, l := range o.LangAlias {
+		if len(ext) == 0 || lang.Get(l) == nil {
+			return core.NewError(locale.ErrInvalidValue).WithField("lang-alias[" + ext + "]")
+		}
+	}
+
+	if o.Encoding != "" {
+		if _, err := ianaindex.IANA.Encoding(o.Encoding); err != nil {
+			return core.WithError(err).WithField("encoding")
+		}
+	}
+
+	return nil
+}
+
+func (o *Input) clone() *Input {
+	if o == nil {
+		return nil
+	}
+
+	i := *o
+	i.Exts = cloneStrings(o.Exts)
+	i.Ignores = cloneStrings(o.Ignores)
+	if o.LangAlias != nil {
+		i.LangAlias = make(map[string]string, len(o.LangAlias))
+		for k, v := range o.LangAlias {
+			i.LangAlias[k] = v
+		}
+	}
+	if o.paths != nil {
+		i.paths = append(make([]core.URI, 0, len(o.paths)), o.paths...)
+	}
+	return &i
+}
+
+func (o *Input) sanitizeReader() error {
+	if len(o.Lang) == 0 {
+		return core.NewError(locale.ErrIsEmpty, "lang").WithField("lang")
+	}
+	if lang.Get(o.Lang) == nil {
+		return core.NewError(locale.ErrInvalidValue).WithField("lang")
+	}
+	if o.LangVersion != "" && lang.Get(o.Lang, o.LangVersion) == nil {
+		return core.NewError(locale.ErrInvalidValue).WithField("lang-version")
+	}
+
+	if o.Encoding != "" {
+		enc, err := ianaindex.IANA.Encoding(o.Encoding)
+		if err != nil {
+			return core.WithError(err).WithField("encoding")
+		}
+		o.encoding = enc
+	}
+
+	data, err := io.ReadAll(o.reader)
+	if err != nil {
+		return core.WithError(err)
+	}
+	if o.encoding != nil {
+		if data, err = o.encoding.NewDecoder().Bytes(data); err != nil {
+			return core.WithError(err).WithField("encoding")
+		}
+	}
+	o.data = data
+
+	o.sanitized = true
+	return nil
+}
+
+// 文件数量达到 Input.MaxFiles 时用于中止 filepath.Walk
+var errMaxFiles = errors.New("max-files")
+
 // 按 Input 中的规则查找所有符合条件的文件列表并保存至 Input.paths
+//
+// 可多次调用，每次都会重新查找文件列表。
 func (o *Input) recursivePath() error {
-	local, err := o.Dir.File()
+	paths, truncated, err := o.walk()
 	if err != nil {
-		return core.WithError(err).WithField("dir")
+		return err
 	}
-	local = filepath.Clean(local)
+	o.paths, o.truncated = paths, truncated
+	return nil
+}
 
-	for i, pattern := range o.Ignores {
-		o.Ignores[i] = filepath.FromSlash(pattern)
+// 按 Input 中的规则查找所有符合条件的文件列表
+//
+// 不会修改 o 的内容，可以在多个 goroutine 中同时调用。
+func (o *Input) walk() (paths []core.URI, truncated bool, err error) {
+	local, err := o.Dir.File()
+	if err != nil {
+		return nil, false, core.WithError(err).WithField("dir")
 	}
+	local = filepath.Clean(local)
 
 	walk := func(path string, fi os.FileInfo, err error) error {
 		if err != nil {
@@ -117,24 +302,30 @@ func (o *Input) recursivePath() error {
 		if err != nil {
 			return err
 		}
-		if !ignore {
-			o.paths = append(o.paths, core.FileURI(path))
+		if ignore {
+			return nil
+		}
+
+		if o.MaxFiles > 0 && len(paths) >= o.MaxFiles {
+			truncated = true
+			return errMaxFiles
 		}
+		paths = append(paths, core.FileURI(path))
 		return nil
 	}
 
-	if err := filepath.Walk(local, walk); err != nil {
-		return core.WithError(err).WithField("dir")
+	if err := filepath.Walk(local, walk); err != nil && err != errMaxFiles {
+		return nil, false, core.WithError(err).WithField("dir")
 	}
 
-	if len(o.paths) == 0 {
-		return core.NewError(locale.ErrNoFiles).WithField("dir")
+	if len(paths) == 0 {
+		return nil, false, core.NewError(locale.ErrNoFiles).WithField("dir")
 	}
-	return nil
+	return paths, truncated, nil
 }
 
 func (o *Input) isIgnore(root, path string) (bool, error) {
-	ext := filepath.Ext(path)
+	ext := strings.ToLower(filepath.Ext(path))
 	if sliceutil.Count(o.Exts, func(i string) bool { return i == ext }) == 0 {
 		return true, nil
 	}
@@ -167,6 +358,11 @@ func (o *Input) isIgnore(root, path string) (bool, error) {
 func ParseInputs(blocks chan core.Block, h *core.MessageHandler, opt ...*Input) {
 	wg := &sync.WaitGroup{}
 	for _, i := range opt {
+		if i.truncated {
+			h.Warning(core.Location{URI: i.Dir}.NewError(locale.MaxFilesExceeded, i.MaxFiles).WithField("max-files"))
+			h.Locale(core.Info, locale.MaxFilesHint)
+		}
+
 		for _, path := range i.paths {
 			wg.Add(1)
 			go func(path core.URI, i *Input) {
@@ -179,15 +375,60 @@ func ParseInputs(blocks chan core.Block, h *core.MessageHandler, opt ...*Input)
 }
 
 // ParseFile 分析 uri 指向的文件并输出到 blocks
+//
+// 由 InputFromReader 声明的实例，uri 为其指定的名称时，直接采用 reader 中的内容。
 func (o *Input) ParseFile(blocks chan core.Block, h *core.MessageHandler, uri core.URI) {
-	data, err := uri.ReadAll(o.encoding)
-	if err != nil {
-		h.Error((core.Location{URI: uri}).WithError(err))
-		return
+	var data []byte
+	if o.data != nil && len(o.paths) > 0 && uri == o.paths[0] {
+		data = o.data
+	} else {
+		var err error
+		if data, err = uri.ReadAll(o.encoding); err != nil {
+			h.Error((core.Location{URI: uri}).WithError(err))
+			return
+		}
+	}
+
+	if o.ParseFrontMatter {
+		block, rest, err := frontmatter.Block(uri, data)
+		if err != nil {
+			h.Error(err)
+			return
+		}
+		if block != nil {
+			blocks <- *block
+		}
+		data = rest
 	}
 
-	lang.Parse(h, o.Lang, core.Block{
+	langID, langVersion := o.lang(uri)
+	if o.ParseStructTags && langID == "go" {
+		if tags, err := structtag.Blocks(uri, data); err != nil {
+			h.Error(err) // 不影响注释内容的解析
+		} else {
+			for _, block := range tags {
+				blocks <- *block
+			}
+		}
+	}
+
+	ctx := context.Background()
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	lang.ParseContext(ctx, h, langID, langVersion, core.Block{
 		Data:     data,
 		Location: core.Location{URI: uri},
 	}, blocks)
 }
+
+// 获取 uri 对应的语言 ID 及其版本号
+func (o *Input) lang(uri core.URI) (id, version string) {
+	if l, found := o.LangAlias[strings.ToLower(filepath.Ext(string(uri)))]; found {
+		return l, ""
+	}
+	return o.Lang, o.LangVersion
+}
@@ -3,14 +3,20 @@
 package build
 
 import (
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/issue9/assert/v2"
 	"golang.org/x/text/encoding/simplifiedchinese"
+	"gopkg.in/yaml.v3"
 
 	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/lang"
+	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
 func TestParseInputs(t *testing.T) {
@@ -112,6 +118,177 @@ func TestInput_ParseFile(t *testing.T) {
 	a.NotEmpty(rslt.Errors)
 }
 
+func TestInput_LangAlias(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := core.FileURI(t.TempDir())
+	a.NotError(dir.Append("main.go").WriteAll([]byte("package main"), os.ModePerm))
+	a.NotError(dir.Append("index.jinja").WriteAll([]byte(`{{ title }}
+# <api method="GET">
+# <path path="/jinja" />
+# </api>
+`), os.ModePerm))
+
+	o := &Input{
+		Lang:      "go",
+		Dir:       dir,
+		LangAlias: map[string]string{"JINJA": "php"},
+	}
+	a.NotError(o.sanitize())
+	a.Equal(o.LangAlias, map[string]string{".jinja": "php"}).
+		Equal(o.Exts, []string{".go", ".jinja"}).
+		Equal(2, len(o.paths)).
+		Equal(lang.Get("go").Exts, []string{".go"}) // 不能修改 lang 中的原始数据
+
+	blocks := make(chan core.Block, 10)
+	rslt := messagetest.NewMessageHandler()
+	o.ParseFile(blocks, rslt.Handler, dir.Append("index.jinja"))
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).Equal(1, len(blocks))
+
+	// 未使用 LangAlias，# 不是 go 的注释
+	o = &Input{Lang: "go", Dir: dir, Exts: []string{".jinja"}}
+	a.NotError(o.sanitize())
+	blocks = make(chan core.Block, 10)
+	rslt = messagetest.NewMessageHandler()
+	o.ParseFile(blocks, rslt.Handler, dir.Append("index.jinja"))
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(blocks)
+
+	// 未注册的语言
+	o = &Input{Lang: "go", Dir: dir, LangAlias: map[string]string{".jinja": "not-exists"}}
+	err := o.sanitize()
+	a.Error(err)
+	cerr, ok := err.(*core.Error)
+	a.True(ok).Equal(cerr.Field, "lang-alias[.jinja]")
+}
+
+func TestInput_LangVersion(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := core.FileURI(t.TempDir())
+	a.NotError(dir.Append("main.py").WriteAll([]byte(`def users():
+    ur"""<api method="GET"><path path="/users" /></api>"""
+`), os.ModePerm))
+
+	parse := func(o *Input) int {
+		a.NotError(o.sanitize())
+		blocks := make(chan core.Block, 10)
+		rslt := messagetest.NewMessageHandler()
+		o.ParseFile(blocks, rslt.Handler, dir.Append("main.py"))
+		rslt.Handler.Stop()
+		close(blocks)
+		a.Empty(rslt.Errors)
+		return len(blocks)
+	}
+
+	// python 3 不支持 ur 前缀
+	a.Equal(0, parse(&Input{Lang: "python", Dir: dir}))
+	a.Equal(0, parse(&Input{Lang: "python", LangVersion: "3", Dir: dir}))
+	a.Equal(1, parse(&Input{Lang: "python", LangVersion: "2", Dir: dir}))
+
+	// 未注册的版本
+	o := &Input{Lang: "python", LangVersion: "4", Dir: dir}
+	err := o.sanitize()
+	a.Error(err)
+	cerr, ok := err.(*core.Error)
+	a.True(ok).Equal(cerr.Field, "lang-version")
+
+	o = &Input{Lang: "go", LangVersion: "1", Dir: dir}
+	a.Error(o.sanitize())
+}
+
+func TestInput_ParseFrontMatter(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := core.FileURI(t.TempDir())
+	a.NotError(dir.Append("main.go").WriteAll([]byte(`---
+method: GET
+path: /users
+summary: users
+---
+package main
+
+// <api method="POST" summary="post">
+// <path path="/users" />
+// </api>
+`), os.ModePerm))
+
+	o := &Input{Lang: "go", Dir: dir, ParseFrontMatter: true}
+	a.NotError(o.sanitize())
+	blocks := make(chan core.Block, 10)
+	rslt := messagetest.NewMessageHandler()
+	o.ParseFile(blocks, rslt.Handler, dir.Append("main.go"))
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).Equal(2, len(blocks))
+
+	fm := <-blocks
+	a.Equal(fm.Location.Range.End.Line, 4)
+	comment := <-blocks
+	a.Equal(comment.Location.Range.Start.Line, 7) // 保持原有的定位
+
+	// 未启用
+	o = &Input{Lang: "go", Dir: dir}
+	a.NotError(o.sanitize())
+	blocks = make(chan core.Block, 10)
+	rslt = messagetest.NewMessageHandler()
+	o.ParseFile(blocks, rslt.Handler, dir.Append("main.go"))
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).Equal(1, len(blocks))
+}
+
+func TestInput_ParseStructTags(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := core.FileURI(t.TempDir())
+	a.NotError(dir.Append("main.go").WriteAll([]byte(`package main
+
+type GetUsers struct {
+	Handler struct{} `+"`json:\"-\" apidoc:\"GET /users 获取用户列表\"`"+`
+	Name    string   `+"`json:\"name\"`"+`
+}
+
+// <api method="POST" summary="post">
+// <path path="/users" />
+// </api>
+`), os.ModePerm))
+
+	o := &Input{Lang: "go", Dir: dir, ParseStructTags: true}
+	a.NotError(o.sanitize())
+	blocks := make(chan core.Block, 10)
+	rslt := messagetest.NewMessageHandler()
+	o.ParseFile(blocks, rslt.Handler, dir.Append("main.go"))
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).Equal(2, len(blocks))
+
+	tag := <-blocks
+	a.Equal(tag.Location.Range.Start.Line, 3)
+	rslt = messagetest.NewMessageHandler()
+	d := &ast.APIDoc{}
+	d.Parse(rslt.Handler, tag)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Length(d.APIs, 1)
+	api := d.APIs[0]
+	a.Equal(api.Method.V(), "GET").
+		Equal(api.Path.Path.V(), "/users").
+		Equal(api.Summary.V(), "获取用户列表")
+
+	// 未启用
+	o = &Input{Lang: "go", Dir: dir}
+	a.NotError(o.sanitize())
+	blocks = make(chan core.Block, 10)
+	rslt = messagetest.NewMessageHandler()
+	o.ParseFile(blocks, rslt.Handler, dir.Append("main.go"))
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).Equal(1, len(blocks))
+}
+
 func TestInput_sanitize(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -137,9 +314,8 @@ func TestInput_sanitize(t *testing.T) {
 	a.NotError(o.sanitize())
 	a.Equal(o.Exts, language.Exts)
 
-	// 指定了 Exts，自动调整扩展名样式。
-	o.Lang = "go"
-	o.Exts = []string{"go", ".g2"}
+	// 指定了 Exts，自动调整扩展名样式，且转换成小写。
+	o.Exts = []string{"go", ".G2"}
 	o.sanitized = false
 	a.NotError(o.sanitize())
 	a.Equal(o.Exts, []string{".go", ".g2"})
@@ -166,6 +342,14 @@ func TestInput_recursivePath(t *testing.T) {
 	err := opt.recursivePath()
 	a.NotError(err).Equal(2, len(opt.paths))
 
+	// 扩展名不区分大小写
+	dir := core.FileURI(t.TempDir())
+	a.NotError(dir.Append("plumber.R").WriteAll([]byte("#' comment"), os.ModePerm))
+	a.NotError(dir.Append("utils.r").WriteAll([]byte("#' comment"), os.ModePerm))
+	opt = &Input{Dir: dir, Exts: []string{".r"}}
+	err = opt.recursivePath()
+	a.NotError(err).Equal(2, len(opt.paths))
+
 	opt = &Input{
 		Dir:       "./testdata",
 		Recursive: true,
@@ -217,3 +401,91 @@ func TestInput_recursivePath(t *testing.T) {
 	err = opt.recursivePath()
 	a.Error(err).Empty(opt.paths)
 }
+
+func TestInput_MaxFiles(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := core.FileURI(t.TempDir())
+	for _, name := range []string{"a.go", "b.go", "c.go", "d.go", "e.go"} {
+		a.NotError(dir.Append(name).WriteAll([]byte("// <api method=\"GET\"></api>\n"), os.ModePerm))
+	}
+
+	opt := &Input{Lang: "go", Dir: dir, MaxFiles: -1}
+	a.Error(opt.sanitize())
+
+	// 未超出限制
+	opt = &Input{Lang: "go", Dir: dir, MaxFiles: 5}
+	a.NotError(opt.sanitize())
+	a.Equal(5, len(opt.paths)).False(opt.truncated)
+
+	opt = &Input{Lang: "go", Dir: dir, MaxFiles: 3}
+	a.NotError(opt.sanitize())
+	a.Equal(3, len(opt.paths)).True(opt.truncated)
+
+	blocks := make(chan core.Block, 100)
+	rslt := messagetest.NewMessageHandler()
+	ParseInputs(blocks, rslt.Handler, opt)
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Equal(3, len(blocks)).
+		Empty(rslt.Errors).
+		Equal(1, len(rslt.Warns)).
+		Equal(rslt.Infos, []interface{}{locale.New(locale.MaxFilesHint)})
+}
+
+func TestInput_Timeout(t *testing.T) {
+	a := assert.New(t, false)
+
+	opt := &Input{}
+	a.NotError(yaml.Unmarshal([]byte("lang: c++\ndir: ./testdata\ntimeout: 30s\n"), opt))
+	a.Equal(opt.Timeout, 30*time.Second)
+	a.NotError(opt.sanitize())
+
+	opt = &Input{Lang: "go", Dir: "./testdata", Timeout: -1}
+	a.Error(opt.sanitize())
+}
+
+func TestInputFromReader(t *testing.T) {
+	a := assert.New(t, false)
+
+	const code = `// <apidoc version="1.0.0">
+// <title>title</title>
+// <mimetype>application/json</mimetype>
+// </apidoc>
+
+// <api method="GET">
+// <path path="/users" />
+// <response status="200" type="string" />
+// </api>
+`
+	i := InputFromReader("go", "virtual.go", strings.NewReader(code))
+	a.NotNil(i).NotError(i.sanitize())
+
+	blocks := make(chan core.Block, 100)
+	rslt := messagetest.NewMessageHandler()
+	ParseInputs(blocks, rslt.Handler, i)
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).Equal(2, len(blocks))
+	blk := <-blocks
+	a.Equal(blk.Location.URI, core.URI("virtual.go"))
+
+	// 完整的流程
+	rslt = messagetest.NewMessageHandler()
+	errs, _, err := CheckSyntaxResult(rslt.Handler, InputFromReader("go", "virtual.go", strings.NewReader(code)))
+	rslt.Handler.Stop()
+	a.NotError(err).Equal(errs, 0).
+		Equal(rslt.Successes, []interface{}{locale.New(locale.TestSuccess, 1, 1)})
+
+	// 无效的语言
+	i = InputFromReader("not-exists", "virtual.go", strings.NewReader(code))
+	a.Error(i.sanitize())
+
+	// 编码
+	gbk, err := simplifiedchinese.GBK.NewEncoder().String("// <api method=\"GET\"><description>中文</description></api>\n")
+	a.NotError(err)
+	i = InputFromReader("go", "gbk.go", strings.NewReader(gbk))
+	i.Encoding = "gbk"
+	a.NotError(i.sanitize())
+	a.Contains(string(i.data), "中文")
+}
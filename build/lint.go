@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/lint"
+)
+
+// Lint 语法之外的规范性检测
+//
+// 检测结果以警告的形式输出，不会影响文档的生成。
+type Lint struct {
+	// 检测接口路径的命名规范
+	//
+	// 路径中除参数之外的内容只能是小写字母，以连字符代替下划线，
+	// 且不能包含 HTTP 方法名称，比如 /get-users。
+	NamingConventions bool `yaml:"naming-conventions,omitempty"`
+}
+
+func (l *Lint) rules() []lint.Rule {
+	if l == nil {
+		return nil
+	}
+
+	rules := make([]lint.Rule, 0, 1)
+	if l.NamingConventions {
+		rules = append(rules, lint.NamingRule{})
+	}
+	return rules
+}
+
+func (l *Lint) check(h *core.MessageHandler, d *ast.APIDoc) {
+	if rules := l.rules(); len(rules) > 0 {
+		lint.Check(h, d, rules...)
+	}
+}
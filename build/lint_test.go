@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+)
+
+func TestConfig_CheckSyntaxResult_lint(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	data := []byte(`// <api method="GET" summary="test"><path path="/Get_Users" /></api>` + "\n")
+	a.NotError(os.WriteFile(filepath.Join(dir, "api.go"), data, os.ModePerm))
+	cfg := &Config{Inputs: []*Input{{Lang: "go", Dir: core.FileURI(dir)}}}
+
+	rslt := messagetest.NewMessageHandler()
+	errs, warns := cfg.CheckSyntaxResult(rslt.Handler)
+	rslt.Handler.Stop()
+	a.Equal(errs, 0).Equal(warns, 0)
+
+	cfg.Lint = &Lint{NamingConventions: true}
+	rslt = messagetest.NewMessageHandler()
+	errs, warns = cfg.CheckSyntaxResult(rslt.Handler)
+	rslt.Handler.Stop()
+	a.Equal(errs, 0).Equal(warns, 3).Length(rslt.Warns, 3)
+}
+
+func TestLint_rules(t *testing.T) {
+	a := assert.New(t, false)
+
+	var l *Lint
+	a.Empty(l.rules())
+
+	l = &Lint{}
+	a.Empty(l.rules())
+
+	l.NamingConventions = true
+	a.Length(l.rules(), 1)
+}
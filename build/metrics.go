@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/caixw/apidoc/v7/core"
+)
+
+// Metrics 构建过程的度量数据
+//
+// 以 JSON 格式保存之后，可以由外部的监控工具跟踪构建结果的变化趋势。
+type Metrics struct {
+	Timestamp    time.Time `json:"timestamp"`     // 构建的开始时间
+	Duration     int64     `json:"duration_ms"`   // 构建的耗时，单位为毫秒
+	APICount     int       `json:"api_count"`     // 接口数量
+	ErrorCount   int       `json:"error_count"`   // 错误信息的数量
+	WarningCount int       `json:"warning_count"` // 警告信息的数量
+}
+
+// NewMetrics 根据构建结果生成 Metrics
+//
+// start 为构建的开始时间，耗时计算至调用 NewMetrics 为止；
+// stats 为 nil 时，表示构建未能完成，接口数量为 0；
+// h 应该由 core.NewCollectMessageHandler 创建，否则错误和警告的数量始终为 0；
+// h 的消息是异步处理的，应该在 h.Stop 之后再调用，否则错误和警告的数量可能不完整。
+func NewMetrics(start time.Time, stats *Stats, h *core.MessageHandler) *Metrics {
+	m := &Metrics{
+		Timestamp: start,
+		Duration:  time.Since(start).Milliseconds(),
+	}
+	if stats != nil {
+		m.APICount = stats.TotalAPIs
+	}
+	m.ErrorCount, m.WarningCount = h.Count()
+
+	return m
+}
+
+// Save 以 JSON 格式将 m 保存至 path
+func (m *Metrics) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, os.ModePerm)
+}
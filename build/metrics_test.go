@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+)
+
+func TestMetrics(t *testing.T) {
+	a := assert.New(t, false)
+	start := time.Now().Add(-time.Second)
+
+	rslt := messagetest.NewMessageHandler()
+	rslt.Handler.Error(core.NewError("err"))
+	rslt.Handler.Warning(core.NewError("warn"))
+	rslt.Handler.Warning(core.NewError("warn"))
+	rslt.Handler.Stop()
+
+	m := NewMetrics(start, &Stats{TotalAPIs: 5}, rslt.Handler)
+	a.Equal(m.Timestamp, start).
+		True(m.Duration >= 1000).
+		Equal(m.APICount, 5).
+		Equal(m.ErrorCount, 1).
+		Equal(m.WarningCount, 2)
+
+	// 构建未完成
+	m = NewMetrics(start, nil, rslt.Handler)
+	a.Equal(m.APICount, 0).Equal(m.ErrorCount, 1)
+
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	a.NotError(m.Save(path))
+	data, err := os.ReadFile(path)
+	a.NotError(err)
+
+	fields := map[string]interface{}{}
+	a.NotError(json.Unmarshal(data, &fields))
+	a.Length(fields, 5).
+		Equal(fields["api_count"], 0.0).
+		Equal(fields["error_count"], 1.0).
+		Equal(fields["warning_count"], 2.0).
+		NotNil(fields["timestamp"]).
+		NotNil(fields["duration_ms"])
+}
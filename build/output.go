@@ -5,10 +5,16 @@ package build
 import (
 	"bytes"
 	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/issue9/errwrap"
+	"github.com/issue9/sliceutil"
 	"github.com/issue9/version"
 
 	"github.com/caixw/apidoc/v7/core"
@@ -26,7 +32,17 @@ const (
 	OpenapiJSON = "openapi+json"
 )
 
-type marshaler func(*ast.APIDoc) ([]byte, error)
+// Output.Sort 的可选值
+const (
+	SortPath   = "path"   // 按路径排序，路径相同的按请求方法排序
+	SortMethod = "method" // 按请求方法排序，请求方法相同的按路径排序
+	SortTag    = "tag"    // 按第一个标签排序，没有标签的排在最后
+)
+
+// Output.StylesheetVersion 的格式
+var stylesheetVersionRegexp = regexp.MustCompile(`^v[1-9][0-9]*$`)
+
+type marshaler func(*core.MessageHandler, *ast.APIDoc) ([]byte, error)
 
 // Output 指定了渲染输出的相关设置项。
 type Output struct {
@@ -41,12 +57,49 @@ type Output struct {
 
 	// 文档的保存路径
 	//
-	// 仅适用本地路径
+	// 可以是本地路径，也可以是 http 或 https 地址，远程地址以 PUT 请求写入。
 	Path core.URI `yaml:"path"`
 
+	// 以 text/template 模板的形式指定文档的保存路径
+	//
+	// 如果不为空，则以生成的路径代替 Path。模板中可以使用以下变量：
+	//  - Title 文档的标题；
+	//  - Version 文档的版本号；
+	// Title 和 Version 中的 /、\ 和 .. 会被替换成 -，以免改变目录结构；
+	//  - Date 文档的生成日期，格式为 2006-01-02；
+	//  - Type 输出的文件类型，即 Output.Type 的值；
+	// 比如 docs/{{.Title}}-{{.Version}}.xml。
+	//
+	// 如果生成的是相对路径，通过配置文件加载时，以配置文件所在的目录为基准。
+	PathTemplate string `yaml:"path-template,omitempty"`
+
 	// 只输出该标签的文档，若为空，则表示所有。
 	Tags []string `yaml:"tags,omitempty"`
 
+	// 接口在文档中的排列顺序
+	//
+	// 可以是 path、method 和 tag，为空表示 path。
+	Sort string `yaml:"sort,omitempty"`
+
+	// 不需要输出的服务器名称
+	//
+	// 这些服务器会从文档中删除，同时也会删除各个接口中对这些服务器的引用。
+	// 如果接口因此不再引用任何服务器，则表示该接口适用于剩余的所有服务器。
+	SkipServers []string `yaml:"skip-servers,omitempty"`
+
+	// 不输出已经弃用的内容
+	//
+	// 为 true 时，会删除所有指定了 deprecated 的接口、标签和服务器，
+	// 同时也会删除接口中对这些标签和服务器的引用。
+	ExcludeDeprecated bool `yaml:"exclude-deprecated,omitempty"`
+
+	// 只输出已经弃用的内容
+	//
+	// 与 ExcludeDeprecated 相反，只保留指定了 deprecated 的接口、标签和服务器，
+	// 被保留的接口所引用的标签和服务器即使未弃用也会保留。
+	// 可用于生成单独的迁移文档。两者不能同时为 true。
+	DeprecatedOnly bool `yaml:"deprecated-only,omitempty"`
+
 	// xslt 文件地址
 	//
 	// 默认值为 https://apidoc.tools/docs/ 下当前版本的 apidoc.xsl，比如：
@@ -55,6 +108,13 @@ type Output struct {
 	// NOTE: 仅针对 xml 类型的输出文件
 	Style string `yaml:"style,omitempty"`
 
+	// 默认 xslt 文件的版本
+	//
+	// 格式为 vN，比如 v5 会生成 https://apidoc.tools/v5/apidoc.xsl，
+	// 可用于在升级程序之后依然使用已知可用的 xslt 文件。
+	// 仅在 Style 为空时有效。
+	StylesheetVersion string `yaml:"stylesheet-version,omitempty"`
+
 	// 命名空间的相关设置
 	//
 	// 当 namespace 为 true 时会在文档中输出以 core.XMLNamespace 作为命名空间的值，
@@ -65,9 +125,71 @@ type Output struct {
 	Namespace       bool   `yaml:"namespace,omitempty"`
 	NamespacePrefix string `yaml:"namespace-prefix,omitempty"`
 
-	procInst []string  // 保存所有 xml 的指令内容，包括编码信息
-	marshal  marshaler // Type 对应的转换函数
-	xml      bool      // 是否为 xml 内容
+	// 每一级的缩进内容
+	//
+	// 只能由空格和制表符组成，默认值为 \t。
+	//
+	// NOTE: 仅针对 Type = APIDocXML
+	Indent string `yaml:"indent,omitempty"`
+
+	// 提取所有服务器地址中共同的路径部分
+	//
+	// 为 true 时，会将所有服务器地址中共同的路径部分从服务器地址中删除，
+	// 并添加到每一个接口的路径之前。若不存在共同的路径部分，则给出警告信息。
+	//
+	// NOTE: 仅针对 Type = OpenapiJSON 和 OpenapiYAML
+	ExtractBasePath bool `yaml:"extract-base-path,omitempty"`
+
+	// 为未指定 id 的接口自动生成 operationId
+	//
+	// 由请求方法和路径组成，比如 GET /users/{id} 会生成 getUsersById，
+	// 与已有的值重复时，会在末尾添加数字加以区分。
+	//
+	// NOTE: 仅针对 Type = OpenapiJSON 和 OpenapiYAML
+	GenerateOperationIDs bool `yaml:"generate-operation-ids,omitempty"`
+
+	// 自动生成的 operationId 的命名风格
+	//
+	// 可以是 camel、snake 或 kebab，默认值为 camel。
+	//
+	// NOTE: 仅针对 Type = OpenapiJSON 和 OpenapiYAML
+	OperationIDStyle string `yaml:"operation-id-style,omitempty"`
+
+	// 将结构完全相同的类型提取至 components.schemas
+	//
+	// 为 true 时，多次出现的相同类型只定义一次，其它地方以 $ref 的形式引用。
+	//
+	// NOTE: 仅针对 Type = OpenapiJSON 和 OpenapiYAML
+	DeduplicateSchemas bool `yaml:"deduplicate-schemas,omitempty"`
+
+	// 不输出任何 x- 开头的扩展字段
+	//
+	// 为 true 时，如果文档中存在无法以 OpenAPI 原生字段表示的内容，
+	// 比如标签的 deprecated 和 color 属性、接口的 ext 元素等，则直接返回错误。
+	// 可用于确认文档能否完全以标准的 OpenAPI 表示。
+	//
+	// NOTE: 仅针对 Type = OpenapiJSON 和 OpenapiYAML
+	OpenAPIStrict bool `yaml:"openapi-strict,omitempty"`
+
+	// 是否在分析文档之前检测 Path 所在的目录是否可写
+	//
+	// 默认为 true，可以避免在长时间的分析之后才发现无法写入文件。
+	// 指定了 PathTemplate 时，由于路径需要在分析之后才能确定，不作检测。
+	CheckWritable *bool `yaml:"check-writable,omitempty"`
+
+	procInst     []string           // 保存所有 xml 的指令内容，包括编码信息
+	marshal      marshaler          // Type 对应的转换函数
+	xml          bool               // 是否为 xml 内容
+	pathTemplate *template.Template // 由 PathTemplate 编译而来
+	wd           core.URI           // PathTemplate 生成相对路径时的基准目录
+}
+
+// PathTemplate 中可用的变量
+type pathTemplateData struct {
+	Title   string
+	Version string
+	Date    string
+	Type    string
 }
 
 func (o *Output) contains(tags ...string) bool {
@@ -85,6 +207,124 @@ func (o *Output) contains(tags ...string) bool {
 	return false
 }
 
+func (o *Output) clone() *Output {
+	c := *o
+	c.Tags = cloneStrings(o.Tags)
+	c.SkipServers = cloneStrings(o.SkipServers)
+	c.procInst = cloneStrings(o.procInst)
+	if o.CheckWritable != nil {
+		v := *o.CheckWritable
+		c.CheckWritable = &v
+	}
+	return &c
+}
+
+// Style 为空时采用的默认值
+func (o *Output) defaultStyle() string {
+	if o.StylesheetVersion != "" {
+		return docs.VersionAssetURL(core.OfficialURL, o.StylesheetVersion, "apidoc.xsl")
+	}
+	return docs.StylesheetURL(core.OfficialURL)
+}
+
+// 将 other 中的非零值合并到 o 中，Tags 和 SkipServers 取两者的并集。
+func (o *Output) mergeWith(other *Output) {
+	if other.Version != "" {
+		o.Version = other.Version
+	}
+	if other.Type != "" {
+		o.Type = other.Type
+	}
+	if other.Path != "" {
+		o.Path = other.Path
+	}
+	if other.PathTemplate != "" {
+		o.PathTemplate = other.PathTemplate
+	}
+	if other.Style != "" {
+		o.Style = other.Style
+	}
+	if other.StylesheetVersion != "" {
+		o.StylesheetVersion = other.StylesheetVersion
+	}
+	if other.Sort != "" {
+		o.Sort = other.Sort
+	}
+	if other.Namespace {
+		o.Namespace = true
+	}
+	if other.NamespacePrefix != "" {
+		o.NamespacePrefix = other.NamespacePrefix
+	}
+	if other.CheckWritable != nil {
+		v := *other.CheckWritable
+		o.CheckWritable = &v
+	}
+
+	if other.Indent != "" {
+		o.Indent = other.Indent
+	}
+	if other.ExtractBasePath {
+		o.ExtractBasePath = true
+	}
+	if other.GenerateOperationIDs {
+		o.GenerateOperationIDs = true
+	}
+	if other.OperationIDStyle != "" {
+		o.OperationIDStyle = other.OperationIDStyle
+	}
+	if other.DeduplicateSchemas {
+		o.DeduplicateSchemas = true
+	}
+	if other.OpenAPIStrict {
+		o.OpenAPIStrict = true
+	}
+
+	if other.ExcludeDeprecated {
+		o.ExcludeDeprecated = true
+	}
+	if other.DeprecatedOnly {
+		o.DeprecatedOnly = true
+	}
+
+	o.Tags = union(o.Tags, other.Tags)
+	o.SkipServers = union(o.SkipServers, other.SkipServers)
+}
+
+// 将 s2 中不存在于 s1 的元素追加到 s1 之后
+func union(s1, s2 []string) []string {
+	for _, item := range s2 {
+		if !sliceutil.Exists(s1, func(i string) bool { return i == item }) {
+			s1 = append(s1, item)
+		}
+	}
+	return s1
+}
+
+// 检测 Path 所在的目录是否可写
+//
+// 通过在该目录下创建临时文件进行检测。
+func (o *Output) checkWritable() error {
+	if (o.CheckWritable != nil && !*o.CheckWritable) || o.PathTemplate != "" || isRemote(o.Path) {
+		return nil
+	}
+
+	file, err := o.Path.File()
+	if err != nil {
+		return core.WithError(err).WithField("path")
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(file), ".apidoc-*")
+	if err != nil {
+		return core.WithError(err).WithField("path")
+	}
+
+	if err = f.Close(); err != nil {
+		return core.WithError(err).WithField("path")
+	}
+	return os.Remove(f.Name())
+}
+
 func (o *Output) sanitize() error {
 	if o.Type == "" {
 		o.Type = APIDocXML
@@ -96,48 +336,130 @@ func (o *Output) sanitize() error {
 		}
 	}
 
+	if o.StylesheetVersion != "" && !stylesheetVersionRegexp.MatchString(o.StylesheetVersion) {
+		return core.NewError(locale.ErrInvalidFormat).WithField("stylesheet-version")
+	}
+
+	if o.ExcludeDeprecated && o.DeprecatedOnly {
+		return core.NewError(locale.ErrInvalidValue).WithField("deprecated-only")
+	}
+
+	switch o.Sort {
+	case "", SortPath, SortMethod, SortTag:
+	default:
+		return core.NewError(locale.ErrInvalidValue).WithField("sort")
+	}
+
 	switch o.Type {
 	case APIDocXML:
 		o.marshal = o.apidocMarshaler
 	case OpenapiJSON:
-		o.marshal = openapi.JSON
+		o.marshal = func(h *core.MessageHandler, d *ast.APIDoc) ([]byte, error) {
+			return openapi.JSON(h, d, o.openapiOptions())
+		}
 	case OpenapiYAML:
-		o.marshal = openapi.YAML
+		o.marshal = func(h *core.MessageHandler, d *ast.APIDoc) ([]byte, error) {
+			return openapi.YAML(h, d, o.openapiOptions())
+		}
 	default:
 		return core.NewError(locale.ErrInvalidValue).WithField("type")
 	}
 
+	if o.Indent == "" {
+		o.Indent = "\t"
+	} else if strings.Trim(o.Indent, " \t") != "" {
+		return core.NewError(locale.ErrInvalidValue).WithField("indent")
+	}
+
 	o.xml = strings.HasSuffix(o.Type, "+xml")
 	if o.xml {
 		if o.Style == "" {
-			o.Style = docs.StylesheetURL(core.OfficialURL)
+			o.Style = o.defaultStyle()
 		}
 
 		o.procInst = []string{
 			xml.Header,
 			`<?xml-stylesheet type="text/xsl" href="` + o.Style + `"?>`,
 		}
+	} else {
+		if !openapi.IsOperationIDStyle(o.OperationIDStyle) {
+			return core.NewError(locale.ErrInvalidValue).WithField("operation-id-style")
+		}
 	}
 
-	if len(o.Path) > 0 {
+	if len(o.Path) > 0 && !isRemote(o.Path) { // 远程地址以 PUT 请求写入
 		scheme, _ := o.Path.Parse()
 		if scheme != core.SchemeFile && scheme != "" {
 			return core.NewError(locale.ErrInvalidURIScheme, scheme).WithField("path")
 		}
 	}
 
+	o.pathTemplate = nil
+	if o.PathTemplate != "" {
+		tpl, err := template.New("path").Option("missingkey=error").Parse(o.PathTemplate)
+		if err != nil {
+			return core.WithError(err).WithField("path-template")
+		}
+		o.pathTemplate = tpl
+	}
+
 	return nil
 }
 
-func (o *Output) apidocMarshaler(d *ast.APIDoc) ([]byte, error) {
+// 将 Title 和 Version 中可能改变目录结构的字符替换掉
+var pathSegmentReplacer = strings.NewReplacer("/", "-", "\\", "-", "..", "-")
+
+// 返回文档的保存路径
+//
+// 指定了 PathTemplate 时，根据 d 的内容生成，否则直接返回 Path，不会修改 o 的内容。
+func (o *Output) outputPath(d *ast.APIDoc) (core.URI, error) {
+	if o.pathTemplate == nil {
+		return o.Path, nil
+	}
+
+	data := &pathTemplateData{
+		Title:   pathSegmentReplacer.Replace(d.Title.V()),
+		Version: pathSegmentReplacer.Replace(d.Version.V()),
+		Date:    d.Created.V().Format("2006-01-02"),
+		Type:    o.Type,
+	}
+	buf := &strings.Builder{}
+	if err := o.pathTemplate.Execute(buf, data); err != nil {
+		return "", core.WithError(err).WithField("path-template")
+	}
+
+	path := core.URI(buf.String())
+	if o.wd != "" {
+		p, err := abs(path, o.wd)
+		if err != nil {
+			return "", core.WithError(err).WithField("path-template")
+		}
+		path = p
+	}
+
+	return path, nil
+}
+
+func (o *Output) openapiOptions() *openapi.Options {
+	return &openapi.Options{
+		ExtractBasePath:      o.ExtractBasePath,
+		GenerateOperationIDs: o.GenerateOperationIDs,
+		OperationIDStyle:     o.OperationIDStyle,
+		DeduplicateSchemas:   o.DeduplicateSchemas,
+		Strict:               o.OpenAPIStrict,
+	}
+}
+
+func (o *Output) apidocMarshaler(_ *core.MessageHandler, d *ast.APIDoc) ([]byte, error) {
 	if !o.Namespace {
-		return xmlenc.Encode("\t", d, "", "")
+		return xmlenc.Encode(o.Indent, d, "", "")
 	}
-	return xmlenc.Encode("\t", d, core.XMLNamespace, o.NamespacePrefix)
+	return xmlenc.Encode(o.Indent, d, core.XMLNamespace, o.NamespacePrefix)
 }
 
-func (o *Output) buffer(d *ast.APIDoc) (*bytes.Buffer, error) {
+func (o *Output) buffer(h *core.MessageHandler, d *ast.APIDoc) (*bytes.Buffer, error) {
 	filterDoc(d, o)
+	sortAPIs(d, o.Sort)
 
 	if o.Version != "" {
 		d.Version = &ast.VersionAttribute{Value: xmlenc.String{Value: o.Version}}
@@ -146,7 +468,7 @@ func (o *Output) buffer(d *ast.APIDoc) (*bytes.Buffer, error) {
 	d.Created = &ast.DateAttribute{Value: ast.Date{Value: time.Now()}}
 	d.APIDoc = &ast.APIDocVersionAttribute{Value: xmlenc.String{Value: ast.Version}}
 
-	data, err := o.marshal(d)
+	data, err := o.marshal(h, d)
 	if err != nil {
 		return nil, err
 	}
@@ -165,7 +487,181 @@ func (o *Output) buffer(d *ast.APIDoc) (*bytes.Buffer, error) {
 	return &buf.Buffer, nil
 }
 
+// 按 by 指定的方式对 d.APIs 进行排序
+func sortAPIs(d *ast.APIDoc, by string) {
+	var less func(i, j *ast.API) bool
+	switch by {
+	case SortMethod:
+		less = func(i, j *ast.API) bool {
+			if im, jm := i.Method.V(), j.Method.V(); im != jm {
+				return im < jm
+			}
+			return apiPath(i) < apiPath(j)
+		}
+	case SortTag:
+		less = func(i, j *ast.API) bool {
+			it, jt := apiTag(i), apiTag(j)
+			if it != jt {
+				return jt == "" || (it != "" && it < jt)
+			}
+			if ip, jp := apiPath(i), apiPath(j); ip != jp {
+				return ip < jp
+			}
+			return i.Method.V() < j.Method.V()
+		}
+	default: // SortPath
+		less = func(i, j *ast.API) bool {
+			if ip, jp := apiPath(i), apiPath(j); ip != jp {
+				return ip < jp
+			}
+			return i.Method.V() < j.Method.V()
+		}
+	}
+
+	sort.SliceStable(d.APIs, func(i, j int) bool { return less(d.APIs[i], d.APIs[j]) })
+}
+
+func apiPath(api *ast.API) string {
+	if api.Path == nil {
+		return ""
+	}
+	return api.Path.Path.V()
+}
+
+// 返回接口的第一个标签，没有则返回空值。
+func apiTag(api *ast.API) string {
+	if len(api.Tags) == 0 {
+		return ""
+	}
+	return api.Tags[0].V()
+}
+
 func filterDoc(d *ast.APIDoc, o *Output) {
+	filterTags(d, o)
+	filterDeprecated(d, o)
+	filterServers(d, o)
+}
+
+func filterDeprecated(d *ast.APIDoc, o *Output) {
+	switch {
+	case o.ExcludeDeprecated:
+		filterByDeprecated(d, false, func(deprecated *ast.VersionAttribute) bool {
+			return deprecated == nil
+		})
+	case o.DeprecatedOnly: // 已弃用的接口可能引用了未弃用的标签和服务器
+		filterByDeprecated(d, true, func(deprecated *ast.VersionAttribute) bool {
+			return deprecated != nil
+		})
+	}
+}
+
+// 根据 deprecated 属性过滤文档中的接口、标签和服务器
+//
+// keep 用于判断是否保留该元素，被删除的标签和服务器，其在接口中的引用也会一并删除；
+// keepReferenced 表示是否同时保留被已保留接口引用的标签和服务器。
+func filterByDeprecated(d *ast.APIDoc, keepReferenced bool, keep func(*ast.VersionAttribute) bool) {
+	apis := make([]*ast.API, 0, len(d.APIs))
+	referencedTags := make(map[string]struct{}, len(d.Tags))
+	referencedServers := make(map[string]struct{}, len(d.Servers))
+	for _, api := range d.APIs {
+		if !keep(api.Deprecated) {
+			continue
+		}
+
+		apis = append(apis, api)
+		if keepReferenced {
+			for _, tag := range api.Tags {
+				referencedTags[tag.V()] = struct{}{}
+			}
+			for _, srv := range api.Servers {
+				referencedServers[srv.V()] = struct{}{}
+			}
+		}
+	}
+	d.APIs = apis
+
+	removedTags := make([]string, 0, len(d.Tags))
+	tags := make([]*ast.Tag, 0, len(d.Tags))
+	for _, tag := range d.Tags {
+		if _, found := referencedTags[tag.Name.V()]; found || keep(tag.Deprecated) {
+			tags = append(tags, tag)
+		} else {
+			removedTags = append(removedTags, tag.Name.V())
+		}
+	}
+	d.Tags = tags
+
+	removedServers := make([]string, 0, len(d.Servers))
+	srvs := make([]*ast.Server, 0, len(d.Servers))
+	for _, srv := range d.Servers {
+		if _, found := referencedServers[srv.Name.V()]; found || keep(srv.Deprecated) {
+			srvs = append(srvs, srv)
+		} else {
+			removedServers = append(removedServers, srv.Name.V())
+		}
+	}
+	d.Servers = srvs
+
+	removed := func(names []string, name string) bool {
+		return sliceutil.Exists(names, func(s string) bool { return s == name })
+	}
+
+	for _, api := range d.APIs {
+		if len(removedTags) > 0 && len(api.Tags) > 0 {
+			values := make([]*ast.TagValue, 0, len(api.Tags))
+			for _, tag := range api.Tags {
+				if !removed(removedTags, tag.V()) {
+					values = append(values, tag)
+				}
+			}
+			api.Tags = values
+		}
+
+		if len(removedServers) > 0 && len(api.Servers) > 0 {
+			values := make([]*ast.ServerValue, 0, len(api.Servers))
+			for _, srv := range api.Servers {
+				if !removed(removedServers, srv.V()) {
+					values = append(values, srv)
+				}
+			}
+			api.Servers = values
+		}
+	}
+}
+
+func filterServers(d *ast.APIDoc, o *Output) {
+	if len(o.SkipServers) == 0 {
+		return
+	}
+
+	skip := func(name string) bool {
+		return sliceutil.Exists(o.SkipServers, func(s string) bool { return s == name })
+	}
+
+	srvs := make([]*ast.Server, 0, len(d.Servers))
+	for _, srv := range d.Servers {
+		if !skip(srv.Name.V()) {
+			srvs = append(srvs, srv)
+		}
+	}
+	d.Servers = srvs
+
+	for _, api := range d.APIs {
+		if len(api.Servers) == 0 {
+			continue
+		}
+
+		values := make([]*ast.ServerValue, 0, len(api.Servers))
+		for _, srv := range api.Servers {
+			if !skip(srv.V()) {
+				values = append(values, srv)
+			}
+		}
+		api.Servers = values
+	}
+}
+
+func filterTags(d *ast.APIDoc, o *Output) {
 	if len(o.Tags) == 0 {
 		return
 	}
@@ -3,13 +3,24 @@
 package build
 
 import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/issue9/assert/v2"
 
 	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/ast/asttest"
 	"github.com/caixw/apidoc/v7/internal/docs"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
 )
 
 func TestOptions_contains(t *testing.T) {
@@ -36,6 +47,13 @@ func TestOutput_Sanitize(t *testing.T) {
 	o = &Output{Type: "invalid-type"}
 	a.Error(o.sanitize())
 
+	o = &Output{Type: OpenapiYAML, OperationIDStyle: "snake"}
+	a.NotError(o.sanitize())
+	a.Equal(o.openapiOptions().OperationIDStyle, "snake")
+
+	o = &Output{Type: OpenapiYAML, OperationIDStyle: "pascal"}
+	a.Error(o.sanitize())
+
 	o = &Output{Type: APIDocXML}
 	o.Path = "./testdir/apidoc.json"
 	a.NotError(o.sanitize())
@@ -47,6 +65,23 @@ func TestOutput_Sanitize(t *testing.T) {
 	a.NotError(o.sanitize())
 	o.Version = "1"
 	a.Error(o.sanitize())
+
+	// StylesheetVersion
+	o = &Output{StylesheetVersion: "v5"}
+	a.NotError(o.sanitize())
+	a.Equal(o.Style, "https://apidoc.tools/v5/apidoc.xsl").
+		Contains(o.procInst[1], "https://apidoc.tools/v5/apidoc.xsl")
+
+	o = &Output{StylesheetVersion: "v5", Style: "https://example.com/apidoc.xsl"}
+	a.NotError(o.sanitize())
+	a.Equal(o.Style, "https://example.com/apidoc.xsl")
+
+	for _, v := range []string{"5", "v", "v0", "v5.1", "V5"} {
+		o = &Output{StylesheetVersion: v}
+		err := o.sanitize()
+		a.Error(err, "%s 未返回错误", v)
+		a.Equal(err.(*core.Error).Field, "stylesheet-version")
+	}
 }
 
 func TestOptions_buffer(t *testing.T) {
@@ -58,16 +93,220 @@ func TestOptions_buffer(t *testing.T) {
 		Path: "./openapi.json",
 	}
 	a.NotError(o.sanitize())
-	_, err := o.buffer(doc)
+	_, err := o.buffer(nil, doc)
 	a.NotError(err)
 
 	doc = asttest.Get()
 	o = &Output{}
 	a.NotError(o.sanitize())
-	buf, err := o.buffer(doc)
+	buf, err := o.buffer(nil, doc)
 	a.NotError(err).NotNil(buf)
 }
 
+func TestOutput_Indent(t *testing.T) {
+	a := assert.New(t, false)
+
+	o := &Output{}
+	a.NotError(o.sanitize())
+	a.Equal(o.Indent, "\t")
+	tab, err := o.marshal(nil, asttest.Get())
+	a.NotError(err).Contains(string(tab), "\n\t<")
+
+	o = &Output{Indent: "  "}
+	a.NotError(o.sanitize())
+	space, err := o.marshal(nil, asttest.Get())
+	a.NotError(err).
+		Contains(string(space), "\n  <").
+		NotContains(string(space), "\t")
+	a.Equal(strings.ReplaceAll(string(tab), "\t", "  "), string(space))
+
+	o = &Output{Indent: "-"}
+	a.Error(o.sanitize())
+}
+
+func TestOutput_PathTemplate(t *testing.T) {
+	a := assert.New(t, false)
+
+	// 无效的模板
+	o := &Output{PathTemplate: "{{.Title"}
+	a.Error(o.sanitize())
+
+	dir := t.TempDir()
+	o = &Output{
+		Type:         OpenapiJSON,
+		Version:      "1.2.3",
+		Path:         "./not-used.json",
+		PathTemplate: "{{.Title}}-{{.Version}}-{{.Date}}.{{.Type}}",
+		wd:           core.FileURI(dir),
+	}
+	a.NotError(o.sanitize()).NotNil(o.pathTemplate)
+
+	doc := asttest.Get()
+	buf, err := o.buffer(nil, doc)
+	a.NotError(err).NotNil(buf)
+	path, err := o.outputPath(doc)
+	a.NotError(err)
+	date := doc.Created.V().Format("2006-01-02")
+	a.Equal(path, core.FileURI(dir).Append("test-1.2.3-"+date+"."+OpenapiJSON)).
+		Equal(o.Path, "./not-used.json") // 不会修改 Path
+
+	// 标题和版本号中包含路径分隔符
+	doc.Title.Content.Value = "../a/b"
+	doc.Version.Value.Value = "1\\2"
+	path, err = o.outputPath(doc)
+	a.NotError(err).
+		Equal(path, core.FileURI(dir).Append("--a-b-1-2-"+date+"."+OpenapiJSON))
+
+	// 未指定 PathTemplate
+	o = &Output{Path: "./apidoc.xml"}
+	a.NotError(o.sanitize())
+	path, err = o.outputPath(doc)
+	a.NotError(err).Equal(path, "./apidoc.xml")
+
+	// 不存在的变量
+	o = &Output{PathTemplate: "{{.NotExists}}"}
+	a.NotError(o.sanitize())
+	path, err = o.outputPath(doc)
+	a.Error(err).Equal(path, "")
+}
+
+func TestOutput_checkWritable(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	o := &Output{Path: core.FileURI(filepath.Join(dir, "apidoc.xml"))}
+	a.NotError(o.checkWritable())
+	entries, err := os.ReadDir(dir)
+	a.NotError(err).Empty(entries) // 临时文件已经删除
+
+	// 目录不存在
+	o = &Output{Path: core.FileURI(filepath.Join(dir, "not-exists", "apidoc.xml"))}
+	err = o.checkWritable()
+	a.Error(err)
+	serr, ok := err.(*core.Error)
+	a.True(ok).Equal(serr.Field, "path")
+
+	// 不检测
+	disabled := false
+	o.CheckWritable = &disabled
+	a.NotError(o.checkWritable())
+
+	// 由 PathTemplate 生成路径
+	o = &Output{Path: core.FileURI(filepath.Join(dir, "not-exists", "apidoc.xml")), PathTemplate: "{{.Title}}.xml"}
+	a.NotError(o.checkWritable())
+
+	// 在分析文档之前返回错误
+	rslt := messagetest.NewMessageHandler()
+	err = Build(rslt.Handler, &Output{Path: core.FileURI(filepath.Join(dir, "not-exists", "apidoc.xml"))}, &Input{Lang: "c++", Dir: "./testdata"})
+	rslt.Handler.Stop()
+	a.Error(err).Empty(rslt.Errors).Empty(rslt.Warns)
+
+	// 只读目录，root 用户不受权限限制。
+	if os.Geteuid() != 0 {
+		ro := filepath.Join(dir, "readonly")
+		a.NotError(os.Mkdir(ro, 0o555))
+		o = &Output{Path: core.FileURI(filepath.Join(ro, "apidoc.xml"))}
+		err = o.checkWritable()
+		a.Error(err).True(errors.Is(err, fs.ErrPermission))
+	}
+}
+
+// 以 PUT 请求写入远程地址
+func TestBuild_remote(t *testing.T) {
+	a := assert.New(t, false)
+
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/apidoc.xml" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		a.NotError(err)
+		body = data
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	rslt := messagetest.NewMessageHandler()
+	err := Build(rslt.Handler, &Output{Path: core.URI(srv.URL + "/apidoc.xml")}, &Input{Lang: "c++", Dir: "./testdata"})
+	rslt.Handler.Stop()
+	a.NotError(err).Empty(rslt.Errors)
+	a.True(strings.HasPrefix(string(body), "<?xml"))
+
+	// 服务端返回错误
+	rslt = messagetest.NewMessageHandler()
+	err = Build(rslt.Handler, &Output{Path: core.URI(srv.URL + "/not-exists.xml")}, &Input{Lang: "c++", Dir: "./testdata"})
+	rslt.Handler.Stop()
+	a.Error(err)
+
+	// 其它协议
+	o := &Output{Path: "ftp://example.com/apidoc.xml"}
+	a.Error(o.sanitize())
+}
+
+func TestSortAPIs(t *testing.T) {
+	a := assert.New(t, false)
+
+	newAPI := func(method, path string, tags ...string) *ast.API {
+		api := &ast.API{
+			Method: &ast.MethodAttribute{Value: xmlenc.String{Value: method}},
+			Path:   &ast.Path{Path: &ast.Attribute{Value: xmlenc.String{Value: path}}},
+		}
+		for _, tag := range tags {
+			api.Tags = append(api.Tags, &ast.TagValue{Content: ast.Content{Value: tag}})
+		}
+		return api
+	}
+	newDoc := func() *ast.APIDoc {
+		return &ast.APIDoc{APIs: []*ast.API{
+			newAPI("POST", "/users", "user"),
+			newAPI("GET", "/users", "user"),
+			newAPI("DELETE", "/admins"),
+			newAPI("GET", "/admins", "admin", "user"),
+		}}
+	}
+	keys := func(d *ast.APIDoc) []string {
+		ret := make([]string, 0, len(d.APIs))
+		for _, api := range d.APIs {
+			ret = append(ret, api.Method.V()+" "+api.Path.Path.V())
+		}
+		return ret
+	}
+
+	d := newDoc()
+	sortAPIs(d, SortPath)
+	a.Equal(keys(d), []string{"DELETE /admins", "GET /admins", "GET /users", "POST /users"})
+
+	d = newDoc()
+	d.APIs = append(d.APIs, newAPI("GET", "/a"))
+	sortAPIs(d, SortMethod)
+	a.Equal(keys(d), []string{"DELETE /admins", "GET /a", "GET /admins", "GET /users", "POST /users"})
+
+	d = newDoc()
+	sortAPIs(d, SortTag)
+	a.Equal(keys(d), []string{"GET /admins", "GET /users", "POST /users", "DELETE /admins"})
+
+	d = newDoc()
+	sortAPIs(d, "")
+	a.Equal(keys(d), []string{"DELETE /admins", "GET /admins", "GET /users", "POST /users"})
+
+	// Output.Sort
+	o := &Output{}
+	a.NotError(o.sanitize())
+	a.Equal(o.Sort, "")
+
+	o = &Output{Sort: "none"}
+	a.Error(o.sanitize())
+
+	o = &Output{Sort: SortTag}
+	a.NotError(o.sanitize())
+	a.Equal(o.Sort, SortTag)
+
+	o = &Output{Sort: "invalid"}
+	a.Error(o.sanitize())
+}
+
 func TestFilterDoc(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -113,3 +352,155 @@ func TestFilterDoc(t *testing.T) {
 	a.Equal(0, len(d.Tags)).
 		Equal(0, len(d.APIs))
 }
+
+func TestFilterDoc_SkipServers(t *testing.T) {
+	a := assert.New(t, false)
+
+	serverNames := func(values []*ast.ServerValue) []string {
+		names := make([]string, 0, len(values))
+		for _, v := range values {
+			names = append(names, v.V())
+		}
+		return names
+	}
+
+	// 不存在的服务器
+	d := asttest.Get()
+	o := &Output{SkipServers: []string{"not-exists"}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Equal(2, len(d.Servers)).
+		Equal(serverNames(d.APIs[0].Servers), []string{"admin"}).
+		Equal(serverNames(d.APIs[1].Servers), []string{"admin", "client"})
+
+	d = asttest.Get()
+	o = &Output{SkipServers: []string{"client"}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Equal(1, len(d.Servers)).
+		Equal(d.Servers[0].Name.V(), "admin").
+		Equal(serverNames(d.APIs[0].Servers), []string{"admin"}).
+		Equal(serverNames(d.APIs[1].Servers), []string{"admin"})
+
+	// 接口不再引用任何服务器，表示适用于剩余的所有服务器。
+	d = asttest.Get()
+	o = &Output{SkipServers: []string{"admin"}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Equal(1, len(d.Servers)).
+		Equal(d.Servers[0].Name.V(), "client").
+		Equal(2, len(d.APIs)).
+		Empty(d.APIs[0].Servers).
+		Equal(serverNames(d.APIs[1].Servers), []string{"client"})
+
+	// 所有服务器
+	d = asttest.Get()
+	o = &Output{SkipServers: []string{"admin", "client"}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Empty(d.Servers).
+		Empty(d.APIs[0].Servers).
+		Empty(d.APIs[1].Servers)
+
+	// 与 tags 同时使用
+	d = asttest.Get()
+	o = &Output{Tags: []string{"tag1"}, SkipServers: []string{"admin"}}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Equal(1, len(d.APIs)).
+		Equal(serverNames(d.APIs[0].Servers), []string{"client"})
+
+	// 输出 openapi
+	d = asttest.Get()
+	o = &Output{Type: OpenapiJSON, SkipServers: []string{"admin"}}
+	a.NotError(o.sanitize())
+	buf, err := o.buffer(nil, d)
+	a.NotError(err).NotNil(buf).
+		False(strings.Contains(buf.String(), "https://example.com/admin"))
+}
+
+func TestFilterDoc_ExcludeDeprecated(t *testing.T) {
+	a := assert.New(t, false)
+
+	deprecated := &ast.VersionAttribute{Value: xmlenc.String{Value: "1.0.0"}}
+
+	// 未启用
+	d := asttest.Get()
+	o := &Output{}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Equal(2, len(d.APIs)).Equal(3, len(d.Tags)).Equal(2, len(d.Servers))
+
+	d = asttest.Get()
+	d.Tags[1].Deprecated = deprecated    // t2
+	d.Servers[1].Deprecated = deprecated // client
+	o = &Output{ExcludeDeprecated: true}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Equal(1, len(d.APIs)).
+		Equal(d.APIs[0].Method.V(), http.MethodGet).
+		Equal(2, len(d.Tags)).
+		Equal(d.Tags[0].Name.V(), "t1").
+		Equal(d.Tags[1].Name.V(), "tag1").
+		Equal(1, len(d.Servers)).
+		Equal(d.Servers[0].Name.V(), "admin").
+		Equal(1, len(d.APIs[0].Tags)).
+		Equal(d.APIs[0].Tags[0].V(), "t1").
+		Equal(1, len(d.APIs[0].Servers))
+
+	// 与 tags 同时使用
+	d = asttest.Get()
+	o = &Output{Tags: []string{"tag1"}, ExcludeDeprecated: true}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Empty(d.APIs).Equal(1, len(d.Tags))
+}
+
+func TestFilterDoc_DeprecatedOnly(t *testing.T) {
+	a := assert.New(t, false)
+
+	deprecated := &ast.VersionAttribute{Value: xmlenc.String{Value: "1.0.0"}}
+
+	// 与 ExcludeDeprecated 冲突
+	o := &Output{DeprecatedOnly: true, ExcludeDeprecated: true}
+	err := o.sanitize()
+	a.Error(err)
+	cerr, ok := err.(*core.Error)
+	a.True(ok).Equal(cerr.Field, "deprecated-only")
+
+	d := asttest.Get()
+	d.Tags[2].Deprecated = deprecated    // tag1
+	d.Servers[1].Deprecated = deprecated // client
+	o = &Output{DeprecatedOnly: true}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Equal(1, len(d.APIs)).
+		Equal(d.APIs[0].Method.V(), http.MethodPost).
+		Equal(2, len(d.Tags)). // 未弃用的 t1 被保留的接口引用
+		Equal(d.Tags[0].Name.V(), "t1").
+		Equal(d.Tags[1].Name.V(), "tag1").
+		Equal(2, len(d.Servers)). // 未弃用的 admin 被保留的接口引用
+		Equal(d.Servers[0].Name.V(), "admin").
+		Equal(d.Servers[1].Name.V(), "client").
+		Equal(2, len(d.APIs[0].Tags)).
+		Equal(2, len(d.APIs[0].Servers))
+
+	// 未被引用的标签依然会被删除
+	d = asttest.Get()
+	o = &Output{DeprecatedOnly: true}
+	a.NotError(o.sanitize())
+	filterDoc(d, o)
+	a.Equal(1, len(d.APIs)).
+		Equal(2, len(d.Tags)).
+		Equal(d.Tags[0].Name.V(), "t1").
+		Equal(d.Tags[1].Name.V(), "tag1")
+
+	// 输出内容只包含弃用的接口
+	d = asttest.Get()
+	o = &Output{DeprecatedOnly: true}
+	a.NotError(o.sanitize())
+	buf, err := o.buffer(nil, d)
+	a.NotError(err).NotNil(buf)
+	a.True(strings.Contains(buf.String(), `method="POST"`)).
+		False(strings.Contains(buf.String(), `method="GET"`))
+}
@@ -11,6 +11,12 @@ import (
 	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
+// 是否为 http 或 https 协议的远程地址
+func isRemote(uri core.URI) bool {
+	scheme, _ := uri.Parse()
+	return scheme == core.SchemeHTTP || scheme == core.SchemeHTTPS
+}
+
 // 获取 path 的绝对路径
 //
 // 如果 path 是相对路径的，则将其设置为相对于 wd 的路径。
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: MIT
+
+package build
+
+import "github.com/caixw/apidoc/v7/internal/ast"
+
+// Stats 文档的统计信息
+//
+// 包含以下字段：
+//  - TotalAPIs 接口数量；
+//  - DeprecatedAPIs 已弃用的接口数量；
+//  - TotalTags 标签数量；
+//  - TotalServers 服务器数量；
+//  - UniqueRequestTypes 请求内容中不同类型的数量；
+//  - UniqueResponseTypes 返回内容中不同类型的数量；
+//  - APIsPerTag 各个标签下的接口数量，键名为标签名；
+type Stats ast.DocStats
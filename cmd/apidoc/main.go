@@ -10,6 +10,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -27,12 +28,17 @@ func main() {
 		fmt.Fprintln(os.Stderr, err, tag)
 		tag = language.MustParse(locale.DefaultLocaleID)
 	}
-	apidoc.SetLocale(tag)
+	apidoc.SetLocaleOrDefault(tag) // 系统语言不被支持时采用默认值
 
 	if err := cmd.Init(os.Stdout).Exec(os.Args[1:]); err != nil {
 		if _, err := fmt.Fprintln(os.Stderr, err); err != nil {
 			panic(err)
 		}
+
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(2)
 	}
 }
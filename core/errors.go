@@ -3,6 +3,8 @@
 package core
 
 import (
+	"errors"
+
 	"github.com/issue9/sliceutil"
 	"golang.org/x/text/message"
 
@@ -67,6 +69,20 @@ func (err *Error) Error() string {
 	return locale.Sprintf(locale.ErrMessage, err.Err.Error(), detail)
 }
 
+// Code 返回错误信息对应的本地化键名
+//
+// 可用于在不解析错误信息的情况下判断错误的种类，
+// 如果 Err 并不是由本地化键名生成的，则返回空值。
+func (err *Error) Code() string {
+	var lerr *locale.Err
+	if errors.As(err.Err, &lerr) {
+		if key, ok := lerr.Key.(string); ok {
+			return key
+		}
+	}
+	return ""
+}
+
 // Unwrap 实现 errors.Unwrap 接口
 func (err *Error) Unwrap() error { return err.Err }
 
@@ -33,6 +33,15 @@ func TestWithError(t *testing.T) {
 	a.Equal(serr2.Err, err)
 }
 
+func TestError_Code(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(NewError(locale.ErrInvalidFormat).Code(), locale.ErrInvalidFormat)
+	a.Equal(Location{}.NewError(locale.ErrIsEmpty, "id").WithField("id").Code(), locale.ErrIsEmpty)
+	a.Equal(WithError(NewError(locale.ErrInvalidValue)).Code(), locale.ErrInvalidValue)
+	a.Equal(WithError(errors.New("test")).Code(), "")
+}
+
 func TestError_AddTypes(t *testing.T) {
 	a := assert.New(t, false)
 	loc := Location{}
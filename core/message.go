@@ -3,6 +3,9 @@
 package core
 
 import (
+	"fmt"
+	"sync"
+
 	"golang.org/x/text/message"
 
 	"github.com/caixw/apidoc/v7/internal/locale"
@@ -49,17 +52,41 @@ type HandlerFunc func(*Message)
 type MessageHandler struct {
 	messages chan *Message
 	stop     chan struct{}
+	handlers []*MessageHandler // 由 NewMultiMessageHandler 创建时，消息需要转发的对象
+
+	collect bool // 是否需要记录错误和警告信息，由 NewCollectMessageHandler 创建时为 true
+	mux     sync.RWMutex
+	errors  []*Error
+	warns   []*Error
 }
 
 // NewMessageHandler 声明新的 MessageHandler 实例
+//
+// 返回的实例不会记录已经处理的消息，Errors、Warnings 和 Count 始终返回空值。
 func NewMessageHandler(f HandlerFunc) *MessageHandler {
+	return newMessageHandler(f, false)
+}
+
+// NewCollectMessageHandler 声明会记录错误和警告信息的 MessageHandler 实例
+//
+// 所有的错误和警告信息都会保留到实例被回收为止，
+// 仅适用于生命周期较短的场景，比如单次的文档生成。
+func NewCollectMessageHandler(f HandlerFunc) *MessageHandler {
+	return newMessageHandler(f, true)
+}
+
+func newMessageHandler(f HandlerFunc, collect bool) *MessageHandler {
 	h := &MessageHandler{
 		messages: make(chan *Message, 100),
 		stop:     make(chan struct{}),
+		collect:  collect,
 	}
 
 	go func() {
 		for msg := range h.messages {
+			if h.collect {
+				h.record(msg)
+			}
 			f(msg)
 		}
 		h.stop <- struct{}{}
@@ -68,6 +95,79 @@ func NewMessageHandler(f HandlerFunc) *MessageHandler {
 	return h
 }
 
+// NewMultiMessageHandler 声明将消息转发给多个 MessageHandler 的实例
+//
+// 所有发送给返回对象的消息都会转发给 handlers 中的每一个元素，
+// 调用返回对象的 Stop 时，也会依次调用 handlers 中每一个元素的 Stop。
+func NewMultiMessageHandler(handlers ...*MessageHandler) *MessageHandler {
+	h := NewMessageHandler(func(msg *Message) {
+		for _, handler := range handlers {
+			handler.Message(msg.Type, msg.Message)
+		}
+	})
+	h.handlers = handlers
+	return h
+}
+
+// 记录错误和警告类型的消息
+func (h *MessageHandler) record(msg *Message) {
+	if msg.Type != Erro && msg.Type != Warn {
+		return
+	}
+
+	err := messageError(msg.Message)
+	h.mux.Lock()
+	if msg.Type == Erro {
+		h.errors = append(h.errors, err)
+	} else {
+		h.warns = append(h.warns, err)
+	}
+	h.mux.Unlock()
+}
+
+// 将消息内容转换成 *Error
+func messageError(msg interface{}) *Error {
+	switch v := msg.(type) {
+	case *Error:
+		return v
+	case error:
+		return WithError(v)
+	case *locale.Locale:
+		return NewError(v.Key, v.Values...)
+	default:
+		return &Error{Err: fmt.Errorf("%v", v)}
+	}
+}
+
+// Errors 返回所有已经处理的错误类型消息
+//
+// 仅由 NewCollectMessageHandler 创建的实例才会记录消息。
+// 消息是异步处理的，只有在 Stop 之后才能保证返回所有的消息。
+// 非 *Error 类型的消息会被转换成 *Error。
+func (h *MessageHandler) Errors() []*Error {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+	return append([]*Error(nil), h.errors...)
+}
+
+// Warnings 返回所有已经处理的警告类型消息
+//
+// 其它与 Errors 相同。
+func (h *MessageHandler) Warnings() []*Error {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+	return append([]*Error(nil), h.warns...)
+}
+
+// Count 返回已经处理的错误和警告类型消息的数量
+//
+// 其它与 Errors 相同。
+func (h *MessageHandler) Count() (errors, warnings int) {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+	return len(h.errors), len(h.warns)
+}
+
 // Stop 停止处理错误内容
 //
 // 只有在消息处理完成之后，才会返回。
@@ -77,6 +177,10 @@ func (h *MessageHandler) Stop() {
 	// Stop() 调用可能是在主程序结束处。
 	// 通过 h.stop 阻塞函数返回，直到所有消息都处理完成。
 	<-h.stop
+
+	for _, handler := range h.handlers {
+		handler.Stop()
+	}
 }
 
 // Message 发送消息
@@ -4,6 +4,7 @@ package core
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -64,6 +65,46 @@ func TestHandler(t *testing.T) {
 	})
 }
 
+func TestHandler_Errors(t *testing.T) {
+	a := assert.New(t, false)
+
+	h := NewCollectMessageHandler(func(*Message) {})
+	a.NotNil(h)
+
+	err := (Location{URI: "erro.go"}).NewError(locale.ErrInvalidUTF8Character)
+	h.Error(err)
+	h.Error(errors.New("erro"))
+	h.Locale(Erro, locale.ErrInvalidUTF8Character)
+	h.Warning("warn")
+	h.Info((Location{URI: "info.go"}).NewError(locale.ErrInvalidUTF8Character))
+	h.Success("succ")
+	h.Stop()
+
+	errs, warns := h.Count()
+	a.Equal(errs, 3).Equal(warns, 1)
+
+	es := h.Errors()
+	a.Length(es, 3).
+		Equal(es[0], err).
+		Equal(es[1].Err.Error(), "erro").
+		Equal(es[2].Err.Error(), locale.Sprintf(locale.ErrInvalidUTF8Character))
+
+	ws := h.Warnings()
+	a.Length(ws, 1).Equal(ws[0].Err.Error(), "warn")
+
+	// 返回的是副本
+	es[0] = nil
+	a.Equal(h.Errors()[0], err)
+
+	// 未指定收集消息
+	h = NewMessageHandler(func(*Message) {})
+	h.Error(err)
+	h.Warning("warn")
+	h.Stop()
+	errs, warns = h.Count()
+	a.Equal(errs, 0).Equal(warns, 0).Empty(h.Errors()).Empty(h.Warnings())
+}
+
 func TestHandler_Stop(t *testing.T) {
 	a := assert.New(t, false)
 	var exit bool
@@ -78,3 +119,32 @@ func TestHandler_Stop(t *testing.T) {
 	h.Stop() // 此处会阻塞，等待完成
 	a.True(exit)
 }
+
+func TestNewMultiMessageHandler(t *testing.T) {
+	a := assert.New(t, false)
+
+	var msgs1, msgs2 []*Message
+	h1 := NewMessageHandler(func(msg *Message) { msgs1 = append(msgs1, msg) })
+	h2 := NewMessageHandler(func(msg *Message) { msgs2 = append(msgs2, msg) })
+
+	h := NewMultiMessageHandler(h1, h2)
+	a.NotNil(h)
+
+	erro := (Location{URI: "erro.go"}).NewError(locale.ErrInvalidUTF8Character)
+	h.Error(erro)
+	h.Warning("warn")
+	h.Info("info")
+	h.Success("succ")
+	h.Stop() // 会同时关闭 h1 和 h2
+
+	a.Equal(msgs1, msgs2).
+		Equal(msgs1, []*Message{
+			{Type: Erro, Message: erro},
+			{Type: Warn, Message: "warn"},
+			{Type: Info, Message: "info"},
+			{Type: Succ, Message: "succ"},
+		})
+
+	a.Panic(func() { h1.Info("info") }).
+		Panic(func() { h2.Info("info") })
+}
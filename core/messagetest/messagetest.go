@@ -19,7 +19,7 @@ func NewMessageHandler() *Result {
 		Successes: []interface{}{},
 	}
 
-	rslt.Handler = core.NewMessageHandler(func(msg *core.Message) {
+	rslt.Handler = core.NewCollectMessageHandler(func(msg *core.Message) {
 		switch msg.Type {
 		case core.Erro:
 			rslt.Errors = append(rslt.Errors, msg.Message)
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/issue9/sliceutil"
+)
+
+// RetryOptions 读取远程文件时的重试设置
+//
+// 通过 WithRetryOptions 附加在 context.Context 上，
+// 并由 URI.ReadAllContext 读取。
+type RetryOptions struct {
+	// 最大的重试次数，不包含第一次请求，为 0 表示不重试。
+	MaxRetries int
+
+	// 第一次重试之前的等待时间
+	InitialDelay time.Duration
+
+	// 每次重试之后等待时间的增长倍数，小于 1 时按 1 处理。
+	BackoffFactor float64
+
+	// 每次重试之前等待时间的上限
+	//
+	// 同时限制 Retry-After 报头指定的时间，为 0 表示采用默认值 1 分钟。
+	MaxDelay time.Duration
+
+	// 需要重试的状态码
+	//
+	// 为空表示采用默认值：429、502、503 和 504。
+	RetryableCodes []int
+}
+
+const defaultMaxRetryDelay = time.Minute
+
+var defaultRetryableCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+type retryOptionsKey struct{}
+
+// WithRetryOptions 将重试设置附加在 ctx 上
+func WithRetryOptions(ctx context.Context, o *RetryOptions) context.Context {
+	return context.WithValue(ctx, retryOptionsKey{}, o)
+}
+
+// 从 ctx 中获取重试设置，不存在则返回 nil。
+func getRetryOptions(ctx context.Context) *RetryOptions {
+	if o, ok := ctx.Value(retryOptionsKey{}).(*RetryOptions); ok {
+		return o
+	}
+	return nil
+}
+
+func (o *RetryOptions) retryable(code int) bool {
+	codes := o.RetryableCodes
+	if len(codes) == 0 {
+		codes = defaultRetryableCodes
+	}
+	return sliceutil.Exists(codes, func(c int) bool { return c == code })
+}
+
+// 计算第 retry 次重试之前需要等待的时间，retry 从 0 开始。
+//
+// 如果 resp 中包含了合法的 Retry-After 报头，则以报头的值为准。
+// 返回值不会超过 MaxDelay。
+func (o *RetryOptions) delay(retry int, resp *http.Response) time.Duration {
+	max := o.MaxDelay
+	if max <= 0 {
+		max = defaultMaxRetryDelay
+	}
+
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if d > max {
+				return max
+			}
+			return d
+		}
+	}
+
+	factor := o.BackoffFactor
+	if factor < 1 {
+		factor = 1
+	}
+
+	d := float64(o.InitialDelay)
+	for i := 0; i < retry && d < float64(max); i++ {
+		d *= factor
+	}
+	if d > float64(max) {
+		return max
+	}
+	return time.Duration(d)
+}
+
+// 解析 Retry-After 报头，其值可以是秒数或是 HTTP 日期格式。
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if d := time.Until(t); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// 等待 d 时长，如果 ctx 提前结束，则返回其错误信息。
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v2"
+)
+
+// 返回一个前 failed 次请求都返回 code 的服务
+func newFlakyServer(failed, code int, header http.Header) (*httptest.Server, *int) {
+	count := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count <= failed {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(code)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	return srv, &count
+}
+
+func TestURI_ReadAllContext_retry(t *testing.T) {
+	a := assert.New(t, false)
+
+	// 未指定重试
+	srv, count := newFlakyServer(2, http.StatusServiceUnavailable, nil)
+	data, err := URI(srv.URL).ReadAllContext(context.Background(), nil)
+	a.Nil(data).TypeEqual(true, err, &HTTPError{}).Equal(*count, 1)
+	srv.Close()
+
+	// 两次 503 之后返回 200
+	srv, count = newFlakyServer(2, http.StatusServiceUnavailable, nil)
+	ctx := WithRetryOptions(context.Background(), &RetryOptions{
+		MaxRetries:    3,
+		InitialDelay:  time.Millisecond,
+		BackoffFactor: 2,
+	})
+	data, err = URI(srv.URL).ReadAllContext(ctx, nil)
+	a.NotError(err).Equal(string(data), "ok").Equal(*count, 3)
+	srv.Close()
+
+	// 超过重试次数
+	srv, count = newFlakyServer(5, http.StatusServiceUnavailable, nil)
+	ctx = WithRetryOptions(context.Background(), &RetryOptions{MaxRetries: 2})
+	data, err = URI(srv.URL).ReadAllContext(ctx, nil)
+	a.Nil(data).Error(err).Equal(*count, 3)
+	srv.Close()
+
+	// 不可重试的状态码
+	srv, count = newFlakyServer(2, http.StatusInternalServerError, nil)
+	data, err = URI(srv.URL).ReadAllContext(ctx, nil)
+	a.Nil(data).Error(err).Equal(*count, 1)
+	srv.Close()
+
+	// 自定义状态码
+	srv, count = newFlakyServer(1, http.StatusInternalServerError, nil)
+	ctx = WithRetryOptions(context.Background(), &RetryOptions{
+		MaxRetries:     1,
+		RetryableCodes: []int{http.StatusInternalServerError},
+	})
+	data, err = URI(srv.URL).ReadAllContext(ctx, nil)
+	a.NotError(err).Equal(string(data), "ok").Equal(*count, 2)
+	srv.Close()
+
+	// Retry-After 优先于 InitialDelay
+	srv, count = newFlakyServer(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}})
+	ctx = WithRetryOptions(context.Background(), &RetryOptions{
+		MaxRetries:   1,
+		InitialDelay: time.Hour,
+	})
+	data, err = URI(srv.URL).ReadAllContext(ctx, nil)
+	a.NotError(err).Equal(string(data), "ok").Equal(*count, 2)
+	srv.Close()
+
+	// 等待期间 ctx 被取消
+	srv, _ = newFlakyServer(1, http.StatusServiceUnavailable, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ctx = WithRetryOptions(ctx, &RetryOptions{MaxRetries: 1, InitialDelay: time.Hour})
+	data, err = URI(srv.URL).ReadAllContext(ctx, nil)
+	a.Nil(data).ErrorIs(err, context.DeadlineExceeded)
+	srv.Close()
+}
+
+func TestRetryOptions_delay(t *testing.T) {
+	a := assert.New(t, false)
+
+	o := &RetryOptions{InitialDelay: time.Second, BackoffFactor: 2}
+	a.Equal(o.delay(0, nil), time.Second).
+		Equal(o.delay(1, nil), 2*time.Second).
+		Equal(o.delay(2, nil), 4*time.Second)
+
+	o = &RetryOptions{InitialDelay: time.Second}
+	a.Equal(o.delay(2, nil), time.Second)
+
+	resp := &http.Response{Header: http.Header{"Retry-After": {"3"}}}
+	a.Equal(o.delay(0, resp), 3*time.Second)
+
+	resp = &http.Response{Header: http.Header{"Retry-After": {"invalid"}}}
+	a.Equal(o.delay(0, resp), time.Second)
+
+	// MaxDelay
+	resp = &http.Response{Header: http.Header{"Retry-After": {"86400"}}}
+	a.Equal(o.delay(0, resp), defaultMaxRetryDelay)
+
+	o = &RetryOptions{InitialDelay: time.Second, BackoffFactor: 2, MaxDelay: 3 * time.Second}
+	a.Equal(o.delay(1, nil), 2*time.Second).
+		Equal(o.delay(2, nil), 3*time.Second).
+		Equal(o.delay(100, nil), 3*time.Second).
+		Equal(o.delay(0, resp), 3*time.Second)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	a := assert.New(t, false)
+
+	d, ok := parseRetryAfter("")
+	a.False(ok).Equal(d, 0)
+
+	d, ok = parseRetryAfter("-1")
+	a.False(ok).Equal(d, 0)
+
+	d, ok = parseRetryAfter("5")
+	a.True(ok).Equal(d, 5*time.Second)
+
+	d, ok = parseRetryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	a.True(ok).Equal(d, 0)
+
+	d, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	a.True(ok).True(d > 0)
+}
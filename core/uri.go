@@ -3,6 +3,8 @@
 package core
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"io/fs"
 	"io/ioutil"
@@ -10,7 +12,9 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/transform"
@@ -23,6 +27,7 @@ const (
 	SchemeFile  = "file"
 	SchemeHTTP  = "http"
 	SchemeHTTPS = "https"
+	SchemeFS    = "fs" // 由 FSURI 生成，指向 fs.FS 中的内容
 
 	separator = "://"
 )
@@ -56,6 +61,52 @@ func FileURI(path string) URI {
 	return URI(SchemeFile + separator + path)
 }
 
+// 由 FSURI 注册的 fs.FS 实例，URI 中以其键名作为标识。
+var fsList = struct {
+	sync.RWMutex
+	items map[int]fs.FS
+	next  int
+}{items: map[int]fs.FS{}}
+
+// FSURI 根据 fs.FS 中的路径构建 URI 实例
+//
+// 返回的 URI 以 fs:// 作为协议，ReadAll 等操作会从 fsys 中读取 path 指向的内容。
+// 比如在测试中可以传入 os.DirFS("testdata") 或是 fstest.MapFS。
+//
+// fsys 会被保存在全局列表中，不再使用时需要调用 ReleaseFS 释放。
+func FSURI(fsys fs.FS, path string) URI {
+	fsList.Lock()
+	id := fsList.next
+	fsList.next++
+	fsList.items[id] = fsys
+	fsList.Unlock()
+
+	uri := URI(SchemeFS + separator + strconv.Itoa(id))
+	if path = strings.Trim(path, "/"); path != "" && path != "." {
+		uri += URI("/" + path)
+	}
+	return uri
+}
+
+// ReleaseFS 释放由 FSURI 注册的 fs.FS 实例
+//
+// uri 为 FSURI 的返回值或是其子路径，释放之后，
+// 与 uri 关联同一个 fs.FS 的所有 URI 都将不再可用。
+// 非 fs:// 协议或是已经释放的 uri 不作任何处理。
+func ReleaseFS(uri URI) {
+	scheme, path := uri.Parse()
+	if scheme != SchemeFS {
+		return
+	}
+
+	id, _, _ := strings.Cut(path, "/")
+	if index, err := strconv.Atoi(id); err == nil {
+		fsList.Lock()
+		delete(fsList.items, index)
+		fsList.Unlock()
+	}
+}
+
 // UnmarshalJSON 实现对非 ascii 字符的解码
 func (uri *URI) UnmarshalJSON(v []byte) error {
 	if len(v) <= 2 {
@@ -80,6 +131,34 @@ func (uri URI) File() (string, error) {
 	return "", locale.NewError(locale.ErrInvalidURIScheme, scheme)
 }
 
+// FS 返回 fs:// 协议关联的 fs.FS 及其中的路径
+//
+// 返回的路径符合 fs.ValidPath 的要求，根目录以 . 表示。
+func (uri URI) FS() (fs.FS, string, error) {
+	scheme, path := uri.Parse()
+	if scheme != SchemeFS {
+		return nil, "", locale.NewError(locale.ErrInvalidURIScheme, scheme)
+	}
+
+	id, path, _ := strings.Cut(path, "/")
+	if path = strings.Trim(filepath.ToSlash(path), "/"); path == "" {
+		path = "."
+	}
+
+	index, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, "", locale.NewError(locale.ErrInvalidURI, string(uri))
+	}
+
+	fsList.RLock()
+	fsys, found := fsList.items[index]
+	fsList.RUnlock()
+	if !found {
+		return nil, "", locale.NewError(locale.ErrInvalidURI, string(uri))
+	}
+	return fsys, path, nil
+}
+
 func (uri URI) String() string {
 	return string(uri)
 }
@@ -121,6 +200,13 @@ func (uri URI) Exists() (bool, error) {
 		return err == nil || errors.Is(err, fs.ErrExist), nil
 	case SchemeHTTP, SchemeHTTPS:
 		return remoteFileIsExists(string(uri))
+	case SchemeFS:
+		fsys, p, err := uri.FS()
+		if err != nil {
+			return false, err
+		}
+		_, err = fs.Stat(fsys, p)
+		return err == nil, nil
 	default:
 		return false, locale.NewError(locale.ErrInvalidURIScheme, scheme)
 	}
@@ -128,26 +214,47 @@ func (uri URI) Exists() (bool, error) {
 
 // ReadAll 以 enc 编码读取 uri 的内容
 //
-// 目前仅支持 file、http 和 https 协议
+// 目前仅支持 file、http、https 和 fs 协议
 func (uri URI) ReadAll(enc encoding.Encoding) ([]byte, error) {
+	return uri.ReadAllContext(context.Background(), enc)
+}
+
+// ReadAllContext 以 enc 编码读取 uri 的内容
+//
+// 如果 ctx 中通过 WithRetryOptions 附加了重试设置，
+// 读取远程文件遇到可重试的状态码时，会按设置进行重试。
+func (uri URI) ReadAllContext(ctx context.Context, enc encoding.Encoding) ([]byte, error) {
 	scheme, path := uri.Parse()
 	switch scheme {
 	case SchemeFile, "":
 		return readLocalFile(path, enc)
 	case SchemeHTTP, SchemeHTTPS:
-		return readRemoteFile(string(uri), enc)
+		return readRemoteFile(ctx, string(uri), enc)
+	case SchemeFS:
+		fsys, p, err := uri.FS()
+		if err != nil {
+			return nil, err
+		}
+		return readFSFile(fsys, p, enc)
 	default:
 		return nil, locale.NewError(locale.ErrInvalidURIScheme, scheme)
 	}
 }
 
 // WriteAll 写入内容至 uri
-func (uri URI) WriteAll(data []byte) error {
+//
+// 目前仅支持 file、http 和 https 协议，
+// 本地文件以 perm 作为权限，远程文件则以 PUT 请求的方式写入，perm 不启作用。
+func (uri URI) WriteAll(data []byte, perm os.FileMode) error {
 	scheme, path := uri.Parse()
-	if scheme == SchemeFile || scheme == "" {
-		return ioutil.WriteFile(path, data, os.ModePerm)
+	switch scheme {
+	case SchemeFile, "":
+		return ioutil.WriteFile(path, data, perm)
+	case SchemeHTTP, SchemeHTTPS:
+		return writeRemoteFile(string(uri), data)
+	default:
+		return locale.NewError(locale.ErrInvalidURIScheme, scheme)
 	}
-	return locale.NewError(locale.ErrInvalidURIScheme, scheme)
 }
 
 // Parse 分析 uri，获取其各个部分的内容
@@ -184,12 +291,45 @@ func readLocalFile(path string, enc encoding.Encoding) ([]byte, error) {
 	return ioutil.ReadAll(reader)
 }
 
-// 以指定的编码方式读取远程文件内容
-func readRemoteFile(url string, enc encoding.Encoding) ([]byte, error) {
-	resp, err := http.Get(filepath.ToSlash(url))
+// 以指定的编码方式读取 fsys 中的文件内容
+func readFSFile(fsys fs.FS, path string, enc encoding.Encoding) ([]byte, error) {
+	r, err := fsys.Open(path)
 	if err != nil {
 		return nil, err
 	}
+	defer r.Close()
+
+	if enc == nil || enc == encoding.Nop {
+		return ioutil.ReadAll(r)
+	}
+	return ioutil.ReadAll(transform.NewReader(r, enc.NewDecoder()))
+}
+
+// 以指定的编码方式读取远程文件内容
+func readRemoteFile(ctx context.Context, url string, enc encoding.Encoding) ([]byte, error) {
+	retry := getRetryOptions(ctx)
+
+	var resp *http.Response
+	for i := 0; ; i++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, filepath.ToSlash(url), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if retry == nil || i >= retry.MaxRetries || !retry.retryable(resp.StatusCode) {
+			break
+		}
+
+		resp.Body.Close()
+		if err := sleep(ctx, retry.delay(i, resp)); err != nil {
+			return nil, err
+		}
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode > 300 {
@@ -202,3 +342,22 @@ func readRemoteFile(url string, enc encoding.Encoding) ([]byte, error) {
 	reader := transform.NewReader(resp.Body, enc.NewDecoder())
 	return ioutil.ReadAll(reader)
 }
+
+// 以 PUT 请求的方式将 data 写入远程文件
+func writeRemoteFile(url string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return NewHTTPError(resp.StatusCode, locale.ErrWriteRemoteFile, url, resp.StatusCode)
+	}
+	return nil
+}
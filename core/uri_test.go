@@ -4,11 +4,15 @@ package core
 
 import (
 	"encoding/json"
+	"io"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
+	"testing/fstest"
 	"unicode/utf8"
 
 	"github.com/issue9/assert/v2"
@@ -33,6 +37,74 @@ func TestFileURI(t *testing.T) {
 	a.NotError(err).Equal("/path", file)
 }
 
+func TestFSURI(t *testing.T) {
+	a := assert.New(t, false)
+
+	fsys := fstest.MapFS{
+		"index.xml":  &fstest.MapFile{Data: []byte("index")},
+		"dir/a.go":   &fstest.MapFile{Data: []byte("package a")},
+		"dir/gbk.go": &fstest.MapFile{Data: []byte{0xd6, 0xd0, 0xce, 0xc4}}, // 中文
+	}
+
+	root := FSURI(fsys, "")
+	scheme, _ := root.Parse()
+	a.Equal(scheme, SchemeFS)
+	f, p, err := root.FS()
+	a.NotError(err).NotNil(f).Equal(p, ".")
+
+	data, err := root.Append("index.xml").ReadAll(nil)
+	a.NotError(err).Equal(string(data), "index")
+
+	dir := FSURI(fsys, "/dir/")
+	_, p, err = dir.FS()
+	a.NotError(err).Equal(p, "dir")
+
+	data, err = dir.Append("a.go").ReadAll(nil)
+	a.NotError(err).Equal(string(data), "package a")
+
+	data, err = dir.Append("gbk.go").ReadAll(simplifiedchinese.GB18030)
+	a.NotError(err).Equal(string(data), "中文")
+
+	data, err = dir.Append("not-exists").ReadAll(nil)
+	a.ErrorIs(err, fs.ErrNotExist).Nil(data)
+
+	exists, err := dir.Append("a.go").Exists()
+	a.NotError(err).True(exists)
+	exists, err = dir.Append("not-exists").Exists()
+	a.NotError(err).False(exists)
+
+	a.Error(dir.WriteAll([]byte("abc"), os.ModePerm))
+
+	// 无效的标识
+	_, _, err = URI("fs://abc/dir").FS()
+	a.Error(err)
+	_, _, err = URI("fs://100000/dir").FS()
+	a.Error(err)
+	_, err = URI("fs://100000/dir").ReadAll(nil)
+	a.Error(err)
+
+	// 非 fs 协议
+	_, _, err = FileURI("./uri.go").FS()
+	a.Error(err)
+
+	// 释放之后，关联同一个 fs.FS 的 URI 都不再可用
+	ReleaseFS(dir.Append("a.go"))
+	_, _, err = dir.FS()
+	a.Error(err)
+	_, err = dir.Append("a.go").ReadAll(nil)
+	a.Error(err)
+	_, _, err = root.FS()
+	a.NotError(err)
+	ReleaseFS(dir) // 重复释放
+	ReleaseFS(FileURI("./uri.go"))
+
+	// 释放之后的标识不会被复用
+	other := FSURI(fsys, "")
+	a.NotEqual(other, dir)
+	ReleaseFS(root)
+	ReleaseFS(other)
+}
+
 func TestURI_json(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -212,9 +284,46 @@ func TestURI_WriteAll(t *testing.T) {
 	a := assert.New(t, false)
 
 	uri := URI(" :///path.php")
-	a.Error(uri.WriteAll([]byte("test")))
+	a.Error(uri.WriteAll([]byte("test"), os.ModePerm))
 
 	// 协议类型错误
-	uri = URI("https:///path.php")
-	a.Error(uri.WriteAll([]byte("test")))
+	uri = URI("fs://abc/path.php")
+	a.Error(uri.WriteAll([]byte("test"), os.ModePerm))
+
+	// 本地文件
+	path := filepath.Join(t.TempDir(), "file.txt")
+	uri = FileURI(path)
+	a.NotError(uri.WriteAll([]byte("test"), 0o600))
+	data, err := uri.ReadAll(nil)
+	a.NotError(err).Equal(string(data), "test")
+	if runtime.GOOS != "windows" {
+		stat, err := os.Stat(path)
+		a.NotError(err).Equal(stat.Mode().Perm(), os.FileMode(0o600))
+	}
+
+	// 远程文件
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path == "/forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		a.NotError(err)
+		body = string(data)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	a.NotError(URI(srv.URL+"/file.txt").WriteAll([]byte("remote"), os.ModePerm))
+	a.Equal(body, "remote")
+
+	err = URI(srv.URL+"/forbidden").WriteAll([]byte("remote"), os.ModePerm)
+	httpErr, ok := err.(*HTTPError)
+	a.True(ok).Equal(httpErr.Code, http.StatusForbidden)
 }
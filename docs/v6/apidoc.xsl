@@ -184,6 +184,19 @@
 
                 <xsl:value-of select="path/@path" />
             </span>
+
+            <xsl:for-each select="tag">
+                <xsl:variable name="tag" select="/apidoc/tag[@name=current()]" />
+                <span class="tag">
+                    <xsl:if test="$tag/@color">
+                    <xsl:attribute name="style">background-color:<xsl:value-of select="$tag/@color" /></xsl:attribute>
+                    </xsl:if>
+                    <xsl:choose>
+                        <xsl:when test="$tag/@title"><xsl:value-of select="$tag/@title" /></xsl:when>
+                        <xsl:otherwise><xsl:value-of select="." /></xsl:otherwise>
+                    </xsl:choose>
+                </span>
+            </xsl:for-each>
         </div>
 
         <div class="right">
@@ -51,6 +51,9 @@ const (
 	RichtextTypeMarkdown = "markdown"
 )
 
+// ExtensionPrefix 扩展字段名称的前缀
+const ExtensionPrefix = "x-"
+
 // 几种与时间类型相关的格式
 const (
 	DateFormat     = "2006-01-02"     // 对应 TypeDate
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+// 表示接口变化的类型
+const (
+	DiffAdded DiffKind = iota + 1
+	DiffRemoved
+	DiffModified
+)
+
+// 比较时需要忽略的类型，主要为位置信息等与文档内容无关的字段。
+var diffIgnoreTypes = []reflect.Type{
+	reflect.TypeOf(xmlenc.Base{}),
+	reflect.TypeOf(xmlenc.BaseTag{}),
+	reflect.TypeOf(xmlenc.BaseAttribute{}),
+	reflect.TypeOf(xmlenc.Name{}),
+	reflect.TypeOf(core.Location{}),
+	reflect.TypeOf(core.Range{}),
+	reflect.TypeOf(struct{}{}),
+}
+
+type (
+	// DiffKind 接口变化的类型
+	DiffKind int8
+
+	// APIDiff 表示两个文档中同一接口的变化
+	APIDiff struct {
+		Kind DiffKind
+
+		// 发生变化的接口
+		//
+		// DiffRemoved 时为旧文档中的接口，其它情况下为新文档中的接口。
+		API *API
+
+		// 发生变化的字段
+		//
+		// 仅在 DiffModified 时有值，为字段在 API 中的路径，
+		// 比如 Requests[0].Type、Responses[1].Status 等。
+		Field string
+	}
+)
+
+// String fmt.Stringer
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	case DiffModified:
+		return "modified"
+	default:
+		return "<unknown>"
+	}
+}
+
+// Diff 比较两个文档中接口的变化
+//
+// 以请求方法和路径作为接口的唯一标记，old 中存在而 doc 中不存在的为 DiffRemoved，
+// 反之为 DiffAdded；两者都存在但内容不同的为 DiffModified，
+// 每一个发生变化的字段都会生成一条记录。位置等与文档内容无关的信息不参与比较。
+func Diff(old, doc *APIDoc) []*APIDiff {
+	diffs := make([]*APIDiff, 0, 10)
+
+	for _, api := range old.APIs {
+		newAPI := doc.findAPI(api.Method.V(), api.pathValue())
+		if newAPI == nil {
+			diffs = append(diffs, &APIDiff{Kind: DiffRemoved, API: api})
+			continue
+		}
+
+		for _, field := range diffValue("", reflect.ValueOf(api).Elem(), reflect.ValueOf(newAPI).Elem(), nil) {
+			diffs = append(diffs, &APIDiff{Kind: DiffModified, API: newAPI, Field: field})
+		}
+	}
+
+	for _, api := range doc.APIs {
+		if old.findAPI(api.Method.V(), api.pathValue()) == nil {
+			diffs = append(diffs, &APIDiff{Kind: DiffAdded, API: api})
+		}
+	}
+
+	return diffs
+}
+
+func (doc *APIDoc) findAPI(method, path string) *API {
+	for _, api := range doc.APIs {
+		if api.Method.V() == method && api.pathValue() == path {
+			return api
+		}
+	}
+	return nil
+}
+
+func (api *API) pathValue() string {
+	if api.Path == nil {
+		return ""
+	}
+	return api.Path.Path.V()
+}
+
+// 比较 v1 和 v2，将不同的字段路径追加到 fields 中并返回。
+func diffValue(path string, v1, v2 reflect.Value, fields []string) []string {
+	switch v1.Kind() {
+	case reflect.Ptr:
+		if v1.IsNil() || v2.IsNil() {
+			if v1.IsNil() != v2.IsNil() {
+				fields = append(fields, path)
+			}
+			return fields
+		}
+		return diffValue(path, v1.Elem(), v2.Elem(), fields)
+	case reflect.Slice:
+		if v1.Len() != v2.Len() {
+			return append(fields, path)
+		}
+		for i := 0; i < v1.Len(); i++ {
+			fields = diffValue(path+"["+strconv.Itoa(i)+"]", v1.Index(i), v2.Index(i), fields)
+		}
+		return fields
+	case reflect.Struct:
+		t := v1.Type()
+		if t == reflect.TypeOf(Number{}) || t == reflect.TypeOf(Bool{}) || t == reflect.TypeOf(Date{}) {
+			if !equalValue(v1.Interface(), v2.Interface()) {
+				fields = append(fields, path)
+			}
+			return fields
+		}
+
+	LOOP:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			for _, ignore := range diffIgnoreTypes {
+				if f.Type == ignore {
+					continue LOOP
+				}
+			}
+
+			p := path
+			if !f.Anonymous && f.Name != "Value" { // Value 为属性的实际值，不需要体现在路径中
+				if p != "" {
+					p += "."
+				}
+				p += f.Name
+			}
+			fields = diffValue(p, v1.Field(i), v2.Field(i), fields)
+		}
+		return fields
+	default:
+		if v1.Interface() != v2.Interface() {
+			fields = append(fields, path)
+		}
+		return fields
+	}
+}
+
+// 比较 Number、Bool 和 Date 的值，忽略其中的位置信息。
+func equalValue(v1, v2 interface{}) bool {
+	switch val := v1.(type) {
+	case Number:
+		n := v2.(Number)
+		return val.Int == n.Int && val.Float == n.Float && val.IsFloat == n.IsFloat
+	case Bool:
+		return val.Value == v2.(Bool).Value
+	case Date:
+		return val.Value.Equal(v2.(Date).Value) // time.Time 不能直接比较
+	default:
+		panic("无效的类型")
+	}
+}
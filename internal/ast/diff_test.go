@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+)
+
+func TestDiff(t *testing.T) {
+	a := assert.New(t, false)
+
+	parse := func(blocks ...string) *APIDoc {
+		doc := &APIDoc{}
+		rslt := messagetest.NewMessageHandler()
+		for _, b := range blocks {
+			doc.Parse(rslt.Handler, core.Block{Location: core.Location{URI: "doc.xml"}, Data: []byte(b)})
+		}
+		rslt.Handler.Stop()
+		a.Empty(rslt.Errors)
+		return doc
+	}
+
+	old := parse(`<apidoc version="1.0.0"><title>title</title><tag name="t1" title="t1" /><mimetype>application/json</mimetype></apidoc>`,
+		`<api method="GET" summary="list"><path path="/users" /><tag>t1</tag><response status="200" type="string" /></api>`,
+		`<api method="POST"><path path="/users" /><request type="object"><param name="name" type="string" summary="name" /></request><response status="201" /></api>`,
+		`<api method="DELETE"><path path="/users/{id}"><param name="id" type="number" summary="id" /></path><response status="204" /></api>`,
+	)
+
+	// 内容相同，仅位置不同
+	doc := parse(`<apidoc version="1.0.0">
+	<title>title</title>
+	<tag name="t1" title="t1" />
+	<mimetype>application/json</mimetype>
+</apidoc>`,
+		`<api method="GET" summary="list">
+	<path path="/users" />
+	<tag>t1</tag>
+	<response status="200" type="string" />
+</api>`,
+		`<api method="POST"><path path="/users" /><request type="object"><param name="name" type="string" summary="name" /></request><response status="201" /></api>`,
+		`<api method="DELETE"><path path="/users/{id}"><param name="id" type="number" summary="id" /></path><response status="204" /></api>`,
+	)
+	a.Empty(Diff(old, doc))
+
+	doc = parse(`<apidoc version="1.0.0"><title>title</title><tag name="t1" title="t1" /><mimetype>application/json</mimetype></apidoc>`,
+		`<api method="GET" summary="list users"><path path="/users" /><tag>t1</tag><response status="200" type="string" /></api>`,
+		`<api method="POST"><path path="/users" /><request type="string" /><response status="200" /></api>`,
+		`<api method="PUT"><path path="/users/{id}"><param name="id" type="number" summary="id" /></path><response status="204" /></api>`,
+	)
+	diffs := Diff(old, doc)
+	a.Equal(6, len(diffs))
+
+	a.Equal(diffs[0].Kind, DiffModified).
+		Equal(diffs[0].API.Method.V(), http.MethodGet).
+		Equal(diffs[0].Field, "Summary")
+
+	a.Equal(diffs[1].Kind, DiffModified).
+		Equal(diffs[1].API.Method.V(), http.MethodPost).
+		Equal(diffs[1].Field, "Requests[0].Type")
+	a.Equal(diffs[2].Kind, DiffModified).Equal(diffs[2].Field, "Requests[0].Items")
+	a.Equal(diffs[3].Kind, DiffModified).Equal(diffs[3].Field, "Responses[0].Status")
+
+	a.Equal(diffs[4].Kind, DiffRemoved).
+		Equal(diffs[4].API.Method.V(), http.MethodDelete).
+		Empty(diffs[4].Field)
+
+	a.Equal(diffs[5].Kind, DiffAdded).
+		Equal(diffs[5].API.Method.V(), http.MethodPut).
+		Equal(diffs[5].API.Path.Path.V(), "/users/{id}")
+
+	a.Equal(DiffAdded.String(), "added").
+		Equal(DiffKind(0).String(), "<unknown>")
+}
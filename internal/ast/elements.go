@@ -19,15 +19,16 @@ type (
 		Created       *DateAttribute          `apidoc:"created,attr,usage-apidoc-created,omitempty"` // 生成时间
 		Version       *VersionAttribute       `apidoc:"version,attr,usage-apidoc-version,omitempty"`
 		Title         *Element                `apidoc:"title,elem,usage-apidoc-title"`
-		Description   *Richtext               `apidoc:"description,elem,usage-apidoc-description,omitempty"` // 说明内容
-		Contact       *Contact                `apidoc:"contact,elem,usage-apidoc-contact,omitempty"`         // 团队的联系方式
-		License       *Link                   `apidoc:"license,elem,usage-apidoc-license,omitempty"`         // 版权信息
-		Tags          []*Tag                  `apidoc:"tag,elem,usage-apidoc-tags,omitempty"`                // 标签列表
-		Servers       []*Server               `apidoc:"server,elem,usage-apidoc-servers,omitempty"`          // 服务器列表
-		APIs          []*API                  `apidoc:"api,elem,usage-apidoc-apis,omitempty"`                // API 列表
-		Headers       []*Param                `apidoc:"header,elem,usage-apidoc-headers,omitempty"`          // 公共报头
-		Responses     []*Request              `apidoc:"response,elem,usage-apidoc-responses,omitempty"`      // 所有 API 都有可能的返回内容
-		Mimetypes     []*Element              `apidoc:"mimetype,elem,usage-apidoc-mimetypes"`                // 所有接口都支持的 mimetypes
+		Description   *Richtext               `apidoc:"description,elem,usage-apidoc-description,omitempty"`     // 说明内容
+		Contact       *Contact                `apidoc:"contact,elem,usage-apidoc-contact,omitempty"`             // 团队的联系方式
+		License       *Link                   `apidoc:"license,elem,usage-apidoc-license,omitempty"`             // 版权信息
+		ExternalDocs  *Link                   `apidoc:"external-docs,elem,usage-apidoc-external-docs,omitempty"` // 外部文档
+		Tags          []*Tag                  `apidoc:"tag,elem,usage-apidoc-tags,omitempty"`                    // 标签列表
+		Servers       []*Server               `apidoc:"server,elem,usage-apidoc-servers,omitempty"`              // 服务器列表
+		APIs          []*API                  `apidoc:"api,elem,usage-apidoc-apis,omitempty"`                    // API 列表
+		Headers       []*Param                `apidoc:"header,elem,usage-apidoc-headers,omitempty"`              // 公共报头
+		Responses     []*Request              `apidoc:"response,elem,usage-apidoc-responses,omitempty"`          // 所有 API 都有可能的返回内容
+		Mimetypes     []*Element              `apidoc:"mimetype,elem,usage-apidoc-mimetypes"`                    // 所有接口都支持的 mimetypes
 	}
 
 	// XMLNamespace 定义命名空间的相关属性
@@ -44,19 +45,32 @@ type (
 		RootName struct{} `apidoc:"api,meta,usage-api"`
 		doc      *APIDoc
 
-		Version     *VersionAttribute `apidoc:"version,attr,usage-api-version,omitempty"`
-		Method      *MethodAttribute  `apidoc:"method,attr,usage-api-method"`
-		ID          *Attribute        `apidoc:"id,attr,usage-api-id,omitempty"`
-		Path        *Path             `apidoc:"path,elem,usage-api-path"`
-		Summary     *Attribute        `apidoc:"summary,attr,usage-api-summary,omitempty"`
-		Description *Richtext         `apidoc:"description,elem,usage-api-description,omitempty"`
-		Requests    []*Request        `apidoc:"request,elem,usage-api-requests,omitempty"` // 不同的 mimetype 可能会定义不同
-		Responses   []*Request        `apidoc:"response,elem,usage-api-responses,omitempty"`
-		Callback    *Callback         `apidoc:"callback,elem,usage-api-callback,omitempty"`
-		Deprecated  *VersionAttribute `apidoc:"deprecated,attr,usage-api-deprecated,omitempty"`
-		Headers     []*Param          `apidoc:"header,elem,usage-api-headers,omitempty"`
-		Tags        []*TagValue       `apidoc:"tag,elem,usage-api-tags,omitempty"`
-		Servers     []*ServerValue    `apidoc:"server,elem,usage-api-servers,omitempty"`
+		Version      *VersionAttribute `apidoc:"version,attr,usage-api-version,omitempty"`
+		Method       *MethodAttribute  `apidoc:"method,attr,usage-api-method"`
+		ID           *Attribute        `apidoc:"id,attr,usage-api-id,omitempty"`
+		Path         *Path             `apidoc:"path,elem,usage-api-path"`
+		Summary      *Attribute        `apidoc:"summary,attr,usage-api-summary,omitempty"`
+		Description  *Richtext         `apidoc:"description,elem,usage-api-description,omitempty"`
+		ExternalDocs *Link             `apidoc:"external-docs,elem,usage-api-external-docs,omitempty"`
+		Requests     []*Request        `apidoc:"request,elem,usage-api-requests,omitempty"` // 不同的 mimetype 可能会定义不同
+		Responses    []*Request        `apidoc:"response,elem,usage-api-responses,omitempty"`
+		Callback     *Callback         `apidoc:"callback,elem,usage-api-callback,omitempty"`
+		Deprecated   *VersionAttribute `apidoc:"deprecated,attr,usage-api-deprecated,omitempty"`
+		Headers      []*Param          `apidoc:"header,elem,usage-api-headers,omitempty"`
+		Tags         []*TagValue       `apidoc:"tag,elem,usage-api-tags,omitempty"`
+		Servers      []*ServerValue    `apidoc:"server,elem,usage-api-servers,omitempty"`
+		Extensions   []*Extension      `apidoc:"ext,elem,usage-api-extensions,omitempty"`
+	}
+
+	// Extension 扩展字段
+	//
+	// 在输出 openapi 时，会以 x- 开头的扩展字段形式输出。
+	Extension struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"ext,meta,usage-extension"`
+
+		Name  *Attribute `apidoc:"name,attr,usage-extension-name"` // 必须以 x- 开头
+		Value *Attribute `apidoc:"value,attr,usage-extension-value"`
 	}
 
 	// Link 表示一个链接
@@ -190,6 +204,7 @@ type (
 		Name       *Attribute        `apidoc:"name,attr,usage-tag-name"`   // 标签的唯一 ID
 		Title      *Attribute        `apidoc:"title,attr,usage-tag-title"` // 显示的名称
 		Deprecated *VersionAttribute `apidoc:"deprecated,attr,usage-tag-deprecated,omitempty"`
+		Color      *Attribute        `apidoc:"color,attr,usage-tag-color,omitempty"` // 界面中标签的颜色
 
 		references []*Reference
 	}
@@ -204,10 +219,25 @@ type (
 		Deprecated  *VersionAttribute `apidoc:"deprecated,attr,usage-server-deprecated,omitempty"`
 		Summary     *Attribute        `apidoc:"summary,attr,usage-server-summary,omitempty"`
 		Description *Richtext         `apidoc:"description,elem,usage-server-description,omitempty"`
+		Variables   []*ServerVariable `apidoc:"variable,elem,usage-server-variables,omitempty"`
 
 		references []*Reference
 	}
 
+	// ServerVariable 服务地址中的变量
+	//
+	// 对应 URL 中以 {} 包含的变量名称。
+	ServerVariable struct {
+		xmlenc.BaseTag
+		RootName struct{} `apidoc:"variable,meta,usage-server-variable"`
+
+		Name        *Attribute `apidoc:"name,attr,usage-server-variable-name"`
+		Default     *Attribute `apidoc:"default,attr,usage-server-variable-default"`
+		Summary     *Attribute `apidoc:"summary,attr,usage-server-variable-summary,omitempty"`
+		Enums       []*Enum    `apidoc:"enum,elem,usage-server-variable-enums,omitempty"`
+		Description *Richtext  `apidoc:"description,elem,usage-server-variable-description,omitempty"`
+	}
+
 	// XML 仅作用于 XML 的几个属性
 	XML struct {
 		XMLAttr     *BoolAttribute `apidoc:"xml-attr,attr,usage-xml-attr,omitempty"`        // 作为父元素的 XML 属性存在
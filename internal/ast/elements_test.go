@@ -57,7 +57,7 @@ func TestAPIDoc(t *testing.T) {
 				URI: "doc.xml",
 				Range: core.Range{
 					Start: core.Position{Character: 0, Line: 2},
-					End:   core.Position{Character: 9, Line: 35},
+					End:   core.Position{Character: 9, Line: 36},
 				},
 			},
 		},
@@ -84,16 +84,16 @@ func TestAPIDoc(t *testing.T) {
 			Location: core.Location{
 				URI: "doc.xml",
 				Range: core.Range{
-					Start: core.Position{Character: 2, Line: 35},
-					End:   core.Position{Character: 8, Line: 35},
+					Start: core.Position{Character: 2, Line: 36},
+					End:   core.Position{Character: 8, Line: 36},
 				},
 			},
 			Local: xmlenc.String{
 				Location: core.Location{
 					URI: "doc.xml",
 					Range: core.Range{
-						Start: core.Position{Character: 2, Line: 35},
-						End:   core.Position{Character: 8, Line: 35},
+						Start: core.Position{Character: 2, Line: 36},
+						End:   core.Position{Character: 8, Line: 36},
 					},
 				},
 				Value: "apidoc",
@@ -290,6 +290,10 @@ func TestAPIDoc(t *testing.T) {
 		Equal(doc.License.Text.V(), "MIT").
 		Equal(doc.License.URL.V(), "https://opensource.org/licenses/MIT")
 
+	a.NotNil(doc.ExternalDocs).
+		Equal(doc.ExternalDocs.Text.V(), "docs").
+		Equal(doc.ExternalDocs.URL.V(), "https://example.com/docs")
+
 	a.NotNil(doc.Contact).
 		Equal(doc.Contact.Name.V(), "test").
 		Equal(doc.Contact.URL.V(), "https://example.com").
@@ -325,10 +329,12 @@ func TestAPIDoc_all(t *testing.T) {
 	tag := doc.Tags[0]
 	a.Equal(tag.Name.V(), "tag1").
 		NotEmpty(tag.Title.V()).
+		Equal(tag.Color.V(), "#0074d9").
 		Equal(1, len(tag.references))
 	tag = doc.Tags[1]
 	a.Equal(tag.Deprecated.V(), "1.0.1").
-		Equal(tag.Name.V(), "tag2")
+		Equal(tag.Name.V(), "tag2").
+		Nil(tag.Color)
 
 	a.Equal(2, len(doc.Servers))
 	srv := doc.Servers[0]
@@ -407,6 +413,14 @@ func TestAPI(t *testing.T) {
 		Equal(cb.Requests[0].Type.V(), TypeObject).
 		Equal(cb.Requests[0].Mimetype.V(), "json").
 		Equal(cb.Responses[0].Status.V(), 200)
+
+	a.Equal(1, len(api.Extensions)).
+		Equal(api.Extensions[0].Name.V(), "x-internal").
+		Equal(api.Extensions[0].Value.V(), "true")
+
+	a.NotNil(api.ExternalDocs).
+		Equal(api.ExternalDocs.Text.V(), "RFC 7231").
+		Equal(api.ExternalDocs.URL.V(), "https://tools.ietf.org/html/rfc7231")
 }
 
 func TestRequest_Param(t *testing.T) {
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	apisType          = reflect.TypeOf([]*API{})
+	dateAttributeType = reflect.TypeOf(&DateAttribute{})
+)
+
+// Fingerprint 返回文档内容的指纹
+//
+// 由文档的语义内容生成 SHA-256 值，位置信息、生成时间以及空白字符的差异都不会影响结果，
+// 接口按请求方法和路径排序之后参与计算，所以接口在源码中的顺序也不会影响结果。
+// 可用于判断文档内容是否发生了变化，而不需要进行完整的比较。
+func (doc *APIDoc) Fingerprint() string {
+	h := sha256.New()
+	fingerprintValue(h, reflect.ValueOf(doc).Elem())
+
+	apis := make([]*API, len(doc.APIs))
+	copy(apis, doc.APIs)
+	sort.SliceStable(apis, func(i, j int) bool {
+		mi, mj := apis[i].Method.V(), apis[j].Method.V()
+		if mi != mj {
+			return mi < mj
+		}
+		return apis[i].pathValue() < apis[j].pathValue()
+	})
+	for _, api := range apis {
+		fingerprintValue(h, reflect.ValueOf(api).Elem())
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// 将 v 的内容写入 h，字段的判断规则与 Diff 相同。
+func fingerprintValue(h hash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			h.Write([]byte{0})
+			return
+		}
+		fingerprintValue(h, v.Elem())
+	case reflect.Slice:
+		fmt.Fprintf(h, "[%d", v.Len())
+		for i := 0; i < v.Len(); i++ {
+			fingerprintValue(h, v.Index(i))
+		}
+		h.Write([]byte{']'})
+	case reflect.Struct:
+		switch val := v.Interface().(type) {
+		case Number:
+			fmt.Fprintf(h, "%d,%v,%t;", val.Int, val.Float, val.IsFloat)
+			return
+		case Bool:
+			fmt.Fprintf(h, "%t;", val.Value)
+			return
+		}
+
+		t := v.Type()
+	LOOP:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			// APIs 由 Fingerprint 排序之后单独处理
+			if !f.IsExported() || f.Type == apisType || f.Type == dateAttributeType {
+				continue
+			}
+			for _, ignore := range diffIgnoreTypes {
+				if f.Type == ignore {
+					continue LOOP
+				}
+			}
+
+			h.Write([]byte(f.Name))
+			fingerprintValue(h, v.Field(i))
+		}
+	case reflect.String:
+		s := strings.Join(strings.Fields(v.String()), " ")
+		fmt.Fprintf(h, "%d:%s", len(s), s)
+	default:
+		fmt.Fprintf(h, "%v;", v.Interface())
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+)
+
+func TestAPIDoc_Fingerprint(t *testing.T) {
+	a := assert.New(t, false)
+
+	parse := func(blocks ...string) *APIDoc {
+		doc := &APIDoc{}
+		rslt := messagetest.NewMessageHandler()
+		for _, b := range blocks {
+			doc.Parse(rslt.Handler, core.Block{Location: core.Location{URI: "doc.xml"}, Data: []byte(b)})
+		}
+		rslt.Handler.Stop()
+		a.Empty(rslt.Errors)
+		return doc
+	}
+
+	get := `<api method="GET" summary="list"><path path="/users" /><response status="200" type="string" /></api>`
+	post := `<api method="POST"><path path="/users" /><request type="object"><param name="name" type="string" summary="name" /></request><response status="201" /></api>`
+
+	doc := parse(`<apidoc version="1.0.0" created="2020-01-02T15:04:05+08:00"><title>title</title><mimetype>application/json</mimetype></apidoc>`, get, post)
+	fp := doc.Fingerprint()
+	a.Length(fp, 64).Equal(fp, doc.Fingerprint())
+
+	// 空白字符、接口顺序以及生成时间不同
+	doc2 := parse(`<apidoc version="1.0.0" created="2021-01-02T15:04:05+08:00">
+	<title>  title </title>
+	<mimetype>application/json</mimetype>
+</apidoc>`, post, `<api method="GET" summary="list">
+	<path path="/users" />
+	<response status="200" type="string" />
+</api>`)
+	a.Equal(doc2.Fingerprint(), fp)
+
+	// 内容不同
+	doc3 := parse(`<apidoc version="1.0.0"><title>title</title><mimetype>application/json</mimetype></apidoc>`,
+		get, `<api method="POST"><path path="/users" /><request type="object"><param name="name" type="number" summary="name" /></request><response status="201" /></api>`)
+	a.NotEqual(doc3.Fingerprint(), fp)
+
+	doc4 := parse(`<apidoc version="1.0.1"><title>title</title><mimetype>application/json</mimetype></apidoc>`, get, post)
+	a.NotEqual(doc4.Fingerprint(), fp)
+
+	doc5 := parse(`<apidoc version="1.0.0"><title>title</title><mimetype>application/json</mimetype></apidoc>`, get)
+	a.NotEqual(doc5.Fingerprint(), fp)
+
+	a.NotEqual((&APIDoc{}).Fingerprint(), fp)
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"io"
 	"sort"
+	"strings"
+	"unicode"
 
 	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/internal/locale"
@@ -73,6 +75,44 @@ func (doc *APIDoc) Parse(h *core.MessageHandler, b core.Block) {
 	doc.sortAPIs()
 }
 
+// CommentedAPIs 查找 b 中被 XML 注释包含的 api 元素
+//
+// 一般用于临时禁用某个接口而不删除其内容，比如：
+//  <!-- <api method="GET">...</api> -->
+// 返回值为各个注释所在的位置。
+func CommentedAPIs(h *core.MessageHandler, b core.Block) []core.Location {
+	if !isValid(b) {
+		return nil
+	}
+
+	p, err := xmlenc.NewParser(h, b)
+	if err != nil { // 错误信息由 Parse 输出
+		return nil
+	}
+
+	var locs []core.Location
+	for {
+		t, _, err := p.Token()
+		if err != nil { // 包括 io.EOF，其它错误由 Parse 输出
+			return locs
+		}
+
+		if c, ok := t.(*xmlenc.Comment); ok && isAPIElement(c.Value.Value) {
+			locs = append(locs, c.Location)
+		}
+	}
+}
+
+// 判断 s 是否以 <api 元素开头
+func isAPIElement(s string) bool {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "<api") {
+		return false
+	}
+	s = s[len("<api"):]
+	return s != "" && (s[0] == '>' || s[0] == '/' || unicode.IsSpace(rune(s[0])))
+}
+
 // 简单预判是否是一个合规的 apidoc 内容
 func isValid(b core.Block) bool {
 	bs := bytes.TrimSpace(b.Data)
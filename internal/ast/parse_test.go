@@ -45,6 +45,31 @@ func TestAPIDoc_ParseBlocks(t *testing.T) {
 	a.NotEmpty(rslt.Errors)
 }
 
+func TestCommentedAPIs(t *testing.T) {
+	a := assert.New(t, false)
+
+	rslt := messagetest.NewMessageHandler()
+	b := core.Block{Data: []byte(`<!-- <api method="GET"><path path="/p1" /></api> -->
+<!--<api>-->
+<!-- <apidoc version="1.0.0" /> -->
+<!-- api -->`)}
+	locs := CommentedAPIs(rslt.Handler, b)
+	a.Length(locs, 2).
+		Equal(locs[0].Range, core.Range{End: core.Position{Character: 52}}).
+		Equal(locs[1].Range, core.Range{Start: core.Position{Line: 1}, End: core.Position{Line: 1, Character: 12}})
+
+	// 非注释
+	locs = CommentedAPIs(rslt.Handler, core.Block{Data: []byte(`<api method="GET"><path path="/p1" /></api>`)})
+	a.Empty(locs)
+
+	// 注释在元素内
+	locs = CommentedAPIs(rslt.Handler, core.Block{Data: []byte(`<apidoc><!-- <api method="GET" /> --></apidoc>`)})
+	a.Length(locs, 1)
+
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+}
+
 func TestAPIDoc_Parse(t *testing.T) {
 	a := assert.New(t, false)
 
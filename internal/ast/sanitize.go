@@ -3,7 +3,9 @@
 package ast
 
 import (
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/issue9/sliceutil"
 	"github.com/issue9/validation/is"
@@ -38,6 +40,21 @@ func (api *API) Sanitize(p *xmlenc.Parser) {
 		}
 		p.Error(err)
 	}
+	indexes = sliceutil.Dup(api.Extensions, func(i, j *Extension) bool { return i.Name.V() == j.Name.V() })
+	if len(indexes) > 0 {
+		err := api.Extensions[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField("ext")
+		for _, ext := range indexes[1:] {
+			err.Relate(api.Extensions[ext].Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
+}
+
+// Sanitize token.Sanitizer
+func (ext *Extension) Sanitize(p *xmlenc.Parser) {
+	if !strings.HasPrefix(ext.Name.V(), ExtensionPrefix) || len(ext.Name.V()) == len(ExtensionPrefix) {
+		p.Error(ext.Location.NewError(locale.ErrInvalidValue).WithField("@name"))
+	}
 }
 
 // Sanitize token.Sanitizer
@@ -85,13 +102,13 @@ func parsePath(path string) (params map[string]struct{}, err error) {
 		switch b {
 		case '{':
 			if start != -1 {
-				return nil, locale.NewError(locale.ErrInvalidFormat)
+				return nil, core.NewError(locale.ErrInvalidFormat)
 			}
 
 			start = i + 1
 		case '}':
 			if start == -1 {
-				return nil, locale.NewError(locale.ErrInvalidFormat)
+				return nil, core.NewError(locale.ErrInvalidFormat)
 			}
 
 			if params == nil {
@@ -104,7 +121,7 @@ func parsePath(path string) (params map[string]struct{}, err error) {
 	}
 
 	if start != -1 { // 没有结束符号
-		return nil, locale.NewError(locale.ErrInvalidFormat)
+		return nil, core.NewError(locale.ErrInvalidFormat)
 	}
 
 	return params, nil
@@ -280,6 +297,72 @@ func (ns *XMLNamespace) Sanitize(p *xmlenc.Parser) {
 	}
 }
 
+// Sanitize 检测内容是否合法
+func (tag *Tag) Sanitize(p *xmlenc.Parser) {
+	if tag.Color != nil && !isColor(tag.Color.V()) {
+		p.Error(tag.Color.Location.NewError(locale.ErrInvalidFormat).WithField("@color"))
+	}
+}
+
+var colorRegexp = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// CSS 2.1 中定义的基本颜色名称
+var colorNames = []string{
+	"aqua", "black", "blue", "fuchsia", "gray", "green", "lime", "maroon", "navy",
+	"olive", "orange", "purple", "red", "silver", "teal", "white", "yellow",
+}
+
+// 是否为合法的颜色值
+//
+// 可以是 #rgb 或是 #rrggbb 格式的十六进制值，或是 CSS 的基本颜色名称。
+func isColor(v string) bool {
+	if colorRegexp.MatchString(v) {
+		return true
+	}
+	return sliceutil.Exists(colorNames, func(name string) bool { return strings.EqualFold(name, v) })
+}
+
+// Sanitize 检测内容是否合法
+//
+// description 与 summary 内容相同时，给出警告信息。
+// 未指定 description 时，由使用方以 summary 代替，不会修改文档内容。
+func (srv *Server) Sanitize(p *xmlenc.Parser) {
+	if desc := srv.Description.V(); desc != "" && desc == srv.Summary.V() {
+		p.Warning(srv.Description.Location.NewError(locale.ErrSameAs, "summary").WithField("description"))
+	}
+
+	indexes := sliceutil.Dup(srv.Variables, func(i, j *ServerVariable) bool { return i.Name.V() == j.Name.V() })
+	if len(indexes) > 0 {
+		err := srv.Variables[indexes[0]].Location.NewError(locale.ErrDuplicateValue).WithField("variable")
+		for _, i := range indexes[1:] {
+			err.Relate(srv.Variables[i].Location, locale.Sprintf(locale.ErrDuplicateValue))
+		}
+		p.Error(err)
+	}
+
+	// 变量必须出现在 URL 中
+	for _, v := range srv.Variables {
+		if !strings.Contains(srv.URL.V(), "{"+v.Name.V()+"}") {
+			p.Error(v.Name.Location.NewError(locale.ErrInvalidValue).WithField("@name"))
+		}
+	}
+}
+
+// Sanitize 检测内容是否合法
+func (v *ServerVariable) Sanitize(p *xmlenc.Parser) {
+	if v.Name.V() == "" {
+		p.Error(v.Location.NewError(locale.ErrIsEmpty, "@name").WithField("@name"))
+	}
+
+	checkDuplicateEnum(v.Enums, p)
+
+	if v.Default.V() == "" {
+		p.Error(v.Location.NewError(locale.ErrIsEmpty, "@default").WithField("@default"))
+	} else if len(v.Enums) > 0 && !sliceutil.Exists(v.Enums, func(e *Enum) bool { return e.Value.V() == v.Default.V() }) {
+		p.Error(v.Default.Location.NewError(locale.ErrInvalidValue).WithField("@default"))
+	}
+}
+
 func (doc *APIDoc) checkXMLNamespaces(p *xmlenc.Parser) error {
 	if len(doc.XMLNamespaces) == 0 {
 		return nil
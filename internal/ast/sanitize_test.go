@@ -21,6 +21,9 @@ var (
 	_ xmlenc.Sanitizer = &Path{}
 	_ xmlenc.Sanitizer = &Enum{}
 	_ xmlenc.Sanitizer = &XMLNamespace{}
+	_ xmlenc.Sanitizer = &Server{}
+	_ xmlenc.Sanitizer = &ServerVariable{}
+	_ xmlenc.Sanitizer = &Extension{}
 )
 
 func newEmptyParser(a *assert.Assertion) *xmlenc.Parser {
@@ -200,6 +203,40 @@ func TestAPI_Sanitize(t *testing.T) {
 	api.Sanitize(p)
 	rslt.Handler.Stop()
 	a.NotEmpty(rslt.Errors)
+
+	// extensions
+
+	api = &API{
+		Extensions: []*Extension{{Name: &Attribute{Value: xmlenc.String{Value: "x-internal"}}}},
+	}
+	p, rslt = newParser(a, "", "")
+	api.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	api.Extensions = append(api.Extensions, &Extension{Name: &Attribute{Value: xmlenc.String{Value: "x-internal"}}})
+	p, rslt = newParser(a, "", "")
+	api.Sanitize(p)
+	rslt.Handler.Stop()
+	a.NotEmpty(rslt.Errors)
+}
+
+func TestExtension_Sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	for _, name := range []string{"", "x-", "internal", "X-internal"} {
+		ext := &Extension{Name: &Attribute{Value: xmlenc.String{Value: name}}}
+		p, rslt := newParser(a, "", "")
+		ext.Sanitize(p)
+		rslt.Handler.Stop()
+		a.Equal(1, len(rslt.Errors), "failed at %s", name)
+	}
+
+	ext := &Extension{Name: &Attribute{Value: xmlenc.String{Value: "x-rate-limit"}}}
+	p, rslt := newParser(a, "", "")
+	ext.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
 }
 
 func TestXMLnamespace_Sanitize(t *testing.T) {
@@ -218,6 +255,136 @@ func TestXMLnamespace_Sanitize(t *testing.T) {
 	a.Empty(rslt.Errors)
 }
 
+func TestTag_Sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	tag := &Tag{}
+	p, rslt := newParser(a, "", "")
+	tag.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	for _, color := range []string{"#fff", "#00ff00", "#ABCDEF", "red", "Blue"} {
+		tag.Color = &Attribute{Value: xmlenc.String{Value: color}}
+		p, rslt = newParser(a, "", "")
+		tag.Sanitize(p)
+		rslt.Handler.Stop()
+		a.Empty(rslt.Errors, color)
+	}
+
+	for _, color := range []string{"", "#", "#ff", "#ffff", "#fffff", "#0000000", "#xyz", "fff", "not-a-color"} {
+		tag.Color = &Attribute{Value: xmlenc.String{Value: color}}
+		p, rslt = newParser(a, "", "")
+		tag.Sanitize(p)
+		rslt.Handler.Stop()
+		a.Equal(1, len(rslt.Errors), color)
+	}
+}
+
+func TestServer_Sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	srv := &Server{
+		URL: &Attribute{Value: xmlenc.String{Value: "https://{env}.example.com"}},
+		Variables: []*ServerVariable{
+			{Name: &Attribute{Value: xmlenc.String{Value: "env"}}},
+		},
+	}
+	p, rslt := newParser(a, "", "")
+	srv.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	// 不存在于 URL 中
+	srv.Variables = append(srv.Variables, &ServerVariable{Name: &Attribute{Value: xmlenc.String{Value: "version"}}})
+	p, rslt = newParser(a, "", "")
+	srv.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Equal(1, len(rslt.Errors))
+
+	// 重复的变量名
+	srv.Variables = []*ServerVariable{
+		{Name: &Attribute{Value: xmlenc.String{Value: "env"}}},
+		{Name: &Attribute{Value: xmlenc.String{Value: "env"}}},
+	}
+	p, rslt = newParser(a, "", "")
+	srv.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Equal(1, len(rslt.Errors))
+
+	// 不会以 summary 填充 description
+	srv = &Server{
+		URL:     &Attribute{Value: xmlenc.String{Value: "https://example.com"}},
+		Summary: &Attribute{Value: xmlenc.String{Value: "summary"}},
+	}
+	p, rslt = newParser(a, "", "")
+	srv.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Empty(rslt.Warns).Nil(srv.Description)
+
+	// 都为空
+	srv = &Server{URL: &Attribute{Value: xmlenc.String{Value: "https://example.com"}}}
+	p, rslt = newParser(a, "", "")
+	srv.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Empty(rslt.Warns).Nil(srv.Description)
+
+	// 不同的内容
+	srv = &Server{
+		URL:     &Attribute{Value: xmlenc.String{Value: "https://example.com"}},
+		Summary: &Attribute{Value: xmlenc.String{Value: "summary"}},
+		Description: &Richtext{
+			Type: &Attribute{Value: xmlenc.String{Value: RichtextTypeHTML}},
+			Text: &CData{Value: xmlenc.String{Value: "<p>desc</p>"}},
+		},
+	}
+	p, rslt = newParser(a, "", "")
+	srv.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Empty(rslt.Warns).
+		Equal(srv.Description.V(), "<p>desc</p>")
+
+	// 相同的内容
+	srv.Description.Text.Value.Value = "summary"
+	p, rslt = newParser(a, "", "")
+	srv.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Equal(1, len(rslt.Warns))
+}
+
+func TestServerVariable_Sanitize(t *testing.T) {
+	a := assert.New(t, false)
+
+	v := &ServerVariable{}
+	p, rslt := newParser(a, "", "")
+	v.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Equal(2, len(rslt.Errors))
+
+	v.Name = &Attribute{Value: xmlenc.String{Value: "env"}}
+	v.Default = &Attribute{Value: xmlenc.String{Value: "api"}}
+	p, rslt = newParser(a, "", "")
+	v.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	// 默认值不在枚举中
+	v.Enums = []*Enum{
+		{Value: &Attribute{Value: xmlenc.String{Value: "dev"}}},
+		{Value: &Attribute{Value: xmlenc.String{Value: "test"}}},
+	}
+	p, rslt = newParser(a, "", "")
+	v.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Equal(1, len(rslt.Errors))
+
+	v.Enums = append(v.Enums, &Enum{Value: &Attribute{Value: xmlenc.String{Value: "api"}}})
+	p, rslt = newParser(a, "", "")
+	v.Sanitize(p)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+}
+
 func TestParsePath(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -265,6 +432,8 @@ func TestParsePath(t *testing.T) {
 
 		if item.err {
 			a.Error(err).Nil(p)
+			serr, ok := err.(*core.Error)
+			a.True(ok).Equal(serr.Code(), locale.ErrInvalidFormat)
 			continue
 		}
 		a.NotError(err).Equal(p, item.params)
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+// DocStats 文档的统计信息
+type DocStats struct {
+	TotalAPIs           int            // 接口数量
+	DeprecatedAPIs      int            // 已弃用的接口数量
+	TotalTags           int            // 标签数量
+	TotalServers        int            // 服务器数量
+	UniqueRequestTypes  int            // 请求内容中不同类型的数量
+	UniqueResponseTypes int            // 返回内容中不同类型的数量，包含 apidoc 中的公共返回内容
+	APIsPerTag          map[string]int // 各个标签下的接口数量，键名为标签名
+}
+
+// Stats 统计文档的相关信息
+func (doc *APIDoc) Stats() DocStats {
+	stats := DocStats{
+		TotalAPIs:    len(doc.APIs),
+		TotalTags:    len(doc.Tags),
+		TotalServers: len(doc.Servers),
+		APIsPerTag:   make(map[string]int, len(doc.Tags)),
+	}
+
+	requests := make(map[string]struct{}, 10)
+	responses := make(map[string]struct{}, 10)
+	for _, resp := range doc.Responses {
+		responses[resp.Type.V()] = struct{}{}
+	}
+
+	for _, api := range doc.APIs {
+		if api.Deprecated != nil {
+			stats.DeprecatedAPIs++
+		}
+
+		for _, tag := range api.Tags {
+			stats.APIsPerTag[tag.V()]++
+		}
+
+		for _, req := range api.Requests {
+			requests[req.Type.V()] = struct{}{}
+		}
+
+		for _, resp := range api.Responses {
+			responses[resp.Type.V()] = struct{}{}
+		}
+	}
+
+	stats.UniqueRequestTypes = len(requests)
+	stats.UniqueResponseTypes = len(responses)
+	return stats
+}
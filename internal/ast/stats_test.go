@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+)
+
+func TestAPIDoc_Stats(t *testing.T) {
+	a := assert.New(t, false)
+
+	blocks := []string{
+		`<apidoc version="1.0.0">
+	<title>title</title>
+	<tag name="t1" title="t1" />
+	<tag name="t2" title="t2" />
+	<server name="s1" url="https://example.com" summary="s1" />
+	<mimetype>application/json</mimetype>
+	<response status="500" type="string" />
+</apidoc>`,
+		`<api method="GET" deprecated="1.0.0">
+	<path path="/users" />
+	<tag>t1</tag>
+	<response status="200" type="object"><param name="id" type="number" summary="id" /></response>
+</api>`,
+		`<api method="POST">
+	<path path="/users" />
+	<tag>t1</tag>
+	<tag>t2</tag>
+	<request type="object"><param name="name" type="string" summary="name" /></request>
+	<response status="201" type="string" />
+</api>`,
+		`<api method="DELETE">
+	<path path="/users" />
+	<request type="string" />
+	<response status="204" />
+</api>`,
+	}
+
+	doc := &APIDoc{}
+	rslt := messagetest.NewMessageHandler()
+	for _, b := range blocks {
+		doc.Parse(rslt.Handler, core.Block{Location: core.Location{URI: "doc.xml"}, Data: []byte(b)})
+	}
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	a.Equal(doc.Stats(), DocStats{
+		TotalAPIs:           3,
+		DeprecatedAPIs:      1,
+		TotalTags:           2,
+		TotalServers:        1,
+		UniqueRequestTypes:  2,
+		UniqueResponseTypes: 3,
+		APIsPerTag:          map[string]int{"t1": 2, "t2": 1},
+	})
+
+	a.Equal((&APIDoc{}).Stats(), DocStats{APIsPerTag: map[string]int{}})
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"encoding/xml"
+	"reflect"
+
+	"github.com/caixw/apidoc/v7/internal/node"
+)
+
+// XSDNamespace XML Schema 的命名空间
+const XSDNamespace = "http://www.w3.org/2001/XMLSchema"
+
+type (
+	xsdSchema struct {
+		XMLName      xml.Name          `xml:"xs:schema"`
+		XMLNS        string            `xml:"xmlns:xs,attr"`
+		Form         string            `xml:"elementFormDefault,attr"`
+		Elements     []*xsdElement     `xml:"xs:element"`
+		ComplexTypes []*xsdComplexType `xml:"xs:complexType"`
+	}
+
+	xsdElement struct {
+		Name string `xml:"name,attr"`
+		Type string `xml:"type,attr"`
+	}
+
+	xsdComplexType struct {
+		Name          string            `xml:"name,attr"`
+		Choice        *xsdChoice        `xml:"xs:choice,omitempty"`
+		SimpleContent *xsdSimpleContent `xml:"xs:simpleContent,omitempty"`
+		Attributes    []*xsdAttribute   `xml:"xs:attribute,omitempty"`
+	}
+
+	// 子元素的顺序和数量不作限制
+	xsdChoice struct {
+		MinOccurs string        `xml:"minOccurs,attr"`
+		MaxOccurs string        `xml:"maxOccurs,attr"`
+		Elements  []*xsdElement `xml:"xs:element"`
+	}
+
+	xsdSimpleContent struct {
+		Extension *xsdExtension `xml:"xs:extension"`
+	}
+
+	xsdExtension struct {
+		Base       string          `xml:"base,attr"`
+		Attributes []*xsdAttribute `xml:"xs:attribute,omitempty"`
+	}
+
+	xsdAttribute struct {
+		Name string `xml:"name,attr"`
+		Type string `xml:"type,attr"`
+		Use  string `xml:"use,attr,omitempty"`
+	}
+)
+
+// GenerateXSD 根据 APIDoc 的结构生成 XML Schema 文档
+//
+// 所有的类型均根据 apidoc 结构体标签生成，子元素的顺序和数量不作限制。
+// apidoc 和 api 均可作为根元素，与源码中的注释块相对应；
+// 生成的 Schema 没有 targetNamespace，仅适用于未指定命名空间的文档。
+func GenerateXSD() ([]byte, error) {
+	data, err := xml.MarshalIndent(newXSD(), "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+func newXSD() *xsdSchema {
+	schema := &xsdSchema{XMLNS: XSDNamespace, Form: "unqualified"}
+
+	for _, v := range []interface{}{&APIDoc{}, &API{}} {
+		n := node.New("", reflect.ValueOf(v))
+		schema.Elements = append(schema.Elements, &xsdElement{Name: n.Value.Name, Type: n.TypeName})
+		schema.appendComplexType(n)
+	}
+
+	return schema
+}
+
+// 将 n 及其所有子元素的类型添加到 schema
+func (schema *xsdSchema) appendComplexType(n *node.Node) {
+	for _, t := range schema.ComplexTypes {
+		if t.Name == n.TypeName {
+			return
+		}
+	}
+
+	t := &xsdComplexType{Name: n.TypeName}
+	schema.ComplexTypes = append(schema.ComplexTypes, t) // 先添加，防止递归类型重复添加
+
+	attrs := make([]*xsdAttribute, 0, len(n.Attributes))
+	for _, attr := range n.Attributes {
+		a := &xsdAttribute{Name: attr.Name, Type: xsdSimpleType(attr.Type())}
+		if !attr.Omitempty {
+			a.Use = "required"
+		}
+		attrs = append(attrs, a)
+	}
+
+	if len(n.Elements) == 0 {
+		t.SimpleContent = &xsdSimpleContent{Extension: &xsdExtension{Base: "xs:string", Attributes: attrs}}
+		return
+	}
+	t.Attributes = attrs
+
+	t.Choice = &xsdChoice{MinOccurs: "0", MaxOccurs: "unbounded"}
+	for _, elem := range n.Elements {
+		typ := node.RealType(elem.Type())
+		if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+			typ = node.RealType(typ.Elem())
+		}
+
+		nn := node.New(elem.Name, reflect.New(typ).Elem())
+		if len(nn.Attributes) == 0 && len(nn.Elements) == 0 { // 只有内容的元素
+			t.Choice.Elements = append(t.Choice.Elements, &xsdElement{Name: elem.Name, Type: xsdSimpleType(typ)})
+			continue
+		}
+
+		t.Choice.Elements = append(t.Choice.Elements, &xsdElement{Name: elem.Name, Type: nn.TypeName})
+		schema.appendComplexType(nn)
+	}
+}
+
+// 将属性或是只有内容的元素类型转换成 XML Schema 的内置类型
+func xsdSimpleType(t reflect.Type) string {
+	t = node.RealType(t)
+	if t.Kind() != reflect.Struct {
+		return "xs:string"
+	}
+
+	v := node.ParseValue(reflect.New(t).Elem())
+	if v == nil {
+		return "xs:string"
+	}
+
+	switch v.Name {
+	case "bool":
+		return "xs:boolean"
+	case "number":
+		return "xs:decimal"
+	case "date":
+		return "xs:dateTime"
+	default:
+		return "xs:string"
+	}
+}
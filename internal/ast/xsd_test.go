@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: MIT
+
+package ast
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v2"
+)
+
+func TestGenerateXSD(t *testing.T) {
+	a := assert.New(t, false)
+
+	data, err := GenerateXSD()
+	a.NotError(err).NotNil(data)
+
+	// 格式正确的 XML 文档
+	schema := &struct {
+		XMLName  xml.Name
+		Elements []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"element"`
+	}{}
+	a.NotError(xml.Unmarshal(data, schema))
+	a.Equal(schema.XMLName.Space, XSDNamespace).
+		Equal(schema.XMLName.Local, "schema").
+		Length(schema.Elements, 2).
+		Equal(schema.Elements[0].Name, "apidoc").
+		Equal(schema.Elements[1].Name, "api")
+
+	// 递归类型只定义一次
+	s := newXSD()
+	names := make(map[string]struct{}, len(s.ComplexTypes))
+	for _, t := range s.ComplexTypes {
+		_, found := names[t.Name]
+		a.False(found, "重复定义的类型 %s", t.Name)
+		names[t.Name] = struct{}{}
+	}
+}
+
+func TestXSD_validate(t *testing.T) {
+	a := assert.New(t, false)
+	s := newXSD()
+
+	for _, file := range []string{"./testdata/doc.xml", "./testdata/all.xml", "./testdata/api.xml"} {
+		data, err := os.ReadFile(file)
+		a.NotError(err).NotNil(data)
+		a.NotError(s.validate(data), "%s 验证失败", file)
+	}
+
+	a.Error(s.validate([]byte(`<apidoc><not-exists /></apidoc>`)))
+	a.Error(s.validate([]byte(`<apidoc not-exists="1"></apidoc>`)))
+	a.Error(s.validate([]byte(`<apidoc created="not-date"></apidoc>`)))
+	a.Error(s.validate([]byte(`<apidoc><tag title="t1" /></apidoc>`))) // 缺少 name
+	a.Error(s.validate([]byte(`<apidoc><title><p>title</p></title></apidoc>`)))
+	a.Error(s.validate([]byte(`<tag name="t1" title="t1" />`))) // 非根元素
+}
+
+// 以 schema 的定义验证 data 的内容
+//
+// 仅用于测试，只实现了 newXSD 中用到的部分规则。
+func (schema *xsdSchema) validate(data []byte) error {
+	types := make(map[string]*xsdComplexType, len(schema.ComplexTypes))
+	for _, t := range schema.ComplexTypes {
+		types[t.Name] = t
+	}
+
+	stack := make([]string, 0, 10) // 各层元素的类型
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			var elems []*xsdElement
+			if len(stack) == 0 {
+				elems = schema.Elements
+			} else if parent := types[stack[len(stack)-1]]; parent != nil && parent.Choice != nil {
+				elems = parent.Choice.Elements
+			}
+
+			typ := ""
+			for _, e := range elems {
+				if e.Name == elem.Name.Local {
+					typ = e.Type
+					break
+				}
+			}
+			if typ == "" {
+				return &xml.SyntaxError{Msg: "未定义的元素 " + elem.Name.Local}
+			}
+
+			if err := validateAttrs(types[typ], elem.Attr); err != nil {
+				return err
+			}
+			stack = append(stack, typ)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+func validateAttrs(t *xsdComplexType, attrs []xml.Attr) error {
+	if t == nil { // 简单类型，不能有属性
+		if len(attrs) > 0 {
+			return &xml.SyntaxError{Msg: "简单类型不能有属性"}
+		}
+		return nil
+	}
+
+	defines := t.Attributes
+	if t.SimpleContent != nil {
+		defines = t.SimpleContent.Extension.Attributes
+	}
+
+	for _, attr := range attrs {
+		var def *xsdAttribute
+		for _, d := range defines {
+			if d.Name == attr.Name.Local {
+				def = d
+				break
+			}
+		}
+		if def == nil {
+			return &xml.SyntaxError{Msg: "未定义的属性 " + attr.Name.Local}
+		}
+		if !validSimpleValue(def.Type, attr.Value) {
+			return &xml.SyntaxError{Msg: "无效的属性值 " + attr.Value}
+		}
+	}
+
+LOOP:
+	for _, d := range defines {
+		if d.Use != "required" {
+			continue
+		}
+		for _, attr := range attrs {
+			if attr.Name.Local == d.Name {
+				continue LOOP
+			}
+		}
+		return &xml.SyntaxError{Msg: "缺少属性 " + d.Name}
+	}
+
+	return nil
+}
+
+func validSimpleValue(typ, v string) bool {
+	var err error
+	switch typ {
+	case "xs:boolean":
+		_, err = strconv.ParseBool(v)
+	case "xs:decimal":
+		_, err = strconv.ParseFloat(v, 64)
+	case "xs:dateTime":
+		_, err = time.Parse(time.RFC3339, v)
+	}
+	return err == nil
+}
@@ -3,7 +3,13 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/issue9/cmdopt"
@@ -13,14 +19,25 @@ import (
 	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
-var buildDir = uri("./")
+var (
+	buildDir        = uri("./")
+	buildProfile    string
+	buildMemProfile string
+	buildStats      bool
+	buildMetrics    string
+)
 
 func initBuild(command *cmdopt.CmdOpt) {
 	fs := command.New("build", locale.Sprintf(locale.CmdBuildUsage), doBuild)
 	fs.Var(&buildDir, "d", locale.Sprintf(locale.FlagBuildDirUsage))
+	fs.StringVar(&buildProfile, "profile", "", locale.Sprintf(locale.FlagBuildProfileUsage))
+	fs.StringVar(&buildMemProfile, "mem-profile", "", locale.Sprintf(locale.FlagBuildMemProfileUsage))
+	fs.BoolVar(&buildStats, "stats", false, locale.Sprintf(locale.FlagBuildStatsUsage))
+	fs.StringVar(&buildMetrics, "metrics-file", "", locale.Sprintf(locale.FlagBuildMetricsFileUsage))
+	initMessageFlags(fs)
 }
 
-func doBuild(io.Writer) error {
+func doBuild(w io.Writer) error {
 	start := time.Now()
 
 	cfg, err := build.LoadConfig(core.URI(buildDir))
@@ -28,10 +45,106 @@ func doBuild(io.Writer) error {
 		return err
 	}
 
-	h := core.NewMessageHandler(messageHandle)
-	defer h.Stop()
+	h := newMessageHandler()
+	stats, err := buildWithProfile(cfg, h)
+	if err == nil {
+		h.Locale(core.Info, locale.Complete, cfg.Output.Path, time.Since(start))
+	}
+	stopMessageHandler(h) // 保证 h.Count 的结果完整
 
-	cfg.Build(h)
-	h.Locale(core.Info, locale.Complete, cfg.Output.Path, time.Since(start))
+	// 即使构建出错也需要写入度量数据
+	if buildMetrics != "" {
+		if err := build.NewMetrics(start, stats, h).Save(buildMetrics); err != nil {
+			return err
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if buildStats {
+		return printStats(w, stats)
+	}
 	return nil
 }
+
+// 构建文档，并根据参数写入 CPU 和内存的性能数据
+func buildWithProfile(cfg *build.Config, h *core.MessageHandler) (*build.Stats, error) {
+	var stats *build.Stats
+	if buildProfile != "" {
+		f, err := os.Create(buildProfile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return nil, err
+		}
+		stats = cfg.BuildStats(h)
+		pprof.StopCPUProfile()
+	} else {
+		stats = cfg.BuildStats(h)
+	}
+
+	if buildMemProfile != "" {
+		if err := writeMemProfile(buildMemProfile); err != nil {
+			return stats, err
+		}
+	}
+
+	return stats, nil
+}
+
+// 以表格的形式输出 stats 的内容
+func printStats(w io.Writer, stats *build.Stats) error {
+	type row struct {
+		name  string
+		value int
+	}
+
+	rows := []*row{
+		{name: locale.Sprintf(locale.StatsTotalAPIs), value: stats.TotalAPIs},
+		{name: locale.Sprintf(locale.StatsDeprecatedAPIs), value: stats.DeprecatedAPIs},
+		{name: locale.Sprintf(locale.StatsTotalTags), value: stats.TotalTags},
+		{name: locale.Sprintf(locale.StatsTotalServers), value: stats.TotalServers},
+		{name: locale.Sprintf(locale.StatsRequestTypes), value: stats.UniqueRequestTypes},
+		{name: locale.Sprintf(locale.StatsResponseTypes), value: stats.UniqueResponseTypes},
+	}
+
+	tags := make([]string, 0, len(stats.APIsPerTag))
+	for tag := range stats.APIsPerTag {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		rows = append(rows, &row{name: locale.Sprintf(locale.StatsAPIsPerTag, tag), value: stats.APIsPerTag[tag]})
+	}
+
+	var maxName int
+	for _, r := range rows {
+		calcMaxWidth(r.name, &maxName)
+	}
+	maxName += tail
+
+	for _, r := range rows {
+		name := r.name + strings.Repeat(" ", maxName-textWidth(r.name))
+		if _, err := fmt.Fprintln(w, name, r.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 将当前的内存分配数据写入 path
+func writeMemProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	runtime.GC() // 获取最新的统计数据
+	return pprof.WriteHeapProfile(f)
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/build"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/docs"
+	"github.com/caixw/apidoc/v7/internal/locale"
+)
+
+func TestCmdBuild_metrics(t *testing.T) {
+	a := assert.New(t, false)
+
+	// 输出到临时目录，不修改 docs/example 下的内容
+	dir := t.TempDir()
+	example, err := docs.Dir().Append("example").File()
+	a.NotError(err)
+	cfg := "version: " + ast.Version + "\ninputs:\n- lang: c++\n  dir: " + example + "\noutput:\n  path: ./index.xml\n"
+	a.NotError(os.WriteFile(filepath.Join(dir, ".apidoc.yaml"), []byte(cfg), os.ModePerm))
+
+	path := filepath.Join(dir, "metrics.json")
+	cmd := Init(new(bytes.Buffer))
+	resetPrinters()
+	a.NotError(cmd.Exec([]string{"build", "-d", dir, "-metrics-file", path}))
+	a.FileExists(filepath.Join(dir, "index.xml"))
+
+	data, err := os.ReadFile(path)
+	a.NotError(err)
+	m := &build.Metrics{}
+	a.NotError(json.Unmarshal(data, m))
+	a.True(m.APICount > 0).
+		Equal(m.ErrorCount, 0).
+		False(m.Timestamp.IsZero())
+}
+
+func TestPrintStats(t *testing.T) {
+	a := assert.New(t, false)
+
+	buf := new(bytes.Buffer)
+	a.NotError(printStats(buf, &build.Stats{
+		TotalAPIs:  3,
+		TotalTags:  2,
+		APIsPerTag: map[string]int{"t2": 1, "t1": 2},
+	}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	a.Equal(8, len(lines))
+	a.True(strings.HasPrefix(lines[0], locale.Sprintf(locale.StatsTotalAPIs))).
+		True(strings.HasSuffix(lines[0], " 3"))
+	a.True(strings.HasPrefix(lines[6], locale.Sprintf(locale.StatsAPIsPerTag, "t1"))).
+		True(strings.HasSuffix(lines[6], " 2"))
+	a.True(strings.HasPrefix(lines[7], locale.Sprintf(locale.StatsAPIsPerTag, "t2"))).
+		True(strings.HasSuffix(lines[7], " 1"))
+}
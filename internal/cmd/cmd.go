@@ -43,12 +43,29 @@ var printers = map[core.MessageType]*printer{
 	},
 }
 
+// 由各个子命令的 -quiet 和 -verbose 参数指定
+var (
+	quiet   bool
+	verbose bool
+)
+
 type printer struct {
 	out    io.Writer
 	color  colors.Color
 	prefix message.Reference
 }
 
+// ExitError 表示需要以指定的状态码退出程序的错误
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (err *ExitError) Error() string { return err.Err.Error() }
+
+// Unwrap 实现 errors.Unwrap 接口
+func (err *ExitError) Unwrap() error { return err.Err }
+
 type uri core.URI
 
 func (u uri) Get() interface{} { return string(u) }
@@ -90,7 +107,34 @@ func Init(out io.Writer) *cmdopt.CmdOpt {
 	return command
 }
 
+// 为 fs 添加控制信息输出的参数
+func initMessageFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&quiet, "quiet", false, locale.Sprintf(locale.FlagQuietUsage))
+	fs.BoolVar(&verbose, "verbose", false, locale.Sprintf(locale.FlagVerboseUsage))
+}
+
+// 声明采用 messageHandle 输出信息的 core.MessageHandler
+//
+// 需要调用 stopMessageHandler 结束。
+func newMessageHandler() *core.MessageHandler {
+	return core.NewCollectMessageHandler(messageHandle)
+}
+
+// 结束 h，如果指定了 verbose，还会输出警告信息的数量。
+func stopMessageHandler(h *core.MessageHandler) {
+	h.Stop()
+
+	if verbose {
+		_, warnCount := h.Count()
+		printers[core.Info].print(locale.New(locale.WarnSummary, warnCount))
+	}
+}
+
 func messageHandle(msg *core.Message) {
+	if quiet && (msg.Type == core.Info || msg.Type == core.Succ) {
+		return
+	}
+
 	printers[msg.Type].print(msg.Message)
 }
 
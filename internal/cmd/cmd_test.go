@@ -78,3 +78,40 @@ func TestMessageHandle(t *testing.T) {
 	a.Contains(info.String(), "info")
 	a.Contains(succ.String(), "succ")
 }
+
+func TestMessageHandle_quiet(t *testing.T) {
+	a := assert.New(t, false)
+
+	erro := new(bytes.Buffer)
+	warn := new(bytes.Buffer)
+	info := new(bytes.Buffer)
+	succ := new(bytes.Buffer)
+
+	printers[core.Erro].out = erro
+	printers[core.Warn].out = warn
+	printers[core.Info].out = info
+	printers[core.Succ].out = succ
+
+	quiet = true
+	verbose = true
+	defer func() {
+		quiet = false
+		verbose = false
+	}()
+
+	h := newMessageHandler()
+	a.NotNil(h)
+
+	h.Locale(core.Erro, "erro")
+	h.Locale(core.Warn, "warn")
+	h.Locale(core.Warn, "warn")
+	h.Locale(core.Info, "info")
+	h.Locale(core.Succ, "succ")
+	stopMessageHandler(h)
+
+	a.Contains(erro.String(), "erro")
+	a.Contains(warn.String(), "warn")
+	a.NotContains(info.String(), "info").
+		Contains(info.String(), locale.Sprintf(locale.WarnSummary, 2))
+	a.Empty(succ.String())
+}
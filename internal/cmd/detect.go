@@ -3,6 +3,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 
@@ -17,6 +18,8 @@ import (
 var (
 	detectRecursive bool
 	detectWrite     bool
+	detectNormalize bool
+	detectFormat    string
 	detectDir       = uri("./")
 )
 
@@ -24,12 +27,19 @@ func initDetect(command *cmdopt.CmdOpt) {
 	fs := command.New("detect", locale.Sprintf(locale.CmdDetectUsage), detect)
 	fs.BoolVar(&detectRecursive, "r", true, locale.Sprintf(locale.FlagDetectRecursiveUsage))
 	fs.BoolVar(&detectWrite, "w", false, locale.Sprintf(locale.FlagDetectWrite))
-	fs.Var(&buildDir, "d", locale.Sprintf(locale.FlagDetectDirUsage))
+	fs.BoolVar(&detectNormalize, "normalize", false, locale.Sprintf(locale.FlagDetectNormalize))
+	fs.StringVar(&detectFormat, "format", "yaml", locale.Sprintf(locale.FlagDetectFormat))
+	fs.Var(&detectDir, "d", locale.Sprintf(locale.FlagDetectDirUsage))
+	initMessageFlags(fs)
 }
 
 func detect(w io.Writer) error {
-	h := core.NewMessageHandler(messageHandle)
-	defer h.Stop()
+	h := newMessageHandler()
+	defer stopMessageHandler(h)
+
+	if detectFormat != "yaml" && detectFormat != "json" {
+		return core.NewError(locale.ErrInvalidValue).WithField("format")
+	}
 
 	dir := detectDir.URI()
 	cfg, err := build.DetectConfig(dir, detectRecursive)
@@ -37,8 +47,20 @@ func detect(w io.Writer) error {
 		return err
 	}
 
+	if detectNormalize {
+		if err = cfg.Normalize(); err != nil {
+			return err
+		}
+	}
+
 	if !detectWrite {
-		data, err := yaml.Marshal(cfg)
+		var data []byte
+		if detectFormat == "json" {
+			data, err = json.MarshalIndent(cfg, "", "\t")
+			data = append(data, '\n')
+		} else {
+			data, err = yaml.Marshal(cfg)
+		}
 		if err != nil {
 			return err
 		}
@@ -46,7 +68,12 @@ func detect(w io.Writer) error {
 		return err
 	}
 
-	if err = cfg.Save(dir); err != nil {
+	if detectFormat == "json" {
+		err = cfg.SaveJSON(dir)
+	} else {
+		err = cfg.Save(dir)
+	}
+	if err != nil {
 		return err
 	}
 	h.Locale(core.Succ, locale.ConfigWriteSuccess, dir)
@@ -4,12 +4,15 @@ package cmd
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/issue9/assert/v2"
 	"gopkg.in/yaml.v3"
 
 	"github.com/caixw/apidoc/v7/build"
+	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/docs"
 )
@@ -28,13 +31,42 @@ func TestCmdDetect(t *testing.T) {
 	a.NotError(yaml.Unmarshal(buf.Bytes(), cfg))
 	a.Equal(cfg.Version, ast.Version)
 
+	buf.Reset()
 	cmd = Init(buf)
 	resetPrinters()
-	err = cmd.Exec([]string{"detect", "-d", path.String(), "-w"})
+	err = cmd.Exec([]string{"detect", "-d", path.String(), "-normalize"})
 	a.NotError(err)
-	cfg2 := &build.Config{}
-	data, err := build.LoadConfig(path)
-	a.NotError(err).NotNil(data)
-	a.NotError(yaml.Unmarshal(buf.Bytes(), cfg2))
-	a.Equal(cfg, cfg2)
+	normalized := &build.Config{}
+	a.NotError(yaml.Unmarshal(buf.Bytes(), normalized))
+	a.Equal(len(normalized.Inputs), len(cfg.Inputs))
+	for i := 1; i < len(normalized.Inputs); i++ {
+		a.True(normalized.Inputs[i-1].Lang <= normalized.Inputs[i].Lang)
+	}
+
+	// 写入 -d 指定的目录
+	dir := t.TempDir()
+	a.NotError(os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), os.ModePerm))
+	cmd = Init(buf)
+	resetPrinters()
+	err = cmd.Exec([]string{"detect", "-d", dir, "-w"})
+	a.NotError(err)
+	saved, err := build.LoadConfig(core.FileURI(dir))
+	a.NotError(err).NotNil(saved)
+	a.Equal(saved.Inputs[0].Lang, "go")
+	a.FileNotExists(filepath.Join(dir, ".apidoc.yaml.bak"))
+
+	buf.Reset()
+	cmd = Init(buf)
+	resetPrinters()
+	err = cmd.Exec([]string{"detect", "-d", path.String(), "-format", "json"})
+	a.NotError(err)
+	jsonCfg, err := build.LoadConfigJSON(path, buf)
+	a.NotError(err).NotNil(jsonCfg)
+	a.Equal(jsonCfg.Version, cfg.Version).
+		Equal(len(jsonCfg.Inputs), len(cfg.Inputs))
+
+	cmd = Init(buf)
+	resetPrinters()
+	err = cmd.Exec([]string{"detect", "-d", path.String(), "-format", "toml"})
+	a.Error(err)
 }
@@ -3,36 +3,42 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
 
 	"github.com/issue9/cmdopt"
-	"golang.org/x/text/language/display"
 
 	"github.com/caixw/apidoc/v7"
 	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
+var localeJSON bool
+
 func initLocale(command *cmdopt.CmdOpt) {
-	command.New("locale", locale.Sprintf(locale.CmdLocaleUsage), doLocale)
+	fs := command.New("locale", locale.Sprintf(locale.CmdLocaleUsage), doLocale)
+	fs.BoolVar(&localeJSON, "json", false, locale.Sprintf(locale.FlagLocaleJSONUsage))
 }
 
 func doLocale(w io.Writer) error {
-	locales := make(map[string]string, len(apidoc.Locales()))
+	locales := apidoc.AllLocales()
+
+	if localeJSON {
+		return json.NewEncoder(w).Encode(locales)
+	}
 
 	// 计算各列的最大长度值
 	var maxID int
-	for _, tag := range apidoc.Locales() {
-		id := tag.String()
-		calcMaxWidth(id, &maxID)
-		locales[id] = display.Self.Name(tag)
+	for _, l := range locales {
+		calcMaxWidth(l.Tag.String(), &maxID)
 	}
 	maxID += tail
 
-	for k, v := range locales {
-		id := k + strings.Repeat(" ", maxID-len(k))
-		if _, err := fmt.Fprintln(w, id, v); err != nil {
+	for _, l := range locales {
+		id := l.Tag.String()
+		id += strings.Repeat(" ", maxID-len(id))
+		if _, err := fmt.Fprintln(w, id, l.NativeName); err != nil {
 			return err
 		}
 	}
@@ -5,6 +5,7 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"io"
 	"strings"
 	"testing"
@@ -35,4 +36,15 @@ func TestLocale(t *testing.T) {
 		cnt := strings.Count(l, strings.Repeat(" ", tail))
 		a.True(cnt >= 1) // 至少两列
 	}
+
+	localeJSON = true
+	defer func() { localeJSON = false }()
+	w.Reset()
+	a.NotError(doLocale(w))
+	locales := []*apidoc.LocaleMeta{}
+	a.NotError(json.Unmarshal(w.Bytes(), &locales)).
+		Equal(len(locales), len(apidoc.Locales()))
+	for _, l := range locales {
+		a.NotEmpty(l.NativeName).Equal(l.Direction, apidoc.DirectionLTR)
+	}
 }
@@ -3,8 +3,13 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"io"
 	"log"
+	"os"
+	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/issue9/cmdopt"
@@ -18,6 +23,7 @@ var (
 	lspMode    string
 	lspHeader  bool
 	lspTimeout time.Duration
+	lspConns   int
 )
 
 func initLSP(command *cmdopt.CmdOpt) {
@@ -26,8 +32,20 @@ func initLSP(command *cmdopt.CmdOpt) {
 	ls.StringVar(&lspMode, "m", "stdio", locale.Sprintf(locale.FlagLSPModeUsage))
 	ls.BoolVar(&lspHeader, "h", false, locale.Sprintf(locale.FlagLSPHeaderUsage))
 	ls.DurationVar(&lspTimeout, "t", time.Second, locale.Sprintf(locale.FlagLSPTimeoutUsage))
+	ls.IntVar(&lspConns, "c", 0, locale.Sprintf(locale.FlagLSPMaxConnsUsage))
 }
 
 func doLSP(o io.Writer) error {
-	return apidoc.ServeLSP(lspHeader, lspMode, lspPort, lspTimeout, log.New(o, "", 0), log.New(o, "", 0))
+	if strings.ToLower(lspMode) == "stdio" { // 标准输出用于传输数据，日志只能输出到标准错误。
+		o = os.Stderr
+	}
+	// 中断信号会关闭所有的连接并退出
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := apidoc.ServeLSP(ctx, lspHeader, lspMode, lspPort, lspTimeout, lspConns, log.New(o, "", 0), log.New(o, "", 0))
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
 }
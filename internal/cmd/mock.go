@@ -159,6 +159,7 @@ var (
 	mockEmailDomains = &slice{"example.com"}
 	mockURLDomains   = &slice{"https://example.com"}
 	mockDateRange    = &dateRange{}
+	mockRetry        int
 )
 
 func initMock(command *cmdopt.CmdOpt) {
@@ -184,11 +185,14 @@ func initMock(command *cmdopt.CmdOpt) {
 	fs.StringVar(&mockOptions.ImageBasePrefix, "image.prefix", "/__image__", locale.Sprintf(locale.FlagMockImagePrefixUsage))
 
 	fs.Var(mockDateRange, "date.range", locale.Sprintf(locale.FlagMockDateRangeUsage))
+	fs.Int64Var(&mockOptions.Seed, "seed", 0, locale.Sprintf(locale.FlagMockSeedUsage))
+	fs.IntVar(&mockRetry, "retry", 0, locale.Sprintf(locale.FlagMockRetryUsage))
+	initMessageFlags(fs)
 }
 
 func doMock(io.Writer) error {
-	h := core.NewMessageHandler(messageHandle)
-	defer h.Stop()
+	h := newMessageHandler()
+	defer stopMessageHandler(h)
 
 	mockOptions.Servers = mockServers
 	mockOptions.StringAlpha = []byte(mockStringAlpha)
@@ -200,6 +204,9 @@ func doMock(io.Writer) error {
 	mockOptions.EmailUsernameSize = apidoc.Range(*mockUsernameSize)
 	mockOptions.DateStart = mockDateRange.start
 	mockOptions.DateEnd = mockDateRange.end
+	if mockRetry > 0 {
+		mockOptions.Retry = &core.RetryOptions{MaxRetries: mockRetry, InitialDelay: time.Second, BackoffFactor: 2}
+	}
 	handler, err := apidoc.MockFile(h, mockPath.URI(), mockOptions)
 	if err != nil {
 		return err
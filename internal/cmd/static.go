@@ -31,12 +31,13 @@ func initStatic(command *cmdopt.CmdOpt) {
 	fs.StringVar(&staticURL, "url", "", locale.Sprintf(locale.FlagStaticURLUsage))
 	fs.BoolVar(&staticStylesheet, "stylesheet", false, locale.Sprintf(locale.FlagStaticStylesheetUsage))
 	fs.Var(&staticPath, "path", locale.Sprintf(locale.FlagStaticPathUsage))
+	initMessageFlags(fs)
 }
 
 func static(io.Writer) (err error) {
 	path := core.URI(staticPath)
-	h := core.NewMessageHandler(messageHandle)
-	defer h.Stop()
+	h := newMessageHandler()
+	defer stopMessageHandler(h)
 
 	var handler http.Handler
 
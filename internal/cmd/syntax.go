@@ -12,11 +12,16 @@ import (
 	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
-var syntaxDir uri = uri(core.FileURI("./"))
+var (
+	syntaxDir         uri = uri(core.FileURI("./"))
+	syntaxWarnAsError bool
+)
 
 func initSyntax(command *cmdopt.CmdOpt) {
 	fs := command.New("syntax", locale.Sprintf(locale.CmdSyntaxUsage), syntax)
 	fs.Var(&syntaxDir, "d", locale.Sprintf(locale.FlagSyntaxDirUsage))
+	fs.BoolVar(&syntaxWarnAsError, "warn-as-error", false, locale.Sprintf(locale.FlagSyntaxWarnAsErrorUsage))
+	initMessageFlags(fs)
 }
 
 func syntax(w io.Writer) error {
@@ -25,10 +30,16 @@ func syntax(w io.Writer) error {
 		return err
 	}
 
-	h := core.NewMessageHandler(messageHandle)
-	defer h.Stop()
+	h := newMessageHandler()
+	defer stopMessageHandler(h)
+
+	errCount, warnCount := cfg.CheckSyntaxResult(h)
+	switch {
+	case errCount > 0:
+		return &ExitError{Code: 1, Err: locale.NewError(locale.SyntaxFailed, errCount, warnCount)}
+	case warnCount > 0 && syntaxWarnAsError:
+		return &ExitError{Code: 2, Err: locale.NewError(locale.SyntaxFailed, errCount, warnCount)}
+	}
 
-	cfg.CheckSyntax(h)
-	h.Locale(core.Succ, locale.TestSuccess)
 	return nil
 }
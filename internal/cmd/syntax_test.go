@@ -4,10 +4,13 @@ package cmd
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/issue9/assert/v2"
 
+	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/docs"
 )
 
@@ -23,3 +26,21 @@ func TestCmdCheckSyntax(t *testing.T) {
 		Empty(erro.String()).
 		NotEmpty(succ.String())
 }
+
+func TestCmdCheckSyntax_failed(t *testing.T) {
+	a := assert.New(t, false)
+
+	dir := t.TempDir()
+	cfg := "version: " + ast.Version + "\ninputs:\n- lang: go\n  dir: .\noutput:\n  path: ./apidoc.xml\n"
+	a.NotError(os.WriteFile(filepath.Join(dir, ".apidoc.yaml"), []byte(cfg), os.ModePerm))
+	a.NotError(os.WriteFile(filepath.Join(dir, "api.go"), []byte("// <api method=\"GET\">\n"), os.ModePerm))
+
+	buf := new(bytes.Buffer)
+	cmd := Init(buf)
+	erro, _, succ, _ := resetPrinters()
+	err := cmd.Exec([]string{"syntax", "-d", dir})
+	exitErr, ok := err.(*ExitError)
+	a.True(ok).Equal(exitErr.Code, 1)
+	a.NotEmpty(erro.String()).
+		Empty(succ.String())
+}
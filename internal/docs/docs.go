@@ -5,6 +5,7 @@ package docs
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"io/fs"
@@ -13,6 +14,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/issue9/source"
 
@@ -47,20 +49,94 @@ func Dir() core.URI {
 //
 // 相对于 docs 目录
 func StylesheetURL(prefix string) string {
+	return AssetURL(prefix, "apidoc.xsl")
+}
+
+// AssetURL 生成当前版本文档目录下 filename 文件的 URL 地址
+//
+// 相对于 docs 目录，比如 apidoc.css 会生成 prefix/v6/apidoc.css。
+func AssetURL(prefix, filename string) string {
+	return VersionAssetURL(prefix, ast.MajorVersion, filename)
+}
+
+// VersionAssetURL 生成指定版本文档目录下 filename 文件的 URL 地址
+//
+// version 为文档的主版本号，比如 v5。
+func VersionAssetURL(prefix, version, filename string) string {
 	if prefix == "" {
-		return ast.MajorVersion + "/apidoc.xsl"
+		return version + "/" + filename
 	}
 	if prefix[len(prefix)-1] != '/' {
 		prefix += "/"
 	}
-	return prefix + ast.MajorVersion + "/apidoc.xsl"
+	return prefix + version + "/" + filename
+}
+
+// 用于检测内嵌文档是否完整的文件列表
+var sentinels = []string{
+	indexPage,
+	"icon.svg",
+	ast.MajorVersion + "/apidoc.xsl",
+}
+
+// Validate 检测内嵌的文档内容是否完整
+//
+// 仅检测部分关键文件是否存在且不为空，
+// 防止因为编译环境的问题导致内嵌的文档内容缺失。
+func Validate() error {
+	for _, name := range sentinels {
+		data, err := fs.ReadFile(docs.FS, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			return core.NewError(locale.ErrFileNotFound, name)
+		} else if err != nil {
+			return err
+		}
+
+		if len(data) == 0 {
+			return core.NewError(locale.ErrIsEmpty, name)
+		}
+	}
+	return nil
 }
 
 // Handler 返回文件服务中间件
 //
-// 如果 folder 为空，表示采用内嵌的数据作为文件服务；
-// stylesheet 是否只返回最基本的样式表相关文件。
+// 如果 folder 为空，表示采用内嵌的数据作为文件服务，
+// 也可以通过 core.FSURI 指定任意的 fs.FS 作为文件服务；
+// stylesheet 是否只返回最基本的样式表相关文件；
+// erro 为服务出错时的错误信息输出通道，为空表示采用 log.Default()。
+//
+// 除了 folder 中的文件之外，还会在 XSDPath 输出由 ast.GenerateXSD 生成的 XML Schema。
 func Handler(folder core.URI, stylesheet bool, erro *log.Logger) http.Handler {
+	if erro == nil {
+		erro = log.Default()
+	}
+
+	return xsdHandler(folderHandler(folder, stylesheet, erro))
+}
+
+// XSDPath Handler 中输出 XML Schema 的地址
+const XSDPath = "/apidoc.xsd"
+
+func xsdHandler(h http.Handler) http.Handler {
+	data, err := ast.GenerateXSD()
+	if err != nil { // 由结构体生成，不可能出错
+		panic(err)
+	}
+	modTime := time.Now()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, "/") != XSDPath[1:] { // 经过 http.StripPrefix 处理的地址可能没有 / 前缀
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		http.ServeContent(w, r, XSDPath, modTime, bytes.NewReader(data))
+	})
+}
+
+func folderHandler(folder core.URI, stylesheet bool, erro *log.Logger) http.Handler {
 	if folder == "" {
 		return fsHandler(docs.FS, stylesheet, erro)
 	}
@@ -70,14 +146,69 @@ func Handler(folder core.URI, stylesheet bool, erro *log.Logger) http.Handler {
 		return fsHandler(os.DirFS(path), stylesheet, erro)
 	case core.SchemeHTTP, core.SchemeHTTPS:
 		return remoteHandler(folder, stylesheet, erro)
+	case core.SchemeFS:
+		fsys, p, err := folder.FS()
+		if err != nil {
+			panic(err)
+		}
+		if fsys, err = fs.Sub(fsys, p); err != nil {
+			panic(err)
+		}
+		return fsHandler(fsys, stylesheet, erro)
 	default:
 		panic(locale.NewError(locale.ErrInvalidURIScheme, scheme))
 	}
 }
 
+// DefaultHealthPath 健康检测的默认地址
+const DefaultHealthPath = "/healthz"
+
+// 健康检测返回的内容
+type health struct {
+	Status    string    `json:"status"`
+	Version   string    `json:"version"`
+	StartTime time.Time `json:"startTime"`
+}
+
+// WithHealth 在 h 的基础上添加健康检测的地址
+//
+// 访问 healthPath 时，以 JSON 格式返回当前的状态、版本号以及服务的启动时间，
+// healthPath 为空表示采用 DefaultHealthPath，其它地址交由 h 处理。
+func WithHealth(h http.Handler, healthPath string) http.Handler {
+	if healthPath == "" {
+		healthPath = DefaultHealthPath
+	}
+
+	data, err := json.Marshal(&health{
+		Status:    "ok",
+		Version:   core.Version(),
+		StartTime: time.Now(),
+	})
+	if err != nil { // 内容固定，不可能出错
+		panic(err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != healthPath {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	})
+}
+
 func fsHandler(fsys fs.FS, stylesheet bool, erro *log.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		pp := r.URL.Path
+		if hasParentDir(pp) {
+			errStatus(w, http.StatusForbidden)
+			return
+		}
+
 		if pp == "" || pp == "/" {
 			pp = indexPage
 		}
@@ -130,6 +261,10 @@ func fsHandler(fsys fs.FS, stylesheet bool, erro *log.Logger) http.Handler {
 func remoteHandler(url core.URI, stylesheet bool, erro *log.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		p := r.URL.Path
+		if hasParentDir(p) {
+			errStatus(w, http.StatusForbidden)
+			return
+		}
 
 		if stylesheet && !isStylesheetFile(p) {
 			errStatus(w, http.StatusNotFound)
@@ -141,7 +276,7 @@ func remoteHandler(url core.URI, stylesheet bool, erro *log.Logger) http.Handler
 		if err != nil {
 			httpError, ok := err.(*core.HTTPError)
 			if !ok {
-				errStatus(w, http.StatusInternalServerError)
+				errStatusWithError(w, err, erro)
 				return
 			}
 
@@ -176,6 +311,20 @@ func errStatusWithError(w http.ResponseWriter, err error, l *log.Logger) {
 	errStatus(w, http.StatusInternalServerError)
 }
 
+// 判断路径 p 中是否包含指向上一级目录的 ..
+//
+// 包含 .. 的路径有可能访问到根目录之外的内容，一律禁止访问。
+// r.URL.Path 已经经过解码，%2e%2e 之类的编码形式也会被当作 .. 处理。
+func hasParentDir(p string) bool {
+	segs := strings.FieldsFunc(p, func(r rune) bool { return r == '/' || r == '\\' })
+	for _, seg := range segs {
+		if seg == ".." {
+			return true
+		}
+	}
+	return false
+}
+
 func isStylesheetFile(filename string) bool {
 	if len(filename) > 0 && filename[0] == '/' {
 		filename = filename[1:]
@@ -3,17 +3,23 @@
 package docs
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/issue9/assert/v2"
 	"github.com/issue9/assert/v2/rest"
 
 	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
 func TestDir(t *testing.T) {
@@ -42,6 +48,30 @@ func TestStylesheetURL(t *testing.T) {
 	a.Equal(StylesheetURL("https://apidoc.tools"), "https://apidoc.tools/"+ast.MajorVersion+"/apidoc.xsl")
 }
 
+func TestAssetURL(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(AssetURL("", "apidoc.css"), ast.MajorVersion+"/apidoc.css")
+	a.Equal(AssetURL(".", "apidoc.css"), "./"+ast.MajorVersion+"/apidoc.css")
+	a.Equal(AssetURL("/api-docs", "apidoc.js"), "/api-docs/"+ast.MajorVersion+"/apidoc.js")
+	a.Equal(AssetURL("/api-docs/", "apidoc.js"), "/api-docs/"+ast.MajorVersion+"/apidoc.js")
+	a.Equal(AssetURL("https://apidoc.tools", "apidoc.xsl"), StylesheetURL("https://apidoc.tools"))
+}
+
+func TestVersionAssetURL(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(VersionAssetURL("", "v5", "apidoc.xsl"), "v5/apidoc.xsl")
+	a.Equal(VersionAssetURL("https://apidoc.tools", "v5", "apidoc.xsl"), "https://apidoc.tools/v5/apidoc.xsl")
+	a.Equal(VersionAssetURL("https://apidoc.tools/", "v5", "apidoc.css"), "https://apidoc.tools/v5/apidoc.css")
+	a.Equal(VersionAssetURL(".", ast.MajorVersion, "apidoc.xsl"), StylesheetURL("."))
+}
+
+func TestValidate(t *testing.T) {
+	a := assert.New(t, false)
+	a.NotError(Validate())
+}
+
 func TestEmbeddedHandler(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -76,6 +106,11 @@ func TestEmbeddedHandler(t *testing.T) {
 	srv.Get("/icon.svg").
 		Do(nil).
 		Status(http.StatusOK)
+
+	srv.Get(XSDPath).
+		Do(nil).
+		Status(http.StatusOK).
+		Header("Content-Type", "application/xml; charset=utf-8")
 }
 
 func TestEmbeddedHandler_stylesheet(t *testing.T) {
@@ -143,6 +178,10 @@ func TestEmbeddedHandler_prefix(t *testing.T) {
 	srv.Get("/prefix/icon.svg").
 		Do(nil).
 		Status(http.StatusOK)
+
+	srv.Get("/prefix" + XSDPath).
+		Do(nil).
+		Status(http.StatusOK)
 }
 
 func TestLocalHandler(t *testing.T) {
@@ -239,6 +278,103 @@ func TestLocalHandler_prefix(t *testing.T) {
 		Status(http.StatusOK)
 }
 
+func TestFSHandler(t *testing.T) {
+	a := assert.New(t, false)
+
+	fsys := fstest.MapFS{
+		"docs/index.xml":         &fstest.MapFile{Data: []byte("<apidoc />")},
+		"docs/example/index.xml": &fstest.MapFile{Data: []byte("<apidoc />")},
+		"docs/icon.svg":          &fstest.MapFile{Data: []byte("<svg />")},
+	}
+
+	srv := rest.NewServer(a, Handler(core.FSURI(fsys, "docs"), false, log.Default()), nil)
+	srv.Get("/").Do(nil).Status(http.StatusOK).StringBody("<apidoc />")
+	srv.Get("/example").Do(nil).Status(http.StatusOK)
+	srv.Get("/icon.svg").Do(nil).Status(http.StatusOK)
+	srv.Get("/not-exists").Do(nil).Status(http.StatusNotFound)
+
+	srv = rest.NewServer(a, Handler(core.FSURI(fsys, "docs"), true, log.Default()), nil)
+	srv.Get("/icon.svg").Do(nil).Status(http.StatusOK)
+	srv.Get("/index.xml").Do(nil).Status(http.StatusNotFound)
+
+	a.Panic(func() {
+		Handler("fs://invalid", false, log.Default())
+	})
+}
+
+func TestHandler_parentDir(t *testing.T) {
+	a := assert.New(t, false)
+
+	remote := httptest.NewServer(Handler(Dir(), false, log.Default()))
+	defer remote.Close()
+
+	handlers := []http.Handler{
+		Handler("", false, log.Default()),
+		Handler(Dir().Append("example"), false, log.Default()),
+		Handler(core.URI(remote.URL).Append("example"), false, log.Default()),
+	}
+	paths := []string{
+		"/../index.xml",
+		"/example/../../index.xml",
+		"/%2e%2e/index.xml",
+		"/%2E%2E%2Findex.xml",
+		"/..%5cindex.xml",
+	}
+
+	for _, h := range handlers {
+		for _, p := range paths {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, p, nil))
+			a.Equal(w.Code, http.StatusForbidden, "%s 的状态码为 %d", p, w.Code)
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/index.xml", nil))
+		a.Equal(w.Code, http.StatusOK)
+	}
+
+	a.False(hasParentDir("/a..b/index.xml"))
+	a.False(hasParentDir("/v7/apidoc.xsl"))
+	a.True(hasParentDir(".."))
+}
+
+func TestWithHealth(t *testing.T) {
+	a := assert.New(t, false)
+
+	get := func(h http.Handler, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	now := time.Now()
+	h := WithHealth(Handler("", false, log.Default()), "")
+	w := get(h, DefaultHealthPath)
+	a.Equal(w.Code, http.StatusOK).
+		Equal(w.Header().Get("Content-Type"), "application/json")
+	hh := &health{}
+	a.NotError(json.Unmarshal(w.Body.Bytes(), hh))
+	a.Equal(hh.Status, "ok").
+		Equal(hh.Version, core.Version()).
+		False(hh.StartTime.Before(now.Truncate(time.Second)))
+
+	// 启动时间不会随请求改变
+	w = get(h, DefaultHealthPath)
+	hh2 := &health{}
+	a.NotError(json.Unmarshal(w.Body.Bytes(), hh2))
+	a.True(hh2.StartTime.Equal(hh.StartTime))
+
+	// 其它地址交由 h 处理
+	a.Equal(get(h, "/index.xml").Code, http.StatusOK)
+	a.Equal(get(h, "/not-exists").Code, http.StatusNotFound)
+
+	// 自定义地址
+	h = WithHealth(Handler(Dir(), true, log.Default()), "/status")
+	a.Equal(get(h, "/status").Code, http.StatusOK)
+	a.Equal(get(h, DefaultHealthPath).Code, http.StatusNotFound)
+	a.Equal(get(h, "/index.xml").Code, http.StatusNotFound) // stylesheet
+}
+
 func TestRemoteHandler(t *testing.T) {
 	a := assert.New(t, false)
 
@@ -335,3 +471,35 @@ func TestRemoteHandler_prefix(t *testing.T) {
 		Do(nil).
 		Status(http.StatusOK)
 }
+
+func TestRemoteHandler_error(t *testing.T) {
+	a := assert.New(t, false)
+
+	remote := httptest.NewServer(Handler(Dir(), false, nil))
+	url := remote.URL
+	remote.Close() // 关闭远程服务，让所有的请求都返回非 HTTPError 错误
+
+	buf := new(bytes.Buffer)
+	srv := rest.NewServer(a, Handler(core.URI(url), false, log.New(buf, "", 0)), nil)
+	srv.Get("/index.xml").
+		Do(nil).
+		Status(http.StatusInternalServerError)
+	a.NotEmpty(buf.String())
+}
+
+func TestErrStatusWithError(t *testing.T) {
+	a := assert.New(t, false)
+
+	buf := new(bytes.Buffer)
+	w := httptest.NewRecorder()
+	errStatusWithError(w, errors.New("error"), log.New(buf, "", 0))
+	a.Equal(w.Code, http.StatusInternalServerError).
+		Equal(buf.String(), "error\n")
+
+	// HTTPError 不会输出到日志
+	buf.Reset()
+	w = httptest.NewRecorder()
+	errStatusWithError(w, core.NewHTTPError(http.StatusBadGateway, locale.ErrInvalidValue), log.New(buf, "", 0))
+	a.Equal(w.Code, http.StatusBadGateway).
+		Empty(buf.String())
+}
@@ -49,7 +49,7 @@ func (d *doc) buildConfigItem(parent string, f reflect.StructField) error {
 		Usage:    locale.Sprintf("usage-config-" + name),
 	})
 
-	if isPrimitive(t) {
+	if isPrimitive(t) || t.Kind() == reflect.Map { // map 的键名由用户指定，无法展开其字段
 		return nil
 	} else if t.Kind() != reflect.Struct {
 		panic(fmt.Sprintf("字段 %s 的类型 %s 无法处理", f.Name, t.Kind()))
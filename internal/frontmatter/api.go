@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+
+package frontmatter
+
+import "encoding/xml"
+
+// API front-matter 中可用的字段
+//
+// 各字段与 <api> 元素中的同名属性或子元素相对应。
+type API struct {
+	Method      string     `yaml:"method"`
+	Path        string     `yaml:"path"`
+	ID          string     `yaml:"id,omitempty"`
+	Summary     string     `yaml:"summary,omitempty"`
+	Description string     `yaml:"description,omitempty"` // markdown 格式
+	Version     string     `yaml:"version,omitempty"`
+	Deprecated  string     `yaml:"deprecated,omitempty"`
+	Tags        []string   `yaml:"tags,omitempty"`
+	Servers     []string   `yaml:"servers,omitempty"`
+	Params      []*Param   `yaml:"params,omitempty"`  // 路径参数
+	Queries     []*Param   `yaml:"queries,omitempty"` // 查询参数
+	Headers     []*Param   `yaml:"headers,omitempty"`
+	Requests    []*Request `yaml:"requests,omitempty"`
+	Responses   []*Request `yaml:"responses,omitempty"`
+}
+
+// Param 参数
+type Param struct {
+	Name     string   `yaml:"name"`
+	Type     string   `yaml:"type"`
+	Summary  string   `yaml:"summary,omitempty"`
+	Default  string   `yaml:"default,omitempty"`
+	Optional bool     `yaml:"optional,omitempty"`
+	Array    bool     `yaml:"array,omitempty"`
+	Items    []*Param `yaml:"items,omitempty"`
+}
+
+// Request 请求或是返回的内容
+type Request struct {
+	Status   int      `yaml:"status,omitempty"`
+	Mimetype string   `yaml:"mimetype,omitempty"`
+	Type     string   `yaml:"type,omitempty"`
+	Summary  string   `yaml:"summary,omitempty"`
+	Array    bool     `yaml:"array,omitempty"`
+	Items    []*Param `yaml:"items,omitempty"`
+	Headers  []*Param `yaml:"headers,omitempty"`
+}
+
+type xmlAPI struct {
+	XMLName     struct{}        `xml:"api"`
+	Method      string          `xml:"method,attr"`
+	ID          string          `xml:"id,attr,omitempty"`
+	Summary     string          `xml:"summary,attr,omitempty"`
+	Version     string          `xml:"version,attr,omitempty"`
+	Deprecated  string          `xml:"deprecated,attr,omitempty"`
+	Path        *xmlPath        `xml:"path"`
+	Description *xmlDescription `xml:"description,omitempty"`
+	Tags        []string        `xml:"tag,omitempty"`
+	Servers     []string        `xml:"server,omitempty"`
+	Headers     []*xmlParam     `xml:"header,omitempty"`
+	Requests    []*xmlRequest   `xml:"request,omitempty"`
+	Responses   []*xmlRequest   `xml:"response,omitempty"`
+}
+
+type xmlPath struct {
+	Path    string      `xml:"path,attr"`
+	Params  []*xmlParam `xml:"param,omitempty"`
+	Queries []*xmlParam `xml:"query,omitempty"`
+}
+
+type xmlDescription struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",cdata"`
+}
+
+type xmlParam struct {
+	Name     string      `xml:"name,attr"`
+	Type     string      `xml:"type,attr"`
+	Summary  string      `xml:"summary,attr,omitempty"`
+	Default  string      `xml:"default,attr,omitempty"`
+	Optional bool        `xml:"optional,attr,omitempty"`
+	Array    bool        `xml:"array,attr,omitempty"`
+	Items    []*xmlParam `xml:"param,omitempty"`
+}
+
+type xmlRequest struct {
+	XMLName  xml.Name
+	Status   int         `xml:"status,attr,omitempty"`
+	Mimetype string      `xml:"mimetype,attr,omitempty"`
+	Type     string      `xml:"type,attr,omitempty"`
+	Summary  string      `xml:"summary,attr,omitempty"`
+	Array    bool        `xml:"array,attr,omitempty"`
+	Headers  []*xmlParam `xml:"header,omitempty"`
+	Items    []*xmlParam `xml:"param,omitempty"`
+}
+
+func (api *API) xml() *xmlAPI {
+	x := &xmlAPI{
+		Method:     api.Method,
+		ID:         api.ID,
+		Summary:    api.Summary,
+		Version:    api.Version,
+		Deprecated: api.Deprecated,
+		Path: &xmlPath{
+			Path:    api.Path,
+			Params:  xmlParams(api.Params),
+			Queries: xmlParams(api.Queries),
+		},
+		Tags:      api.Tags,
+		Servers:   api.Servers,
+		Headers:   xmlParams(api.Headers),
+		Requests:  xmlRequests("request", api.Requests),
+		Responses: xmlRequests("response", api.Responses),
+	}
+
+	if api.Description != "" {
+		x.Description = &xmlDescription{Type: "markdown", Text: api.Description}
+	}
+
+	return x
+}
+
+func xmlParams(params []*Param) []*xmlParam {
+	if len(params) == 0 {
+		return nil
+	}
+
+	ret := make([]*xmlParam, 0, len(params))
+	for _, p := range params {
+		ret = append(ret, &xmlParam{
+			Name:     p.Name,
+			Type:     p.Type,
+			Summary:  p.Summary,
+			Default:  p.Default,
+			Optional: p.Optional,
+			Array:    p.Array,
+			Items:    xmlParams(p.Items),
+		})
+	}
+	return ret
+}
+
+func xmlRequests(name string, reqs []*Request) []*xmlRequest {
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	ret := make([]*xmlRequest, 0, len(reqs))
+	for _, r := range reqs {
+		ret = append(ret, &xmlRequest{
+			XMLName:  xml.Name{Local: name},
+			Status:   r.Status,
+			Mimetype: r.Mimetype,
+			Type:     r.Type,
+			Summary:  r.Summary,
+			Array:    r.Array,
+			Headers:  xmlParams(r.Headers),
+			Items:    xmlParams(r.Items),
+		})
+	}
+	return ret
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+
+// Package frontmatter 处理源码文件头部以 YAML 格式描述的文档内容
+//
+// 文件需要以 --- 开头，之后是 YAML 格式的内容，最后以单独一行的 --- 结束：
+//  ---
+//  method: GET
+//  path: /users/{id}
+//  summary: 获取用户信息
+//  params:
+//    - name: id
+//      type: number
+//      summary: 用户 ID
+//  responses:
+//    - status: 200
+//      mimetype: application/json
+//      type: object
+//  ---
+// 这部分内容会被转换成等价的 <api> 元素。
+package frontmatter
+
+import (
+	"bytes"
+	"encoding/xml"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/caixw/apidoc/v7/core"
+)
+
+const delimiter = "---"
+
+// Split 从 data 中分离出 front-matter 的内容
+//
+// matter 为 front-matter 中的 YAML 内容，r 为 front-matter 在 data 中的范围；
+// rest 为将 front-matter 部分替换成空行之后的内容，以保证其它内容的定位不变。
+// 如果不存在 front-matter，则 found 返回 false。
+func Split(data []byte) (matter, rest []byte, r core.Range, found bool) {
+	first, remain := readLine(data)
+	if string(bytes.TrimRight(first, " \t\r")) != delimiter {
+		return nil, data, core.Range{}, false
+	}
+
+	start := len(data) - len(remain)
+	end := start
+	lines := 1
+	for len(remain) > 0 {
+		var line []byte
+		line, remain = readLine(remain)
+		lines++
+
+		if string(bytes.TrimRight(line, " \t\r")) == delimiter {
+			matter = data[start:end]
+			size := len(data) - len(remain)
+			rest = append(bytes.Repeat([]byte{'\n'}, bytes.Count(data[:size], []byte{'\n'})), remain...)
+
+			r = core.Range{End: core.Position{Line: lines - 1, Character: len(line)}}
+			return matter, rest, r, true
+		}
+
+		end = len(data) - len(remain)
+	}
+
+	return nil, data, core.Range{}, false
+}
+
+// 读取一行内容，返回的 line 不包含换行符
+func readLine(data []byte) (line, remain []byte) {
+	if index := bytes.IndexByte(data, '\n'); index >= 0 {
+		return data[:index], data[index+1:]
+	}
+	return data, nil
+}
+
+// Block 将 data 中的 front-matter 转换成以 XML 表示的 core.Block
+//
+// rest 为将 front-matter 替换成空行之后的 data；
+// 如果不存在 front-matter，则 block 返回 nil，rest 即为 data。
+func Block(uri core.URI, data []byte) (block *core.Block, rest []byte, err error) {
+	matter, rest, r, found := Split(data)
+	if !found {
+		return nil, data, nil
+	}
+
+	loc := core.Location{URI: uri, Range: r}
+	x, err := ToXML(matter)
+	if err != nil {
+		return nil, nil, loc.WithError(err)
+	}
+
+	return &core.Block{Location: loc, Data: x}, rest, nil
+}
+
+// ToXML 将 YAML 格式的 front-matter 内容转换成 <api> 元素
+func ToXML(matter []byte) ([]byte, error) {
+	api := &API{}
+	if err := yaml.Unmarshal(matter, api); err != nil {
+		return nil, err
+	}
+	return xml.MarshalIndent(api.xml(), "", "    ")
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+
+package frontmatter
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
+)
+
+func TestSplit(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		input  string
+		matter string
+		rest   string
+		r      core.Range
+		found  bool
+	}{
+		{input: "", rest: ""},
+		{input: "package main", rest: "package main"},
+		{input: "---\nmethod: GET\n", rest: "---\nmethod: GET\n"}, // 未结束
+		{input: "-- \nmethod: GET\n---\n", rest: "-- \nmethod: GET\n---\n"},
+		{
+			input:  "---\nmethod: GET\npath: /\n---\npackage main",
+			matter: "method: GET\npath: /\n",
+			rest:   "\n\n\n\npackage main",
+			r:      core.Range{End: core.Position{Line: 3, Character: 3}},
+			found:  true,
+		},
+		{
+			input:  "--- \r\nmethod: GET\r\n---",
+			matter: "method: GET\r\n",
+			rest:   "\n\n",
+			r:      core.Range{End: core.Position{Line: 2, Character: 3}},
+			found:  true,
+		},
+		{
+			input: "---\n---\n",
+			rest:  "\n\n",
+			r:     core.Range{End: core.Position{Line: 1, Character: 3}},
+			found: true,
+		},
+	}
+
+	for i, item := range data {
+		matter, rest, r, found := Split([]byte(item.input))
+		a.Equal(string(matter), item.matter, "not equal at %d", i).
+			Equal(string(rest), item.rest, "not equal at %d", i).
+			Equal(r, item.r, "not equal at %d", i).
+			Equal(found, item.found, "not equal at %d", i)
+	}
+}
+
+func TestToXML(t *testing.T) {
+	a := assert.New(t, false)
+
+	data, err := ToXML([]byte(`method: GET
+path: /users/{id}
+summary: get user
+description: "**desc**"
+tags: [t1, t2]
+params:
+  - name: id
+    type: number
+    summary: user id
+queries:
+  - name: page
+    type: number
+    summary: page
+    optional: true
+responses:
+  - status: 200
+    mimetype: application/json
+    type: object
+    items:
+      - name: name
+        type: string
+        summary: name
+`))
+	a.NotError(err).NotNil(data)
+
+	doc := &ast.APIDoc{}
+	rslt := messagetest.NewMessageHandler()
+	doc.Parse(rslt.Handler, core.Block{Data: data})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).Equal(1, len(doc.APIs))
+
+	api := doc.APIs[0]
+	a.Equal(api.Method.V(), "GET").
+		Equal(api.Summary.V(), "get user").
+		Equal(api.Description.V(), "**desc**").
+		Equal(2, len(api.Tags)).
+		Equal(api.Path.Path.V(), "/users/{id}").
+		Equal(api.Path.Params[0].Name.V(), "id").
+		True(api.Path.Queries[0].Optional.V()).
+		Equal(api.Responses[0].Status.V(), 200).
+		Equal(api.Responses[0].Items[0].Name.V(), "name")
+
+	data, err = ToXML([]byte("method: [GET"))
+	a.Error(err).Nil(data)
+}
+
+func TestBlock(t *testing.T) {
+	a := assert.New(t, false)
+
+	input := []byte("---\nmethod: GET\npath: /\nsummary: s\n---\npackage main")
+	block, rest, err := Block("file.go", input)
+	a.NotError(err).
+		NotNil(block).
+		Equal(block.Location, core.Location{URI: "file.go", Range: core.Range{End: core.Position{Line: 4, Character: 3}}}).
+		Equal(string(rest), "\n\n\n\n\npackage main")
+
+	block, rest, err = Block("file.go", []byte("package main"))
+	a.NotError(err).Nil(block).Equal(string(rest), "package main")
+
+	block, rest, err = Block("file.go", []byte("---\nmethod: [GET\n---\n"))
+	a.Error(err).Nil(block).Nil(rest)
+}
@@ -7,8 +7,8 @@ import (
 	"unicode"
 )
 
-// 接口定义了解析代码块的所有操作
-type blocker interface {
+// Blocker 定义了解析代码块的所有操作
+type Blocker interface {
 	// 确定 l 的当前位置是否匹配 Blocker 的起始位置。
 	beginFunc(l *parser) bool
 
@@ -37,7 +37,7 @@ type (
 	}
 )
 
-func newString(begin, end, escape string) blocker {
+func newString(begin, end, escape string) Blocker {
 	return &stringBlock{
 		begin:  begin,
 		end:    end,
@@ -45,14 +45,14 @@ func newString(begin, end, escape string) blocker {
 	}
 }
 
-func newSingleComment(begin string) blocker {
+func newSingleComment(begin string) Blocker {
 	return &singleComment{
 		begin:  begin,
 		begins: []byte(begin),
 	}
 }
 
-func newMultipleComment(begin, end, prefix string) blocker {
+func newMultipleComment(begin, end, prefix string) Blocker {
 	return &multipleComment{
 		begin:  begin,
 		end:    end,
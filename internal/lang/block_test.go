@@ -12,9 +12,9 @@ import (
 )
 
 var (
-	_ blocker = &stringBlock{}
-	_ blocker = &singleComment{}
-	_ blocker = &multipleComment{}
+	_ Blocker = &stringBlock{}
+	_ Blocker = &singleComment{}
+	_ Blocker = &multipleComment{}
 )
 
 func TestStringBlock(t *testing.T) {
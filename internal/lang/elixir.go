@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+
+package lang
+
+// 以模块属性表示的文档，比如 elixir 中的 @doc """...""" 和 erlang 中的 -doc """...""".
+//
+// 属性名与 """ 之间可以有空格，属性名和起止的 """ 都会被替换成空格。
+type elixirDocComment struct {
+	attr  string
+	delim string
+	ends  []byte
+}
+
+func newElixirDocComment(attr string) Blocker {
+	return &elixirDocComment{
+		attr:  attr,
+		delim: `"""`,
+		ends:  []byte(`"""`),
+	}
+}
+
+func (b *elixirDocComment) beginFunc(l *parser) bool {
+	start := l.Current()
+	if !l.Match(b.attr) {
+		return false
+	}
+
+	l.Spaces('\n')
+	if !l.Match(b.delim) {
+		l.Move(start)
+		return false
+	}
+
+	l.Move(start) // 起始符号由 endFunc 一并读取，以便替换成空格
+	return true
+}
+
+func (b *elixirDocComment) endFunc(l *parser) (data []byte, ok bool) {
+	start := l.Current()
+	l.Match(b.attr)
+	l.Spaces('\n')
+	l.Match(b.delim)
+	begins := l.Bytes(start.Offset, l.Current().Offset)
+
+	data, found := l.DelimString(b.delim, true)
+	if !found { // 没有找到结束符号，直接到达文件末尾
+		return nil, false
+	}
+
+	raw := make([]byte, 0, len(begins)+len(data))
+	raw = append(append(raw, begins...), data...)
+	return convertMultipleCommentToXML(raw, begins, b.ends, nil), true
+}
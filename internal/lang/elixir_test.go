@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+)
+
+func TestElixirDocComment(t *testing.T) {
+	a := assert.New(t, false)
+	b := newElixirDocComment("@doc")
+
+	rslt := messagetest.NewMessageHandler()
+	l := newParser(rslt.Handler, core.Block{Data: []byte(`@doc """
+comment1
+"""`)}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.True(b.beginFunc(l))
+	data, found := b.endFunc(l)
+	a.True(found).
+		Equal(string(data), "        \ncomment1\n   ")
+
+	// 多个段落，且 @doc 与 """ 之间有多个空格
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, core.Block{Data: []byte(`@doc   """
+  comment1
+
+  comment2
+  """
+`)}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.True(b.beginFunc(l))
+	data, found = b.endFunc(l)
+	a.True(found).
+		Equal(string(data), "          \n  comment1\n\n  comment2\n     ")
+
+	// @moduledoc
+	b = newElixirDocComment("@moduledoc")
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, core.Block{Data: []byte(`@moduledoc """comment1"""`)}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.True(b.beginFunc(l))
+	data, found = b.endFunc(l)
+	a.True(found).
+		Equal(string(data), "              comment1   ")
+
+	// 不是文档属性
+	for _, s := range []string{`@doc false`, `@docs """x"""`, `@moduledoc """x"""`, `"""x"""`} {
+		b = newElixirDocComment("@doc")
+		rslt = messagetest.NewMessageHandler()
+		l = newParser(rslt.Handler, core.Block{Data: []byte(s)}, nil)
+		rslt.Handler.Stop()
+		a.Empty(rslt.Errors).NotNil(l)
+		a.False(b.beginFunc(l), s).
+			Equal(l.Current().Offset, 0)
+	}
+
+	// 没有注释结束符
+	b = newElixirDocComment("@doc")
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, core.Block{Data: []byte(`@doc """comment1`)}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.True(b.beginFunc(l))
+	data, found = b.endFunc(l)
+	a.False(found).Nil(data)
+}
@@ -3,7 +3,11 @@
 // Package lang 管理各类语言提取注释代码块规则的定义
 package lang
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // 所有支持的语言模型定义
 var langs = []*Language{
@@ -32,7 +36,7 @@ var langs = []*Language{
 		DisplayName: "Dart",
 		ID:          "dart",
 		Exts:        []string{".dart"},
-		blocks: []blocker{
+		blocks: append([]Blocker{
 			newCStyleString(),
 			newString("'", "'", `\`),
 			newString("r'", "'", ``),
@@ -41,8 +45,20 @@ var langs = []*Language{
 			newString(`"""`, `"""`, ``),
 			newSingleComment(`///`),
 			newSwiftNestMCommentBlock("/**", "*/", "*"),
-			newCStyleSingleComment(),
-			newCStyleMultipleComment(),
+		}, CStyleBlockers("//")...),
+	},
+
+	{
+		DisplayName: "Elixir",
+		ID:          "elixir",
+		Exts:        []string{".ex", ".exs"},
+		blocks: []Blocker{
+			newElixirDocComment("@moduledoc"),
+			newElixirDocComment("@doc"),
+			newString(`"""`, `"""`, `\`),
+			newCStyleString(),
+			newString("'", "'", `\`),
+			newSingleComment("#"),
 		},
 	},
 
@@ -50,7 +66,10 @@ var langs = []*Language{
 		DisplayName: "Erlang",
 		ID:          "erlang",
 		Exts:        []string{".erl", ".hrl"},
-		blocks: []blocker{
+		blocks: []Blocker{
+			newElixirDocComment("-moduledoc"), // OTP 27 之后的文档属性
+			newElixirDocComment("-doc"),
+			newString(`"""`, `"""`, ``),
 			newCStyleString(),
 			newSingleComment("%"),
 		},
@@ -60,25 +79,33 @@ var langs = []*Language{
 		DisplayName: "Go",
 		ID:          "go",
 		Exts:        []string{".go"},
-		blocks: []blocker{
+		blocks: append([]Blocker{
 			newCStyleString(),
 			newString("`", "`", ""),
 			newCStyleChar(),
-			newCStyleSingleComment(),
-			newCStyleMultipleComment(),
-		},
+		}, CStyleBlockers("//")...),
 	},
 
 	{
 		DisplayName: "Groovy",
 		ID:          "groovy",
-		Exts:        []string{".groovy"},
-		blocks: []blocker{
+		Exts:        []string{".groovy", ".gradle"},
+		blocks: append([]Blocker{
+			newString(`"""`, `"""`, `\`), // 需要在 " 之前定义
+			newString("'''", "'''", `\`), // 需要在 ' 之前定义
 			newCStyleString(),
 			newString("'", "'", `\`),
-			newString("'''", "'''", `\`),
-			newCStyleSingleComment(),
-			newCStyleMultipleComment(),
+		}, CStyleBlockers("//")...),
+	},
+
+	{
+		DisplayName: "Haskell",
+		ID:          "haskell",
+		Exts:        []string{".hs"},
+		blocks: []Blocker{
+			newCStyleString(),
+			newSwiftNestMCommentBlock("{-", "-}", ""), // 允许嵌套
+			newSingleComment("--"),
 		},
 	},
 
@@ -93,22 +120,21 @@ var langs = []*Language{
 		DisplayName: "JavaScript",
 		ID:          "javascript",
 		Exts:        []string{".js"},
-		blocks: []blocker{
+		blocks: append([]Blocker{
 			newCStyleString(),
 			newString("'", "'", `\`),
 			newString("`", "`", `\`),
-			newCStyleSingleComment(),
-			newCStyleMultipleComment(),
+		}, append(CStyleBlockers("//"),
 			// NOTE: js 中若出现 /*abc/.test() 应该是先优先注释的。放最后，优先匹配 // 和 /*
 			newString("/", "/", `\`),
-		},
+		)...),
 	},
 
 	{
 		DisplayName: "Julia",
 		ID:          "julia",
 		Exts:        []string{".jl"},
-		blocks: []blocker{
+		blocks: []Blocker{
 			newString("'", "'", `\`),
 			newString(`r"`, `"`, ""),
 			newString(`b"`, `"`, ""),
@@ -134,7 +160,7 @@ var langs = []*Language{
 		// .ss,.scm ==> scheme
 		// .clj     ==> Clojure
 		Exts: []string{".lisp", ".lsp", ".l", ".ss", ".scm", ".clj"},
-		blocks: []blocker{
+		blocks: []Blocker{
 			newString(`"`, `"`, `\`), // #"" 为正则表达式
 			newSingleComment(";;;;"),
 			newSingleComment(";;;"),
@@ -147,12 +173,11 @@ var langs = []*Language{
 		DisplayName: "Lua",
 		ID:          "lua",
 		Exts:        []string{".lua"},
-		blocks: []blocker{
+		blocks: []Blocker{
 			newString("'", "'", `\`),
 			newString("\"", "\"", `\`),
 			newString("[[", "]]", ``),
-			newMultipleComment("--[====[", "]====]", "-="),
-			newMultipleComment("--[[", "]]", "-="),
+			newLuaMultipleComment("-="),
 			newSingleComment("--"), // 放在 --[[ 之后，否则会把 --[[ 当作 -- 解析
 		},
 	},
@@ -161,7 +186,7 @@ var langs = []*Language{
 		DisplayName: "Nim",
 		ID:          "nim",
 		Exts:        []string{".nim"},
-		blocks: []blocker{
+		blocks: []Blocker{
 			newString("'", "'", "\\"),
 			newString(`"`, `"`, "\\"),
 			newNimRawString(),
@@ -176,7 +201,7 @@ var langs = []*Language{
 		DisplayName: "Pascal/Delphi",
 		ID:          "pascal",
 		Exts:        []string{".pas", ".pp"},
-		blocks: []blocker{
+		blocks: []Blocker{
 			newPascalStringBlock('\''),
 			newPascalStringBlock('"'),
 			newMultipleComment("{", "}", ""),
@@ -187,12 +212,12 @@ var langs = []*Language{
 	{
 		DisplayName: "Perl",
 		ID:          "perl",
-		Exts:        []string{".perl", ".prl", ".pl"},
-		blocks: []blocker{
+		Exts:        []string{".perl", ".prl", ".pl", ".pm"},
+		blocks: []Blocker{
 			newCStyleString(),
 			newString("'", "'", `\`),
 			newSingleComment("#"),
-			newRubyMultipleComment("=pod", "=cut", ""),
+			newPerlPOD(),
 		},
 	},
 
@@ -200,25 +225,33 @@ var langs = []*Language{
 		DisplayName: "PHP",
 		ID:          "php",
 		Exts:        []string{".php"},
-		blocks: []blocker{
+		blocks: append([]Blocker{
 			newCStyleString(),
 			newString("'", "'", `\`),
 			newPHPDocBlock(),
-			newCStyleSingleComment(),
-			newSingleComment("#"),
-			newCStyleMultipleComment(),
-		},
+		}, append(CStyleBlockers("//"), newSingleComment("#"))...),
 	},
 
 	{
 		DisplayName: "Python",
 		ID:          "python",
 		Exts:        []string{".py"},
-		blocks: []blocker{
-			newMultipleComment(`"""`, `"""`, ""),
-			newMultipleComment("'''", "'''", ""),
+		blocks:      newPythonBlocks(python3DocPrefix),
+		versions: map[string][]Blocker{
+			"2": newPythonBlocks(python2DocPrefix),
+			"3": newPythonBlocks(python3DocPrefix),
+		},
+	},
+
+	{
+		DisplayName: "R",
+		ID:          "r",
+		Exts:        []string{".r"}, // 比较时不区分大小写，同时匹配 .R
+		blocks: []Blocker{
 			newCStyleString(),
-			newSingleComment(`#`),
+			newString("'", "'", `\`),
+			newSingleComment("#'"), // roxygen2，需要在 # 之前定义
+			newSingleComment("#"),
 		},
 	},
 
@@ -226,7 +259,7 @@ var langs = []*Language{
 		DisplayName: "Ruby",
 		ID:          "ruby",
 		Exts:        []string{".rb"},
-		blocks: []blocker{
+		blocks: []Blocker{
 			newCStyleString(),
 			newString("'", "'", `\`),
 			newSingleComment(`#`),
@@ -244,15 +277,25 @@ var langs = []*Language{
 	{
 		DisplayName: "Scala",
 		ID:          "scala",
-		Exts:        []string{".scala"},
-		blocks:      cStyle,
+		Exts:        []string{".scala", ".sc"}, // .sc 为脚本文件
+		blocks:      cStyle,                    // /** */ 中每一行开头的 * 会被去掉
+	},
+
+	{
+		DisplayName: "SQL",
+		ID:          "sql",
+		Exts:        []string{".sql"},
+		blocks: append([]Blocker{
+			newPascalStringBlock('\''),
+			newPascalStringBlock('"'), // 标识符
+		}, CStyleBlockers("--")...),
 	},
 
 	{
 		DisplayName: "Swift",
 		ID:          "swift",
 		Exts:        []string{".swift"},
-		blocks: []blocker{
+		blocks: []Blocker{
 			newString(`"""`, `"""`, `\`),
 			newString(`#"`, `"#`, ""),
 			newCStyleString(),
@@ -266,22 +309,21 @@ var langs = []*Language{
 		DisplayName: "TypeScript",
 		ID:          "typescript",
 		Exts:        []string{".ts"},
-		blocks: []blocker{
+		blocks: append([]Blocker{
 			newCStyleString(),
 			newString("'", "'", `\`),
 			newString("`", "`", `\`),
-			newCStyleSingleComment(),
-			newCStyleMultipleComment(),
+		}, append(CStyleBlockers("//"),
 			// NOTE: js 中若出现 /*abc/.test() 应该是先优先注释的。放最后，优先匹配 // 和 /*
 			newString("/", "/", `\`),
-		},
+		)...),
 	},
 
 	{
 		DisplayName: "Zig",
 		ID:          "zig",
 		Exts:        []string{".zig"},
-		blocks: []blocker{
+		blocks: []Blocker{
 			newCStyleString(),
 			newCStyleChar(),
 			newSingleComment("///"), // 需要在 // 之前定义
@@ -291,31 +333,37 @@ var langs = []*Language{
 	},
 }
 
-var cStyle = []blocker{
+var cStyle = append([]Blocker{
 	newCStyleString(),
 	newCStyleChar(),
 	newSingleComment("///"), // 需要在 // 之前定义
-	newCStyleSingleComment(),
-	newCStyleMultipleComment(),
+}, CStyleBlockers("//")...)
+
+// CStyleBlockers 返回 C 风格的单行注释和 /* */ 多行注释的解析规则
+//
+// prefix 为单行注释的起始符号，一般为 //。每次调用都会返回新的切片，
+// 调用方可以直接在其后追加其它规则。
+func CStyleBlockers(prefix string) []Blocker {
+	return []Blocker{newSingleComment(prefix), newCStyleMultipleComment()}
 }
 
 // 处理 "XXX\""
-func newCStyleString() blocker {
+func newCStyleString() Blocker {
 	return newString(`"`, `"`, `\`)
 }
 
 // 处理 '"'
-func newCStyleChar() blocker {
+func newCStyleChar() Blocker {
 	return newString(`'`, `'`, "")
 }
 
 // 处理 // xxx
-func newCStyleSingleComment() blocker {
+func newCStyleSingleComment() Blocker {
 	return newSingleComment(`//`)
 }
 
 // 处理 /* */
-func newCStyleMultipleComment() blocker {
+func newCStyleMultipleComment() Blocker {
 	return newMultipleComment(`/*`, `*/`, "*")
 }
 
@@ -323,32 +371,77 @@ func newCStyleMultipleComment() blocker {
 type Language struct {
 	DisplayName string    // 显示友好的名称
 	ID          string    // 语言唯一名称，一律小写
-	blocks      []blocker // 注释块的解析规则定义
+	blocks      []Blocker // 注释块的解析规则定义
 	Exts        []string  // 扩展名列表，必须以 . 开头且小写
+
+	// 特定版本的注释块解析规则
+	//
+	// 部分语言在不同版本之间的语法有所变化，键名为版本号，
+	// 未指定版本时采用 blocks 中的规则。
+	versions map[string][]Blocker
 }
 
 // Get 获取指定语言的定义信息
 //
-// 若不存在，则返回 nil
-func Get(id string) *Language {
+// version 为可选的版本号，指定之后返回的对象采用该版本的解析规则，
+// 空值表示采用默认的规则。若语言或是版本不存在，则返回 nil
+func Get(id string, version ...string) *Language {
+	var ver string
+	switch len(version) {
+	case 0:
+	case 1:
+		ver = version[0]
+	default:
+		panic("参数 version 最多只能指定一个")
+	}
+
 	for _, lang := range langs {
-		if lang.ID == id {
+		if lang.ID != id {
+			continue
+		}
+
+		if ver == "" {
 			return lang
 		}
+
+		blocks, found := lang.versions[ver]
+		if !found {
+			return nil
+		}
+		l := *lang
+		l.blocks = blocks
+		return &l
 	}
 
 	return nil
 }
 
+// Versions 返回该语言所有注册了特定解析规则的版本号
+//
+// 按字母顺序排列，如果没有，则返回空值。
+func (l *Language) Versions() []string {
+	if len(l.versions) == 0 {
+		return nil
+	}
+
+	vers := make([]string, 0, len(l.versions))
+	for v := range l.versions {
+		vers = append(vers, v)
+	}
+	sort.Strings(vers)
+	return vers
+}
+
 // GetByExt 根据扩展名获取语言定义信息
 //
-// ext 必须以 . 作为开头
+// ext 必须以 . 作为开头，不区分大小写；
 // 若不存在，则返回 nil
 func GetByExt(ext string) *Language {
 	if len(ext) == 0 || ext[0] != '.' {
 		panic(fmt.Sprintf("参数 ext 的值 [%s] 不能为空，且必须以 . 作为开头", ext))
 	}
 
+	ext = strings.ToLower(ext)
 	for _, lang := range langs {
 		for _, e := range lang.Exts {
 			if e == ext {
@@ -53,6 +53,22 @@ func TestGet(t *testing.T) {
 	// 不比较大小写
 	l = Get("Go")
 	a.Nil(l)
+
+	// 指定版本
+	py := Get("python")
+	a.NotNil(py).Equal(py.Versions(), []string{"2", "3"})
+	a.Equal(Get("python", ""), py)
+	py2 := Get("python", "2")
+	a.NotNil(py2).
+		Equal(py2.ID, "python").
+		Equal(py2.Exts, py.Exts).
+		NotEqual(py2.blocks, py.blocks)
+	a.Nil(Get("python", "4"))
+	a.Nil(Get("go", "1"))
+	a.Nil(Get("go").Versions())
+	a.Panic(func() {
+		Get("python", "2", "3")
+	})
 }
 
 func TestGetByExt(t *testing.T) {
@@ -64,6 +80,10 @@ func TestGetByExt(t *testing.T) {
 	l = GetByExt(".cxx")
 	a.NotNil(l).Equal(l.ID, "c++")
 
+	// 不区分大小写
+	l = GetByExt(".R")
+	a.NotNil(l).Equal(l.ID, "r")
+
 	// 不存在
 	l = GetByExt(".not-exists")
 	a.Nil(l)
@@ -76,3 +96,18 @@ func TestGetByExt(t *testing.T) {
 		GetByExt("go")
 	})
 }
+
+func TestCStyleBlockers(t *testing.T) {
+	a := assert.New(t, false)
+
+	blocks := CStyleBlockers("--")
+	a.Length(blocks, 2)
+	single, ok := blocks[0].(*singleComment)
+	a.True(ok).Equal(single.begin, "--")
+	multiple, ok := blocks[1].(*multipleComment)
+	a.True(ok).Equal(multiple.begin, "/*").Equal(multiple.end, "*/")
+
+	// 每次返回的都是新的切片
+	blocks[0] = nil
+	a.NotNil(CStyleBlockers("--")[0])
+}
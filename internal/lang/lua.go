@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"regexp"
+	"strings"
+)
+
+var luaMultipleCommentBegin = regexp.MustCompile(`^--\[=*\[`)
+
+// lua 的多行注释
+//
+// 起始符号为 --[[ 或是在两个 [ 之间添加任意数量 = 的长括号形式，比如 --[==[，
+// 结束符号需要包含相同数量的 =，比如 ]==]。与 ruby 的多行注释不同，
+// 起始和结束符号可以出现在行中的任意位置。
+type luaMultipleComment struct {
+	prefix []byte
+}
+
+func newLuaMultipleComment(prefix string) Blocker {
+	return &luaMultipleComment{prefix: []byte(prefix)}
+}
+
+func (b *luaMultipleComment) beginFunc(l *parser) bool {
+	return l.MatchRegexp(luaMultipleCommentBegin)
+}
+
+// 由于 blocker 会被多个文件共用，不能在 beginFunc 中保存括号的层级，
+// 而是从当前位置往前查找刚匹配的起始符号，以确定相应的结束符号。
+func (b *luaMultipleComment) endFunc(l *parser) (data []byte, ok bool) {
+	start := l.Current().Offset - 1 // 最后一个 [
+	level := 0
+	for l.Data[start-1-level] == '=' {
+		level++
+	}
+	begins := l.Bytes(start-level-3, start+1) // --[ 加上 = 和最后一个 [
+	end := "]" + strings.Repeat("=", level) + "]"
+
+	data, found := l.DelimString(end, true)
+	if !found { // 没有找到结束符号，直接到达文件末尾
+		return nil, false
+	}
+
+	raw := make([]byte, 0, len(begins)+len(data))
+	raw = append(append(raw, begins...), data...)
+	return convertMultipleCommentToXML(raw, begins, []byte(end), b.prefix), true
+}
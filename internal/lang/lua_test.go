@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+)
+
+func TestLuaMultipleComment(t *testing.T) {
+	a := assert.New(t, false)
+	b := newLuaMultipleComment("-=")
+
+	newLuaParser := func(data string) *parser {
+		rslt := messagetest.NewMessageHandler()
+		l := newParser(rslt.Handler, core.Block{Data: []byte(data)}, nil)
+		rslt.Handler.Stop()
+		a.Empty(rslt.Errors).NotNil(l)
+		return l
+	}
+
+	l := newLuaParser("--[[comment1]]")
+	a.True(b.beginFunc(l))
+	data, found := b.endFunc(l)
+	a.True(found).
+		Equal(string(data), "    comment1  ").
+		True(l.AtEOF())
+
+	// 带 = 的长括号，内容中可以包含 ]]
+	l = newLuaParser("--[==[\n = t[a[1]]\n]=]\n]==]x")
+	a.True(b.beginFunc(l))
+	data, found = b.endFunc(l)
+	a.True(found).
+		Equal(string(data), "      \n   t[a[1]]\n]=]\n    ").
+		Equal(string(l.All()), "x")
+
+	// 不在行首
+	l = newLuaParser("local x = 1 --[=[comment]=]")
+	a.False(b.beginFunc(l))
+	l.Next(len("local x = 1 "))
+	a.True(b.beginFunc(l))
+	data, found = b.endFunc(l)
+	a.True(found).
+		Equal(string(data), "     comment   ")
+
+	// 结束符号的层级不匹配
+	l = newLuaParser("--[=[comment]]")
+	a.True(b.beginFunc(l))
+	data, found = b.endFunc(l)
+	a.False(found).Nil(data)
+
+	// 并非多行注释
+	a.False(b.beginFunc(newLuaParser("--[comment]")))
+	a.False(b.beginFunc(newLuaParser("--[=comment]=]")))
+	a.False(b.beginFunc(newLuaParser("-- [[comment]]")))
+}
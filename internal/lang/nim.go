@@ -10,7 +10,7 @@ type nimRawString struct {
 
 type nimMultipleString struct{}
 
-func newNimRawString() blocker {
+func newNimRawString() Blocker {
 	return &nimRawString{
 		escape: `""`,
 		begin1: `r"`,
@@ -38,7 +38,7 @@ func (s *nimRawString) endFunc(l *parser) (data []byte, ok bool) {
 	} // end for
 }
 
-func newNimMultipleString() blocker {
+func newNimMultipleString() Blocker {
 	return &nimMultipleString{}
 }
 
@@ -3,6 +3,7 @@
 package lang
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/caixw/apidoc/v7/core"
@@ -11,24 +12,36 @@ import (
 )
 
 // Parse 分析 data 的内容并输出到到 blocks
-func Parse(h *core.MessageHandler, langID string, data core.Block, blocks chan core.Block) {
-	l := Get(langID)
+//
+// langVersion 为语言的版本号，空值表示采用默认的解析规则。
+func Parse(h *core.MessageHandler, langID, langVersion string, data core.Block, blocks chan core.Block) {
+	ParseContext(context.Background(), h, langID, langVersion, data, blocks)
+}
+
+// ParseContext 分析 data 的内容并输出到到 blocks
+//
+// 查找和分析代码块的过程中都会检测 ctx 是否已经结束，若已结束，则放弃后续内容，
+// 并向 h 发送一条警告信息，已经分析完成的代码块依然有效。
+// 可用于防止格式错误的内容导致分析过程长时间无法结束。
+func ParseContext(ctx context.Context, h *core.MessageHandler, langID, langVersion string, data core.Block, blocks chan core.Block) {
+	l := Get(langID, langVersion)
 	if l == nil {
-		panic(fmt.Sprintf("%s 指定的语言解析器并不存在", langID))
+		panic(fmt.Sprintf("%s %s 指定的语言解析器并不存在", langID, langVersion))
 	}
 
 	if p := newParser(h, data, l.blocks); p != nil {
-		p.parse(blocks)
+		p.parse(ctx, blocks)
 	}
 }
 
 type parser struct {
 	*lexer.Lexer
-	blocks []blocker
+	blocks []Blocker
 	h      *core.MessageHandler
+	done   <-chan struct{} // 由 parse 的 ctx 参数指定，为空表示不会结束。
 }
 
-func newParser(h *core.MessageHandler, block core.Block, blocks []blocker) *parser {
+func newParser(h *core.MessageHandler, block core.Block, blocks []Blocker) *parser {
 	l, err := lexer.New(block)
 	if err != nil {
 		h.Error(err)
@@ -42,8 +55,25 @@ func newParser(h *core.MessageHandler, block core.Block, blocks []blocker) *pars
 	}
 }
 
+// AtEOF 是否已经到达文件末尾
+//
+// 分析过程被中止时同样返回 true，各个 Blocker 的循环都以此作为结束条件。
+func (l *parser) AtEOF() bool {
+	return l.Lexer.AtEOF() || l.canceled()
+}
+
+// 分析过程是否已经被中止
+func (l *parser) canceled() bool {
+	select {
+	case <-l.done:
+		return true
+	default:
+		return false
+	}
+}
+
 // 从当前位置往后查找，直到找到第一个与 blocks 中某个相匹配的，并返回该 Blocker 。
-func (l *parser) block() (blocker, core.Position) {
+func (l *parser) block() (Blocker, core.Position) {
 	for {
 		if l.AtEOF() {
 			return nil, core.Position{}
@@ -61,22 +91,33 @@ func (l *parser) block() (blocker, core.Position) {
 }
 
 // 分析 l.data 的内容并输出到 blocks
-func (l *parser) parse(blocks chan core.Block) {
-	var block blocker
+func (l *parser) parse(ctx context.Context, blocks chan core.Block) {
+	l.done = ctx.Done()
+
+	var block Blocker
 	var pos core.Position
 	for {
+		if l.canceled() {
+			l.h.Warning(l.currentLocation().NewError(locale.ParseTimeout))
+			return
+		}
+
 		if l.AtEOF() {
 			return
 		}
 
 		if block == nil {
 			if block, pos = l.block(); block == nil { // 没有匹配的 block 了
-				return
+				continue // 可能是因为 ctx 已经结束，交由下一次循环判断。
 			}
 		}
 
 		data, ok := block.endFunc(l)
-		if !ok { // 没有找到结束标签，那肯定是到文件尾了，可以直接返回。
+		if !ok { // 没有找到结束标签，那肯定是到文件尾了，或是 ctx 已经结束。
+			if l.canceled() {
+				continue
+			}
+
 			loc := core.Location{
 				URI: l.Location.URI,
 				Range: core.Range{
@@ -106,3 +147,10 @@ func (l *parser) parse(blocks chan core.Block) {
 		}
 	} // end for
 }
+
+func (l *parser) currentLocation() core.Location {
+	return core.Location{
+		URI:   l.Location.URI,
+		Range: core.Range{Start: l.Current().Position, End: l.Current().Position},
+	}
+}
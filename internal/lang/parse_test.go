@@ -3,18 +3,22 @@
 package lang
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/issue9/assert/v2"
 
 	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/locale"
 )
 
 func TestParser_block(t *testing.T) {
 	a := assert.New(t, false)
 
-	blocks := []blocker{
+	blocks := []Blocker{
 		newCStyleSingleComment(),
 		newCStyleMultipleComment(),
 		newRubyMultipleComment("=pod", "=cut", ""),
@@ -116,7 +120,7 @@ func TestParser_Parse(t *testing.T) {
 	rslt := messagetest.NewMessageHandler()
 	l := newParser(rslt.Handler, b, cStyle)
 	a.NotNil(l)
-	l.parse(blocks)
+	l.parse(context.Background(), blocks)
 	rslt.Handler.Stop()
 	close(blocks)
 	a.Equal(1, len(blocks))
@@ -148,8 +152,209 @@ func TestParser_Parse(t *testing.T) {
 	rslt = messagetest.NewMessageHandler()
 	l = newParser(rslt.Handler, b, cStyle)
 	a.NotNil(l)
-	l.parse(blocks)
+	l.parse(context.Background(), blocks)
 	rslt.Handler.Stop()
 	close(blocks)
 	a.NotEmpty(rslt.Errors)
 }
+
+func TestParse_r(t *testing.T) {
+	a := assert.New(t, false)
+
+	raw := `#' <api method="GET">
+#' <path path="/users" />
+#' </api>
+users <- function() { # comment
+  list()
+}
+`
+	blocks := make(chan core.Block, 100)
+	rslt := messagetest.NewMessageHandler()
+	Parse(rslt.Handler, "r", "", core.Block{Data: []byte(raw)}, blocks)
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).Equal(2, len(blocks))
+
+	blk := <-blocks
+	a.Equal(string(blk.Data), `   <api method="GET">
+   <path path="/users" />
+   </api>
+`).
+		Equal(blk.Location.Range, core.Range{
+			Start: core.Position{Line: 0, Character: 0},
+			End:   core.Position{Line: 3, Character: 0},
+		})
+
+	blk = <-blocks
+	a.Equal(strings.TrimSpace(string(blk.Data)), "comment")
+}
+
+func TestParse_haskell(t *testing.T) {
+	a := assert.New(t, false)
+
+	raw := `{- <api method="GET">
+{- 嵌套的注释 {- 多层 -} -}
+<path path="/users" />
+</api> -}
+users = [] -- comment
+{- 未闭合的注释 {- -}
+`
+	blocks := make(chan core.Block, 100)
+	rslt := messagetest.NewMessageHandler()
+	Parse(rslt.Handler, "haskell", "", core.Block{Data: []byte(raw)}, blocks)
+	rslt.Handler.Stop()
+	close(blocks)
+	// 未闭合的注释会报错，但不影响之前的内容
+	a.Equal(1, len(rslt.Errors)).Equal(2, len(blocks))
+
+	blk := <-blocks
+	a.Equal(string(blk.Data), `   <api method="GET">
+{- 嵌套的注释 {- 多层 -} -}
+<path path="/users" />
+</api>   `)
+
+	blk = <-blocks
+	a.Equal(strings.TrimSpace(string(blk.Data)), "comment")
+}
+
+func TestParse_scala(t *testing.T) {
+	a := assert.New(t, false)
+
+	// Scaladoc 中的 @param 等标记不影响 XML 的内容
+	raw := `/**
+  * <api method="GET">
+  *   <path path="/users" />
+  * </api>
+  *
+  * @param limit 数量
+  * @return 用户列表
+  */
+def users(limit: Int): Seq[User] = ???
+`
+	blocks := make(chan core.Block, 100)
+	rslt := messagetest.NewMessageHandler()
+	Parse(rslt.Handler, "scala", "", core.Block{Data: []byte(raw)}, blocks)
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).Equal(1, len(blocks))
+
+	blk := <-blocks
+	a.Equal(string(blk.Data), `   
+    <api method="GET">
+      <path path="/users" />
+    </api>
+   
+    @param limit 数量
+    @return 用户列表
+    `)
+}
+
+func TestParse_groovy(t *testing.T) {
+	a := assert.New(t, false)
+
+	// 字符串中的注释符号以及注释中的字符串插值都不影响解析
+	raw := `def url = "${base}/users/*"
+def body = """
+5" long // ${name}
+"""
+def text = '''
+it's // ${name}
+'''
+/**
+ * <api method="GET" summary="${summary}">
+ *   <path path="/users" />
+ * </api>
+ */
+task users {
+    doLast { println "done" } // ${ignored}
+}
+`
+	blocks := make(chan core.Block, 100)
+	rslt := messagetest.NewMessageHandler()
+	Parse(rslt.Handler, "groovy", "", core.Block{Data: []byte(raw)}, blocks)
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).Equal(2, len(blocks))
+
+	blk := <-blocks
+	a.Equal(strings.TrimSpace(string(blk.Data)), `<api method="GET" summary="${summary}">
+     <path path="/users" />
+   </api>`)
+
+	blk = <-blocks
+	a.Equal(strings.TrimSpace(string(blk.Data)), "${ignored}")
+}
+
+// 每次调用 endFunc 都会等待一段时间的 blocker
+type slowBlock struct {
+	Blocker
+	delay time.Duration
+}
+
+func (b *slowBlock) endFunc(l *parser) ([]byte, bool) {
+	time.Sleep(b.delay)
+	return b.Blocker.endFunc(l)
+}
+
+// 在 beginFunc 中中止分析过程的 Blocker
+type cancelBlock struct {
+	Blocker
+	cancel context.CancelFunc
+}
+
+func (b *cancelBlock) beginFunc(l *parser) bool {
+	ok := b.Blocker.beginFunc(l)
+	if ok {
+		b.cancel()
+	}
+	return ok
+}
+
+func TestParseContext(t *testing.T) {
+	a := assert.New(t, false)
+
+	raw := strings.Repeat("// <api method=\"GET\"></api>\n\n", 100)
+	b := core.Block{Data: []byte(raw), Location: core.Location{URI: "file:///slow.go"}}
+	slow := []Blocker{&slowBlock{Blocker: newSingleComment("//"), delay: 10 * time.Millisecond}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	blocks := make(chan core.Block, 100)
+	rslt := messagetest.NewMessageHandler()
+	l := newParser(rslt.Handler, b, slow)
+	a.NotNil(l)
+	l.parse(ctx, blocks)
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).
+		Equal(1, len(rslt.Warns)).
+		True(len(blocks) > 0).
+		True(len(blocks) < 100)
+
+	// 已取消的 ctx
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	blocks = make(chan core.Block, 100)
+	rslt = messagetest.NewMessageHandler()
+	ParseContext(ctx, rslt.Handler, "go", "", b, blocks)
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).Equal(1, len(rslt.Warns)).Equal(0, len(blocks))
+
+	// 在查找结束标签的过程中中止
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	b = core.Block{Data: []byte("/* <api method=\"GET\">\n" + strings.Repeat("line\n", 100)), Location: core.Location{URI: "file:///cancel.go"}}
+	blocks = make(chan core.Block, 100)
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, b, []Blocker{&cancelBlock{Blocker: newCStyleMultipleComment(), cancel: cancel}})
+	a.NotNil(l)
+	l.parse(ctx, blocks)
+	rslt.Handler.Stop()
+	close(blocks)
+	a.Empty(rslt.Errors).Equal(1, len(rslt.Warns)).Equal(0, len(blocks)) // 不会被当作未找到结束标签
+
+	pos := core.Position{Line: 0, Character: 2} // /* 之后的位置
+	loc := core.Location{URI: "file:///cancel.go", Range: core.Range{Start: pos, End: pos}}
+	a.Equal(rslt.Warns[0], loc.NewError(locale.ParseTimeout))
+}
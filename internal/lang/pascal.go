@@ -9,7 +9,7 @@ type pascalStringBlock struct {
 	escape string
 }
 
-func newPascalStringBlock(symbol byte) blocker {
+func newPascalStringBlock(symbol byte) Blocker {
 	s := string(symbol)
 	return &pascalStringBlock{
 		symbol: s,
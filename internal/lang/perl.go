@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// 以 = 开头的 POD 指令，比如 =pod、=head1 等。
+var perlPODBegin = regexp.MustCompile(`^=[a-zA-Z]\w*`)
+
+var perlPODEnd = []byte("\n=cut")
+
+// perl 的 POD 文档
+//
+// 以行首的任意 POD 指令开始，直到行首的 =cut 结束，如果没有 =cut，则一直到文件末尾。
+// 所有指令所在的行都会被替换成空格，其余内容作为一个整体的代码块返回。
+type perlPOD struct{}
+
+func newPerlPOD() Blocker {
+	return &perlPOD{}
+}
+
+func (b *perlPOD) beginFunc(l *parser) bool {
+	if l.Current().Character != 0 || matchRubyEndMarker(l) {
+		return false
+	}
+
+	start := l.Current()
+	if !l.MatchRegexp(perlPODBegin) {
+		return false
+	}
+	if string(l.Bytes(start.Offset, l.Current().Offset)) == "=cut" { // 单独的 =cut 不会开始 POD
+		l.Move(start)
+		return false
+	}
+	return true
+}
+
+func (b *perlPOD) endFunc(l *parser) (data []byte, ok bool) {
+	// 起始指令从行首开始且只包含 ASCII 字符，其字节数即为当前的列数。
+	start := l.Current()
+	begins := l.Bytes(start.Offset-start.Character, start.Offset)
+
+	data, found := l.DelimString(string(perlPODEnd), true)
+	if found {
+		line, found := l.Delim('\n', true) // =cut 所在行的剩余内容
+		if !found {
+			line = l.All()
+		}
+		data = append(data, line...)
+	} else {
+		data = l.All()
+	}
+
+	raw := make([]byte, 0, len(begins)+len(data))
+	raw = append(append(raw, begins...), data...)
+
+	for _, line := range bytes.SplitAfter(raw, []byte{'\n'}) { // line 与 raw 共用底层数据
+		if len(line) > 0 && line[0] == '=' {
+			for i := range line {
+				if line[i] != '\n' {
+					line[i] = ' '
+				}
+			}
+		}
+	}
+	return raw, true
+}
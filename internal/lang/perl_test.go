@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+)
+
+func TestPerlPOD(t *testing.T) {
+	a := assert.New(t, false)
+	b := newPerlPOD()
+
+	newPerlParser := func(data string) *parser {
+		rslt := messagetest.NewMessageHandler()
+		l := newParser(rslt.Handler, core.Block{Data: []byte(data)}, nil)
+		rslt.Handler.Stop()
+		a.Empty(rslt.Errors).NotNil(l)
+		return l
+	}
+
+	l := newPerlParser("=pod\ncomment1\n=cut\nmy $x;")
+	a.True(b.beginFunc(l))
+	data, found := b.endFunc(l)
+	a.True(found).
+		Equal(string(data), "    \ncomment1\n    \n").
+		Equal(string(l.All()), "my $x;")
+
+	// 多个段落，所有指令都会被替换成空格
+	l = newPerlParser("=head1 API\n\n<api>\n\n=head2 users\n\n</api>\n\n=cut trailing\n")
+	a.True(b.beginFunc(l))
+	data, found = b.endFunc(l)
+	a.True(found).
+		Equal(string(data), "          \n\n<api>\n\n            \n\n</api>\n\n             \n").
+		True(l.AtEOF())
+
+	// 没有 =cut，直到文件末尾
+	l = newPerlParser("=head1 NAME\ncomment1\n=item x")
+	a.True(b.beginFunc(l))
+	data, found = b.endFunc(l)
+	a.True(found).
+		Equal(string(data), "           \ncomment1\n       ").
+		True(l.AtEOF())
+
+	// 不在行首
+	l = newPerlParser(" =pod\ncomment1\n=cut\n")
+	a.False(b.beginFunc(l))
+
+	// 单独的 =cut 以及非指令的内容
+	a.False(b.beginFunc(newPerlParser("=cut\n")))
+	a.False(b.beginFunc(newPerlParser("= pod\n")))
+	a.False(b.beginFunc(newPerlParser("=1\n")))
+
+	// __END__ 之后的内容不再解析
+	l = newPerlParser("__END__\n=pod\ncomment1\n=cut\n")
+	a.False(b.beginFunc(l)).True(l.AtEOF())
+}
@@ -16,7 +16,7 @@ type phpDocBlock struct {
 // herodoc 和 nowdoc 的实现。
 //
 // http://php.net/manual/zh/language.types.string.php#language.types.string.syntax.heredoc
-func newPHPDocBlock() blocker {
+func newPHPDocBlock() Blocker {
 	return &phpDocBlock{
 		doctype: phpHerodoc,
 	}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import "regexp"
+
+// python 的文档字符串
+//
+// 只有起始的 """ 或是 ''' 为所在行的第一个非空白字符时才会被当作文档，
+// 比如函数体的第一行，否则只是普通的多行字符串，比如 x = """...""".
+type pythonDocString struct {
+	begin *regexp.Regexp
+	end   string
+	ends  []byte
+}
+
+// python 文档字符串允许的前缀
+//
+// python 2 允许 u、r 以及 ur 的组合；python 3 则不再支持 ur。
+const (
+	python2DocPrefix = `(?:[uU]?[rR]?)`
+	python3DocPrefix = `[rRuU]?`
+)
+
+// 生成 python 的注释块解析规则，prefix 为文档字符串允许的前缀。
+func newPythonBlocks(prefix string) []Blocker {
+	return []Blocker{
+		newPythonDocString(prefix, `"""`), // 需要在 """ 字符串之前定义
+		newPythonDocString(prefix, "'''"),
+		newString(`"""`, `"""`, `\`),
+		newString("'''", "'''", `\`),
+		newCStyleString(),
+		newString("'", "'", `\`),
+		newSingleComment(`#`),
+	}
+}
+
+func newPythonDocString(prefix, delim string) Blocker {
+	return &pythonDocString{
+		begin: regexp.MustCompile(`^[ \t]*` + prefix + delim),
+		end:   delim,
+		ends:  []byte(delim),
+	}
+}
+
+func (b *pythonDocString) beginFunc(l *parser) bool {
+	return l.Current().Character == 0 && l.MatchRegexp(b.begin)
+}
+
+func (b *pythonDocString) endFunc(l *parser) (data []byte, ok bool) {
+	// 起始符号从行首开始且只包含 ASCII 字符，其字节数即为当前的列数。
+	start := l.Current()
+	begins := l.Bytes(start.Offset-start.Character, start.Offset)
+
+	data, found := l.DelimString(b.end, true)
+	if !found { // 没有找到结束符号，直接到达文件末尾
+		return nil, false
+	}
+
+	raw := make([]byte, 0, len(begins)+len(data))
+	raw = append(append(raw, begins...), data...)
+	return convertMultipleCommentToXML(raw, begins, b.ends, nil), true
+}
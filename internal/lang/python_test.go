@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+
+package lang
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+)
+
+func TestPythonDocString(t *testing.T) {
+	a := assert.New(t, false)
+	b := newPythonDocString(python3DocPrefix, `"""`)
+
+	rslt := messagetest.NewMessageHandler()
+	l := newParser(rslt.Handler, core.Block{Data: []byte("\t  \"\"\"comment1\n  \"\"\"")}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.True(b.beginFunc(l))
+	data, found := b.endFunc(l)
+	a.True(found).
+		Equal(string(data), "      comment1\n     ")
+
+	// 带前缀
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, core.Block{Data: []byte(`r"""comment1"""`)}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.True(b.beginFunc(l))
+	data, found = b.endFunc(l)
+	a.True(found).
+		Equal(string(data), "    comment1   ")
+
+	// 不是行首的第一个非空白字符
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, core.Block{Data: []byte(`x = """comment1"""`)}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.False(b.beginFunc(l))
+	l.Next(4)
+	a.False(b.beginFunc(l))
+
+	// 没有结束符
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, core.Block{Data: []byte(`"""comment1`)}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.True(b.beginFunc(l))
+	data, found = b.endFunc(l)
+	a.False(found).Nil(data)
+}
+
+func TestParse_pythonVersion(t *testing.T) {
+	a := assert.New(t, false)
+
+	// python 2 中允许 ur 前缀的文档字符串
+	data := []byte("def f():\n    ur\"\"\"<api method=\"GET\" />\"\"\"\n")
+	parse := func(version string) []core.Block {
+		rslt := messagetest.NewMessageHandler()
+		blocks := make(chan core.Block, 10)
+		Parse(rslt.Handler, "python", version, core.Block{Data: data}, blocks)
+		close(blocks)
+		rslt.Handler.Stop()
+		a.Empty(rslt.Errors)
+
+		ret := make([]core.Block, 0, 1)
+		for b := range blocks {
+			ret = append(ret, b)
+		}
+		return ret
+	}
+
+	blocks := parse("2")
+	a.Equal(1, len(blocks)).
+		Contains(string(blocks[0].Data), `<api method="GET" />`)
+
+	// python 3 中 ur 不是合法的前缀，只当作普通的字符串处理
+	a.Empty(parse("3"))
+	a.Empty(parse(""))
+}
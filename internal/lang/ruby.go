@@ -2,13 +2,18 @@
 
 package lang
 
+import "bytes"
+
+// 单独一行的 __END__ 表示代码的结束，之后的内容均不再解析。
+var rubyEndMarker = []byte("__END__")
+
 // 表示超始和结束符号必须占满一行的情况
 type rubyMultipleComment struct {
 	begin, end           string
 	begins, ends, prefix []byte
 }
 
-func newRubyMultipleComment(begin, end, prefix string) blocker {
+func newRubyMultipleComment(begin, end, prefix string) Blocker {
 	begin += "\n"
 	end += "\n"
 	return &rubyMultipleComment{
@@ -21,14 +26,41 @@ func newRubyMultipleComment(begin, end, prefix string) blocker {
 }
 
 func (b *rubyMultipleComment) beginFunc(l *parser) bool {
-	return l.Current().Character == 0 && l.Match(b.begin)
+	if l.Current().Character != 0 || matchRubyEndMarker(l) {
+		return false
+	}
+
+	return l.Match(b.begin)
+}
+
+// 当前位置是否为单独一行的 __END__
+//
+// 如果是，会将 l 直接移至文件末尾，否则不作任何操作。
+func matchRubyEndMarker(l *parser) bool {
+	start := l.Current()
+	if !l.Match(string(rubyEndMarker)) {
+		return false
+	}
+
+	line, found := l.Delim('\n', true)
+	if !found {
+		line = l.All()
+	}
+	if len(bytes.TrimSpace(line)) == 0 {
+		l.All() // 直接跳至文件末尾
+		return true
+	}
+	l.Move(start)
+	return false
 }
 
 // 从 l 的当前位置一直到定义的 b.End 之间的所有字符。
 // 会对每一行应用 filterSymbols 规则。
+//
+// 如果在结束符号之前遇到 __END__，则同样视为未找到结束符号。
 func (b *rubyMultipleComment) endFunc(l *parser) (data []byte, ok bool) {
 	data, found := l.DelimString(b.end, true)
-	if !found { // 没有找到结束符号，直接到达文件末尾
+	if !found || hasRubyEndMarker(data) { // 没有找到结束符号，直接到达文件末尾
 		return nil, false
 	}
 
@@ -36,3 +68,22 @@ func (b *rubyMultipleComment) endFunc(l *parser) (data []byte, ok bool) {
 	raw = append(append(raw, b.begins...), data...)
 	return convertMultipleCommentToXML(raw, b.begins, b.ends, b.prefix), true
 }
+
+// data 中是否包含单独一行的 __END__
+//
+// data 的第一个字符必须是行首。
+func hasRubyEndMarker(data []byte) bool {
+	for len(data) > 0 {
+		var line []byte
+		if index := bytes.IndexByte(data, '\n'); index >= 0 {
+			line, data = data[:index], data[index+1:]
+		} else {
+			line, data = data, nil
+		}
+
+		if bytes.Equal(bytes.TrimRight(line, " \t\r"), rubyEndMarker) {
+			return true
+		}
+	}
+	return false
+}
@@ -53,3 +53,49 @@ func TestRubyMultipleComment(t *testing.T) {
 	data, found = b.endFunc(l)
 	a.False(found).Nil(data)
 }
+
+func TestRubyMultipleComment_endMarker(t *testing.T) {
+	a := assert.New(t, false)
+	b := newRubyMultipleComment("=begin", "=end", "")
+
+	// __END__ 之后的内容不再解析
+	rslt := messagetest.NewMessageHandler()
+	l := newParser(rslt.Handler, core.Block{Data: []byte("__END__\n=begin\ncomment1\n=end\n")}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.False(b.beginFunc(l)).True(l.AtEOF())
+
+	// 文件末尾的 __END__
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, core.Block{Data: []byte("__END__ ")}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.False(b.beginFunc(l)).True(l.AtEOF())
+
+	// 非单独一行的 __END__
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, core.Block{Data: []byte("__END__x\n=begin\n")}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.False(b.beginFunc(l)).
+		False(l.AtEOF()).
+		Equal(l.Current().Offset, 0)
+
+	// 注释中的 __END__，视为未找到结束符号
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, core.Block{Data: []byte("=begin\ncomment1\n__END__\n=end\n")}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.True(b.beginFunc(l))
+	data, found := b.endFunc(l)
+	a.False(found).Nil(data)
+
+	// 注释内容中包含 __END__ 字符串
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, core.Block{Data: []byte("=begin\ncomment1 __END__\n=end\n")}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.True(b.beginFunc(l))
+	data, found = b.endFunc(l)
+	a.True(found).Equal(string(data), "       comment1 __END__\n     ")
+}
@@ -9,7 +9,6 @@ type swiftNestMCommentBlock struct {
 	prefix []byte // 需要过滤的前缀
 	begins []byte
 	ends   []byte
-	level  int8
 }
 
 // prefix 表示每一行的前缀符号，比如：
@@ -17,7 +16,7 @@ type swiftNestMCommentBlock struct {
 //   *
 //   */
 // 中的 * 字符
-func newSwiftNestMCommentBlock(begin, end, prefix string) blocker {
+func newSwiftNestMCommentBlock(begin, end, prefix string) Blocker {
 	return &swiftNestMCommentBlock{
 		begin:  begin,
 		end:    end,
@@ -28,17 +27,15 @@ func newSwiftNestMCommentBlock(begin, end, prefix string) blocker {
 }
 
 func (b *swiftNestMCommentBlock) beginFunc(l *parser) bool {
-	if l.Match(b.begin) {
-		b.level++
-		return true
-	}
-
-	return false
+	return l.Match(b.begin)
 }
 
 func (b *swiftNestMCommentBlock) endFunc(l *parser) (data []byte, ok bool) {
 	data = append(make([]byte, 0, 200), b.begins...)
 
+	// 嵌套的层级，由局部变量保存，blocker 会被多个文件共用。
+	level := 1
+
 LOOP:
 	for {
 		switch {
@@ -46,13 +43,13 @@ LOOP:
 			return nil, false
 		case l.Match(b.end):
 			data = append(data, b.ends...)
-			b.level--
-			if b.level == 0 {
+			level--
+			if level == 0 {
 				break LOOP
 			}
 		case l.Match(b.begin):
 			data = append(data, b.begins...)
-			b.level++
+			level++
 		default:
 			data = append(data, l.Next(1)...)
 		}
@@ -87,4 +87,15 @@ func TestSwiftNestCommentBlock(t *testing.T) {
 	a.False(ok).
 		Equal(len(data), 0).
 		True(l.AtEOF()) // 到达末尾
+
+	// 上一次未闭合的注释不影响之后的解析
+	rslt = messagetest.NewMessageHandler()
+	l = newParser(rslt.Handler, core.Block{Data: []byte(`/*0*/`)}, nil)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors).NotNil(l)
+	a.True(b.beginFunc(l))
+	data, ok = b.endFunc(l)
+	a.True(ok).
+		Equal(string(data), "  0  ").
+		True(l.AtEOF())
 }
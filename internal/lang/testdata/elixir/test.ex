@@ -0,0 +1,21 @@
+## SPDX-License-Identifier: MIT
+
+defmodule Test do
+  @moduledoc """
+   line1
+  """
+
+  @x "//\""
+  @y '/* xx\' */'
+  @z """
+  @doc \"""
+  """
+
+  @doc """
+
+   line1
+   line2
+   line3
+  """
+  def test(), do: nil
+end
@@ -0,0 +1,5 @@
+# SPDX-License-Identifier: MIT
+
+@doc """
+line1
+"""
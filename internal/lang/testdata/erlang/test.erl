@@ -14,3 +14,14 @@ const c = 'c'
  % line2
  % line3
 %%%
+
+-moduledoc """
+line1
+""".
+
+-doc """
+
+   line1
+   line2
+   line3
+""".
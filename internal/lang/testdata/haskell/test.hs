@@ -0,0 +1,17 @@
+-- SPDX-License-Identifier: MIT
+
+module Test where
+
+x = "--\""
+y = "{- \" -}"
+
+foldl' :: (b -> a -> b) -> b -> [a] -> b
+foldl' f z xs = z
+
+--  line1
+
+{-
+   line1
+   line2
+   line3
+-}
@@ -0,0 +1,12 @@
+-- SPDX-License-Identifier: MIT
+
+local t = {[1] = 1}
+local s = t[t[1]]
+
+--[=[
+ = line1
+ = line2
+ = line3
+]=]
+
+local y = 1 --[[    line1]]
@@ -0,0 +1,15 @@
+#  SPDX-License-Identifier: MIT
+
+package Test;
+
+my $x = "=pod";
+
+sub users {}
+
+=head1 API
+   line1
+   line2
+   line3
+=cut
+
+1;
@@ -0,0 +1,22 @@
+# SPDX-License-Identifier: MIT
+
+x = """
+<api method="GET"><path path="/string" /></api>
+"""
+y = '''/* xx ''' + 'x\'' + "\"\"\""
+
+
+def users():
+    """
+    line1
+    """
+    return x
+
+
+class Users:
+    r'''
+
+   line1
+   line2
+   line3
+'''
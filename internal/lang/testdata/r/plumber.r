@@ -0,0 +1,7 @@
+#' SPDX-License-Identifier: MIT
+
+list(x = "#* @get /users")
+function() {
+  #' line1
+  list()
+}
@@ -0,0 +1,18 @@
+# SPDX-License-Identifier: MIT
+
+puts DATA.read
+
+x = "__END__"
+ __END__
+__END__x
+
+=begin
+   line1
+   line2
+   line3
+=end
+
+__END__
+# <api method="GET"><path path="/data" /></api>
+"unclosed string
+=begin
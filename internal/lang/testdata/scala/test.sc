@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: MIT
+
+val x = "//\""
+
+/// line1
+
+val y = "/**\""
+val c = 'c'
+
+/**
+ * line1
+ * line2
+ * line3
+ */
@@ -0,0 +1,19 @@
+-- SPDX-License-Identifier: MIT
+
+SELECT '--''/*' AS "x--y" FROM t;
+
+---- line1
+
+-- line1
+-- line2
+-- line3
+
+/*
+   line1
+   line2
+   line3
+*/
+CREATE PROCEDURE get_users()
+BEGIN
+    SELECT * FROM users;
+END;
@@ -61,7 +61,7 @@ func parseLang(a *assert.Assertion, id, dir string) {
 		blks := make(chan core.Block, 10)
 		rslt := messagetest.NewMessageHandler()
 
-		Parse(rslt.Handler, id, core.Block{
+		Parse(rslt.Handler, id, "", core.Block{
 			Data:     data,
 			Location: core.Location{URI: core.URI(path)},
 		}, blks)
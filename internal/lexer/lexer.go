@@ -4,6 +4,7 @@
 package lexer
 
 import (
+	"regexp"
 	"unicode"
 	"unicode/utf8"
 
@@ -93,6 +94,44 @@ func (l *Lexer) Match(word string) bool {
 	return true
 }
 
+// MatchRegexp 从当前位置开始是否匹配正则表达式 re
+//
+// 只有从当前位置开始的内容与 re 相匹配才算匹配成功，
+// 若匹配，则将指针移向匹配内容之后，否则不作任何操作。
+// re 应该以 ^ 开头，否则会在之后的所有内容中查找匹配项。
+//
+// NOTE: 可回滚该操作
+func (l *Lexer) MatchRegexp(re *regexp.Regexp) bool {
+	loc := re.FindIndex(l.Data[l.current.Offset:])
+	if loc == nil || loc[0] != 0 || loc[1] == 0 {
+		return false
+	}
+
+	p := l.current
+	end := p.Offset + loc[1]
+	for p.Offset < end {
+		r, size := utf8.DecodeRune(l.Data[p.Offset:])
+		p = p.add(r, size)
+	}
+
+	l.prev = l.current
+	l.current = p
+	return true
+}
+
+// MatchRegexpAt 从偏移量 pos 开始的内容是否匹配正则表达式 re
+//
+// 与 MatchRegexp 不同，不会改变当前的定位信息，可用于检测当前位置之前的内容。
+// pos 超出内容范围时返回 false。
+func (l *Lexer) MatchRegexpAt(re *regexp.Regexp, pos int) bool {
+	if pos < 0 || pos > len(l.Data) {
+		return false
+	}
+
+	loc := re.FindIndex(l.Data[pos:])
+	return loc != nil && loc[0] == 0
+}
+
 // Current 返回当前在 data 中的偏移量
 func (l *Lexer) Current() Position { return l.current }
 
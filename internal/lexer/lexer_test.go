@@ -3,6 +3,7 @@
 package lexer
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/issue9/assert/v2"
@@ -294,3 +295,41 @@ func TestLexer_DelimString(t *testing.T) {
 	val, found = l.DelimString("891", true)
 	a.False(found).Nil(val).Equal(l.Current().Offset, 13)
 }
+
+func TestLexer_MatchRegexp(t *testing.T) {
+	a := assert.New(t, false)
+
+	l, err := New(core.Block{Data: []byte("  中\"\"\"\ncd")})
+	a.NotError(err).NotNil(l)
+
+	a.False(l.MatchRegexp(regexp.MustCompile(`^"""`))).Equal(0, l.current.Offset)
+	a.False(l.MatchRegexp(regexp.MustCompile(`"""`))).Equal(0, l.current.Offset) // 非当前位置
+	a.False(l.MatchRegexp(regexp.MustCompile(`^x*`))).Equal(0, l.current.Offset) // 空匹配
+	a.True(l.MatchRegexp(regexp.MustCompile(`^\s*中"""`))).Equal(l.current, Position{
+		Position: core.Position{Line: 0, Character: 6},
+		Offset:   8,
+	})
+
+	l.Rollback()
+	a.Equal(0, l.current.Offset)
+
+	a.True(l.MatchRegexp(regexp.MustCompile(`^[^c]+`))).Equal(l.current, Position{
+		Position: core.Position{Line: 1, Character: 0},
+		Offset:   9,
+	})
+}
+
+func TestLexer_MatchRegexpAt(t *testing.T) {
+	a := assert.New(t, false)
+
+	l, err := New(core.Block{Data: []byte("ab\n  cd")})
+	a.NotError(err).NotNil(l)
+
+	re := regexp.MustCompile(`^\s*cd`)
+	a.True(l.MatchRegexpAt(re, 3)).
+		True(l.MatchRegexpAt(re, 2)).
+		False(l.MatchRegexpAt(re, 0)).
+		False(l.MatchRegexpAt(re, -1)).
+		False(l.MatchRegexpAt(re, 100)).
+		Equal(0, l.current.Offset) // 不改变定位
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+
+// Package lint 对文档内容进行语法之外的规范性检测
+//
+// 检测结果均以警告的形式输出，不会影响文档的生成。
+package lint
+
+import (
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+)
+
+// Rule 检测规则需要实现的接口
+type Rule interface {
+	// Check 检测 d 的内容，并将不符合规则的内容以警告的形式输出至 h
+	Check(h *core.MessageHandler, d *ast.APIDoc)
+}
+
+// Check 依次使用 rules 检测 d 的内容
+func Check(h *core.MessageHandler, d *ast.APIDoc, rules ...Rule) {
+	for _, r := range rules {
+		r.Check(h, d)
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/locale"
+)
+
+// 不应该出现在路径中的 HTTP 方法名称
+var verbs = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// NamingRule 检测接口路径的命名规范
+//
+// 对路径中除参数之外的每一段内容进行以下检测：
+//  - 只能包含小写字母；
+//  - 不能包含下划线，应该使用连字符代替；
+//  - 不能以 HTTP 方法名称作为名称或是名称的前缀，比如 get-users 和 getUsers；
+type NamingRule struct{}
+
+// Check Rule.Check
+func (r NamingRule) Check(h *core.MessageHandler, d *ast.APIDoc) {
+	for _, api := range d.APIs {
+		if api.Path == nil || api.Path.Path == nil {
+			continue
+		}
+
+		path := api.Path.Path
+		for _, seg := range strings.Split(path.V(), "/") {
+			if seg == "" || seg[0] == '{' { // 路径参数由用户自行命名
+				continue
+			}
+
+			if strings.ToLower(seg) != seg {
+				h.Warning(path.Location.NewError(locale.LintPathNotLowercase, seg).WithField("path"))
+			}
+
+			if strings.ContainsRune(seg, '_') {
+				h.Warning(path.Location.NewError(locale.LintPathUnderscore, seg).WithField("path"))
+			}
+
+			if hasVerb(seg) {
+				h.Warning(path.Location.NewError(locale.LintPathVerb, seg).WithField("path"))
+			}
+		}
+	}
+}
+
+// 判断 seg 是否为 HTTP 方法名称或是以其作为前缀
+func hasVerb(seg string) bool {
+	lower := strings.ToLower(seg)
+	for _, verb := range verbs {
+		if !strings.HasPrefix(lower, verb) {
+			continue
+		}
+
+		if len(seg) == len(verb) {
+			return true
+		}
+
+		next := rune(seg[len(verb)])
+		if next == '-' || next == '_' || next == '.' || unicode.IsUpper(next) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
+)
+
+func loadDoc(a *assert.Assertion, paths ...string) *ast.APIDoc {
+	rslt := messagetest.NewMessageHandler()
+	d := &ast.APIDoc{}
+	d.ParseBlocks(rslt.Handler, func(blocks chan core.Block) {
+		for _, p := range paths {
+			params := ""
+			for _, seg := range strings.Split(p, "/") {
+				if strings.HasPrefix(seg, "{") {
+					params += `<param name="` + strings.Trim(seg, "{}") + `" type="string" summary="param" />`
+				}
+			}
+			blocks <- core.Block{Data: []byte(`<api method="GET"><path path="` + p + `">` + params + `</path></api>`)}
+		}
+	})
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+	return d
+}
+
+func TestNamingRule_Check(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		path  string
+		warns int
+	}{
+		{path: "/users/{id}", warns: 0},
+		{path: "/users/{user_ID}/login-logs", warns: 0},
+		{path: "/", warns: 0},
+		{path: "/optional", warns: 0},
+		{path: "/options-list", warns: 1},
+		{path: "/getaway", warns: 0},
+		{path: "/Users", warns: 1},
+		{path: "/user_logs", warns: 1},
+		{path: "/get", warns: 1},
+		{path: "/users/delete-all", warns: 1},
+		{path: "/getUsers", warns: 2},
+		{path: "/Get_Users/{id}", warns: 3},
+	}
+
+	for _, item := range data {
+		d := loadDoc(a, item.path)
+		rslt := messagetest.NewMessageHandler()
+		Check(rslt.Handler, d, NamingRule{})
+		rslt.Handler.Stop()
+		a.Equal(len(rslt.Warns), item.warns, "%s 的警告数量 %d 与期望值 %d 不同", item.path, len(rslt.Warns), item.warns).
+			Empty(rslt.Errors)
+	}
+}
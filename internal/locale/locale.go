@@ -20,6 +20,9 @@ var (
 	localePrinter = message.NewPrinter(localeTag)
 
 	tags = []language.Tag{}
+
+	// 所有已注册的翻译内容，键名为本地化 ID
+	locales = map[string]map[string]string{}
 )
 
 // Locale 提供缓存本地化信息
@@ -41,6 +44,7 @@ func (err *Err) Error() string {
 
 func setMessages(id string, messages map[string]string) {
 	tag := language.MustParse(id)
+	locales[id] = messages
 
 	for key, val := range messages {
 		if err := message.SetString(tag, key, val); err != nil {
@@ -18,6 +18,32 @@ func TestVarsDefaultLocaleID(t *testing.T) {
 	a.Equal(tags[0].String(), DefaultLocaleID)
 }
 
+// 所有的本地化内容都应该包含与 DefaultLocaleID 相同的键名
+func TestLocaleCompleteness(t *testing.T) {
+	base, found := locales[DefaultLocaleID]
+	if !found {
+		t.Fatalf("未注册 %s 的翻译内容", DefaultLocaleID)
+	}
+
+	for id, messages := range locales {
+		if id == DefaultLocaleID {
+			continue
+		}
+
+		for key := range base {
+			if _, found := messages[key]; !found {
+				t.Errorf("%s 缺少键名 %q 的翻译", id, key)
+			}
+		}
+
+		for key := range messages {
+			if _, found := base[key]; !found {
+				t.Errorf("%s 中的键名 %q 并不存在于 %s", id, key, DefaultLocaleID)
+			}
+		}
+	}
+}
+
 func TestTranslate(t *testing.T) {
 	a := assert.New(t, false)
 	a.Equal(Translate("cmn-hans", ErrInvalidUTF8Character), cmnHans[ErrInvalidUTF8Character])
@@ -40,7 +40,15 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	CmdNotFound    = "子命令 %s 未找到\n"
 
 	FlagSyntaxDirUsage         = "以 `URI` 形式表示测试项目地址"
+	FlagSyntaxWarnAsErrorUsage = "是否将警告视为错误，如果为 true，在存在警告时程序会以状态码 2 退出。"
 	FlagBuildDirUsage          = "以 `URI` 形式表示的项目地址"
+	FlagBuildProfileUsage      = "将 CPU 性能数据写入该文件，可通过 go tool pprof 进行分析"
+	FlagBuildMemProfileUsage   = "将内存分配数据写入该文件，可通过 go tool pprof 进行分析"
+	FlagBuildStatsUsage        = "构建完成之后输出文档的统计信息"
+	FlagBuildMetricsFileUsage  = "构建完成之后将构建的度量数据以 JSON 格式写入该文件，构建出错时也会写入"
+	FlagQuietUsage             = "不输出提示和成功信息，仅输出警告和错误信息"
+	FlagVerboseUsage           = "在结束时额外输出警告信息的数量"
+	FlagLocaleJSONUsage        = "以 JSON 格式输出本地化信息"
 	FlagMockPortUsage          = "指定 mock 服务的端口号"
 	FlagMockServersUsage       = "指定 mock 服务时，文档中 server 变量对应的路由前缀"
 	FlagMockIndentUsage        = "指定缩进内容"
@@ -55,9 +63,13 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	FlagMockURLDomainsUsage    = "生成 URL 地址时所可用的域名列表，多个用半角逗号分隔。"
 	FlagMockImagePrefixUsage   = "生成图片类型数据的基地址"
 	FlagMockDateRangeUsage     = "生成可用的日期范围，格式为 [start,end]，start 和 end 均为 RFC3339 格式。"
+	FlagMockSeedUsage          = "生成随机数据时采用的种子，相同的种子会生成相同的数据，为 0 时采用当前时间。"
+	FlagMockRetryUsage         = "读取远程文档失败时的重试次数，为 0 表示不重试。"
 	FlagDetectRecursiveUsage   = "detect 子命令是否检测子目录的值"
 	FlagDetectDirUsage         = "以 `URI` 形式表示检测项目地址"
 	FlagDetectWrite            = "是否将配置内容写入文件，如果为 true，会将配置内容写入检测目录下的 .apidoc.yaml 文件。"
+	FlagDetectNormalize        = "是否将配置内容转换成规范的格式，比如对 inputs 进行排序，去掉可选字段中的零值等。"
+	FlagDetectFormat           = "配置文件的格式，可以是 yaml 或 json，同时也影响 -w 写入的文件。"
 	FlagStaticPortUsage        = "指定 static 服务的端口号"
 	FlagStaticDocsUsage        = "指定 static 服务静态文件所在的 `URI`"
 	FlagStaticStylesheetUsage  = "指定 static 是否只启用样式文件内容"
@@ -68,22 +80,41 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	FlagLSPModeUsage           = "指定 LSP 的运行方式，可以是 stdio、tcp、unix、ipc 和 udp。"
 	FlagLSPHeaderUsage         = "指定 LSP 传递内容是否带报头信息。"
 	FlagLSPTimeoutUsage        = "指定 LSP 每次读取客户端数据的超时时间，超进不会触发错误，只会再次读取。"
+	FlagLSPMaxConnsUsage       = "指定 LSP 允许的最大连接数量，仅对 tcp 和 unix 有效，0 表示不限制。"
 	FlagVersionKindUsage       = "只显示该类型的版本号，可以是 apidoc、doc、lsp、openapi 和 all"
 
-	VersionInCompatible = "当前程序与配置文件中指定的版本号不兼容"
-	Complete            = "完成！文档保存在：%s，总用时：%v"
-	ConfigWriteSuccess  = "配置内容成功写入 %s"
-	TestSuccess         = "语法没有问题！"
-	LangID              = "ID"
-	LangName            = "名称"
-	LangExts            = "扩展名"
-	LoadAPI             = "加载 API：%s %s"
-	RequestAPI          = "访问 API：%s %s"
-	DeprecatedWarn      = "%s %s 将于 %s 被废弃"
-	GeneratorBy         = "当前文档由 %s 生成"
-	ServerStart         = "服务启动，可通过 %s 访问"
-	UnimplementedRPC    = "未实现该 RPC 服务 %s"
-	PackFileHeader      = "文档由 %s 自动生成，请勿手动修改！"
+	VersionInCompatible  = "当前程序与配置文件中指定的版本号不兼容"
+	Complete             = "完成！文档保存在：%s，总用时：%v"
+	WarnSummary          = "共有 %d 条警告信息"
+	StatsTotalAPIs       = "接口数量"
+	StatsDeprecatedAPIs  = "已弃用的接口数量"
+	StatsTotalTags       = "标签数量"
+	StatsTotalServers    = "服务器数量"
+	StatsRequestTypes    = "请求内容的类型数量"
+	StatsResponseTypes   = "返回内容的类型数量"
+	StatsAPIsPerTag      = "标签 %s 下的接口数量"
+	ConfigWriteSuccess   = "配置内容成功写入 %s"
+	TestSuccess          = "语法没有问题！共检测了 %d 个文件中的 %d 个接口"
+	SyntaxFailed         = "语法检测发现 %d 个错误和 %d 个警告"
+	SyntaxPartial        = "已检测 %d 个文件中的 %d 个接口，存在错误的内容可能未被统计"
+	LangID               = "ID"
+	LangName             = "名称"
+	LangExts             = "扩展名"
+	LoadAPI              = "加载 API：%s %s"
+	RequestAPI           = "访问 API：%s %s"
+	DeprecatedWarn       = "%s %s 将于 %s 被废弃"
+	CommentedAPI         = "被注释的 api 元素，不会出现在文档中"
+	MaxFilesExceeded     = "查找到的文件数量超过了 max-files 的限制 %d，多余的文件将被忽略"
+	MaxFilesHint         = "可以调大配置文件中 inputs.max-files 的值，或是将其设置为 0 以取消限制"
+	ParseTimeout         = "分析超时，剩余的内容已被忽略"
+	LintPathNotLowercase = "路径中的 %s 包含大写字母"
+	LintPathUnderscore   = "路径中的 %s 包含下划线，应该使用连字符代替"
+	LintPathVerb         = "路径中的 %s 包含 HTTP 方法名称"
+	NoCommonBasePath     = "各个服务器地址之间不存在共同的路径"
+	GeneratorBy          = "当前文档由 %s 生成"
+	ServerStart          = "服务启动，可通过 %s 访问"
+	UnimplementedRPC     = "未实现该 RPC 服务 %s"
+	PackFileHeader       = "文档由 %s 自动生成，请勿手动修改！"
 
 	// 文档树中各个字段的介绍
 	UsageAPIDoc              = "usage-apidoc"
@@ -96,6 +127,7 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	UsageAPIDocDescription   = "usage-apidoc-description"
 	UsageAPIDocContact       = "usage-apidoc-contact"
 	UsageAPIDocLicense       = "usage-apidoc-license"
+	UsageAPIDocExternalDocs  = "usage-apidoc-external-docs"
 	UsageAPIDocTags          = "usage-apidoc-tags"
 	UsageAPIDocServers       = "usage-apidoc-servers"
 	UsageAPIDocAPIs          = "usage-apidoc-apis"
@@ -108,20 +140,22 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	UsageXMLNamespacePrefix = "usage-xml-namespace-prefix"
 	UsageXMLNamespaceURN    = "usage-xml-namespace-urn"
 
-	UsageAPI            = "usage-api"
-	UsageAPIVersion     = "usage-api-version"
-	UsageAPIMethod      = "usage-api-method"
-	UsageAPIID          = "usage-api-id"
-	UsageAPIPath        = "usage-api-path"
-	UsageAPISummary     = "usage-api-summary"
-	UsageAPIDescription = "usage-api-description"
-	UsageAPIRequests    = "usage-api-requests"
-	UsageAPIResponses   = "usage-api-responses"
-	UsageAPICallback    = "usage-api-callback"
-	UsageAPIDeprecated  = "usage-api-deprecated"
-	UsageAPIHeaders     = "usage-api-headers"
-	UsageAPITags        = "usage-api-tags"
-	UsageAPIServers     = "usage-api-servers"
+	UsageAPI             = "usage-api"
+	UsageAPIVersion      = "usage-api-version"
+	UsageAPIMethod       = "usage-api-method"
+	UsageAPIID           = "usage-api-id"
+	UsageAPIPath         = "usage-api-path"
+	UsageAPISummary      = "usage-api-summary"
+	UsageAPIDescription  = "usage-api-description"
+	UsageAPIExternalDocs = "usage-api-external-docs"
+	UsageAPIRequests     = "usage-api-requests"
+	UsageAPIResponses    = "usage-api-responses"
+	UsageAPICallback     = "usage-api-callback"
+	UsageAPIDeprecated   = "usage-api-deprecated"
+	UsageAPIHeaders      = "usage-api-headers"
+	UsageAPITags         = "usage-api-tags"
+	UsageAPIServers      = "usage-api-servers"
+	UsageAPIExtensions   = "usage-api-extensions"
 
 	UsageLink     = "usage-link"
 	UsageLinkText = "usage-link-text"
@@ -153,6 +187,10 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	UsageExampleSummary  = "usage-example-summary"
 	UsageExampleContent  = "usage-example-content"
 
+	UsageExtension      = "usage-extension"
+	UsageExtensionName  = "usage-extension-name"
+	UsageExtensionValue = "usage-extension-value"
+
 	UsageParam            = "usage-param"
 	UsageParamName        = "usage-param-name"
 	UsageParamType        = "usage-param-type"
@@ -193,14 +231,22 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	UsageTagName       = "usage-tag-name"
 	UsageTagTitle      = "usage-tag-title"
 	UsageTagDeprecated = "usage-tag-deprecated"
+	UsageTagColor      = "usage-tag-color"
 
-	UsageServer            = "usage-server"
-	UsageServerName        = "usage-server-name"
-	UsageServerTitle       = "usage-server-title"
-	UsageServerURL         = "usage-server-url"
-	UsageServerDeprecated  = "usage-server-deprecated"
-	UsageServerSummary     = "usage-server-summary"
-	UsageServerDescription = "usage-server-description"
+	UsageServer                    = "usage-server"
+	UsageServerName                = "usage-server-name"
+	UsageServerTitle               = "usage-server-title"
+	UsageServerURL                 = "usage-server-url"
+	UsageServerDeprecated          = "usage-server-deprecated"
+	UsageServerSummary             = "usage-server-summary"
+	UsageServerDescription         = "usage-server-description"
+	UsageServerVariables           = "usage-server-variables"
+	UsageServerVariable            = "usage-server-variable"
+	UsageServerVariableName        = "usage-server-variable-name"
+	UsageServerVariableDefault     = "usage-server-variable-default"
+	UsageServerVariableSummary     = "usage-server-variable-summary"
+	UsageServerVariableEnums       = "usage-server-variable-enums"
+	UsageServerVariableDescription = "usage-server-variable-description"
 
 	UsageXMLAttr    = "usage-xml-attr"
 	UsageXMLExtract = "usage-xml-extract"
@@ -217,21 +263,45 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	UsageType    = "usage-type"
 
 	// 以下是有关 build.Config 的字段说明
-	UsageConfigVersion               = "usage-config-version"
-	UsageConfigInputs                = "usage-config-inputs"
-	UsageConfigInputsLang            = "usage-config-inputs.lang"
-	UsageConfigInputsDir             = "usage-config-inputs.dir"
-	UsageConfigInputsExts            = "usage-config-inputs.exts"
-	UsageConfigInputsRecursive       = "usage-config-inputs.recursive"
-	UsageConfigInputsEncoding        = "usage-config-inputs.encoding"
-	UsageConfigInputsIgnores         = "usage-config-inputs.ignores"
-	UsageConfigOutput                = "usage-config-output"
-	UsageConfigOutputType            = "usage-config-output.type"
-	UsageConfigOutputPath            = "usage-config-output.path"
-	UsageConfigOutputTags            = "usage-config-output.tags"
-	UsageConfigOutputStyle           = "usage-config-output.style"
-	UsageConfigOutputNamespace       = "usage-config-output.namespace"
-	UsageConfigOutputNamespacePrefix = "usage-config-output.namespace-prefix"
+	UsageConfigVersion                    = "usage-config-version"
+	UsageConfigInputs                     = "usage-config-inputs"
+	UsageConfigInputsLang                 = "usage-config-inputs.lang"
+	UsageConfigInputsLangVersion          = "usage-config-inputs.lang-version"
+	UsageConfigInputsDir                  = "usage-config-inputs.dir"
+	UsageConfigInputsExts                 = "usage-config-inputs.exts"
+	UsageConfigInputsRecursive            = "usage-config-inputs.recursive"
+	UsageConfigInputsEncoding             = "usage-config-inputs.encoding"
+	UsageConfigInputsIgnores              = "usage-config-inputs.ignores"
+	UsageConfigInputsLangAlias            = "usage-config-inputs.lang-alias"
+	UsageConfigInputsParseFrontMatter     = "usage-config-inputs.parse-front-matter"
+	UsageConfigInputsParseStructTags      = "usage-config-inputs.parse-struct-tags"
+	UsageConfigInputsMaxFiles             = "usage-config-inputs.max-files"
+	UsageConfigInputsTimeout              = "usage-config-inputs.timeout"
+	UsageConfigOutput                     = "usage-config-output"
+	UsageConfigOutputType                 = "usage-config-output.type"
+	UsageConfigOutputPath                 = "usage-config-output.path"
+	UsageConfigOutputPathTemplate         = "usage-config-output.path-template"
+	UsageConfigOutputTags                 = "usage-config-output.tags"
+	UsageConfigOutputSort                 = "usage-config-output.sort"
+	UsageConfigOutputCheckWritable        = "usage-config-output.check-writable"
+	UsageConfigOutputSkipServers          = "usage-config-output.skip-servers"
+	UsageConfigOutputExcludeDeprecated    = "usage-config-output.exclude-deprecated"
+	UsageConfigOutputDeprecatedOnly       = "usage-config-output.deprecated-only"
+	UsageConfigOutputStyle                = "usage-config-output.style"
+	UsageConfigOutputStylesheetVersion    = "usage-config-output.stylesheet-version"
+	UsageConfigOutputNamespace            = "usage-config-output.namespace"
+	UsageConfigOutputNamespacePrefix      = "usage-config-output.namespace-prefix"
+	UsageConfigOutputIndent               = "usage-config-output.indent"
+	UsageConfigOutputExtractBasePath      = "usage-config-output.extract-base-path"
+	UsageConfigOutputGenerateOperationIDs = "usage-config-output.generate-operation-ids"
+	UsageConfigOutputOperationIDStyle     = "usage-config-output.operation-id-style"
+	UsageConfigOutputDeduplicateSchemas   = "usage-config-output.deduplicate-schemas"
+	UsageConfigOutputOpenAPIStrict        = "usage-config-output.openapi-strict"
+	UsageConfigOverrides                  = "usage-config-overrides"
+	UsageConfigLint                       = "usage-config-lint"
+	UsageConfigCreatedAt                  = "usage-config-created-at"
+	UsageConfigUpdatedAt                  = "usage-config-updated-at"
+	UsageConfigLintNamingConventions      = "usage-config-lint.naming-conventions"
 
 	// 错误信息，可能在地方用到
 	ErrInvalidUTF8Character      = "无效的 UTF8 字符"
@@ -247,20 +317,27 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	ErrDirNotExists              = "目录不存在"
 	ErrNotFoundEndFlag           = "找不到结束符号"
 	ErrNotFoundEndTag            = "找不到结束标签"
+	ErrUndeclaredNamespacePrefix = "未声明的命名空间前缀 %s"
 	ErrNotFoundSupportedLang     = "该目录下没有支持的语言文件"
 	ErrNoFiles                   = "目录下没有需要解析的文件"
 	ErrInvalidValue              = "无效的值"
 	ErrInvalidTag                = "无效的标签"
 	ErrPathNotMatchParams        = "地址参数不匹配"
 	ErrDuplicateValue            = "重复的值"
+	ErrSameAs                    = "与 %s 的内容相同"
 	ErrMessage                   = "%s 位于 %s"
 	ErrNotFound                  = "未找到该值"
 	ErrReadRemoteFile            = "读取远程文件 %s 时返回状态码 %d"
+	ErrWriteRemoteFile           = "写入远程文件 %s 时返回状态码 %d"
 	ErrServerNotInitialized      = "服务未初始化"
 	ErrInvalidLSPState           = "无效的 LSP 状态"
 	ErrInvalidURIScheme          = "无效的 URI 协议：%s"
 	ErrInvalidURI                = "无效的 URI：%s"
 	ErrFileNotFound              = "未找到文件 %s"
+	ErrIncompleteDocs            = "内置的文档内容不完整：%s"
+	ErrTooManyConnections        = "连接数量已达上限 %d"
+	ErrUnsupportedLocale         = "不支持的本地化 %s"
+	ErrNotSupportedByOpenAPI     = "%s 无法在 OpenAPI 中表示"
 
 	// logs
 	InfoPrefix    = "[INFO] "
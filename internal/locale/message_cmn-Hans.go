@@ -29,7 +29,15 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	CmdNotFound:    "子命令 %s 未找到\n",
 
 	FlagSyntaxDirUsage:         "以 `URI` 形式表示测试项目地址",
+	FlagSyntaxWarnAsErrorUsage: "是否将警告视为错误，如果为 true，在存在警告时程序会以状态码 2 退出。",
 	FlagBuildDirUsage:          "以 `URI` 形式表示的项目地址",
+	FlagBuildProfileUsage:      "将 CPU 性能数据写入该文件，可通过 go tool pprof 进行分析",
+	FlagBuildMemProfileUsage:   "将内存分配数据写入该文件，可通过 go tool pprof 进行分析",
+	FlagBuildStatsUsage:        "构建完成之后输出文档的统计信息",
+	FlagBuildMetricsFileUsage:  "构建完成之后将构建的度量数据以 JSON 格式写入该文件，构建出错时也会写入",
+	FlagQuietUsage:             "不输出提示和成功信息，仅输出警告和错误信息",
+	FlagVerboseUsage:           "在结束时额外输出警告信息的数量",
+	FlagLocaleJSONUsage:        "以 JSON 格式输出本地化信息",
 	FlagMockPortUsage:          "指定 mock 服务的端口号",
 	FlagMockServersUsage:       "指定 mock 服务时，文档中 server 名对应的路由前缀。",
 	FlagMockIndentUsage:        "指定缩进内容",
@@ -44,9 +52,13 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	FlagMockURLDomainsUsage:    "生成 URL 地址时所可用的域名列表，多个用半角逗号分隔。",
 	FlagMockImagePrefixUsage:   "生成图片类型数据的基地址",
 	FlagMockDateRangeUsage:     "生成可用的日期范围，格式为 [start,end]，start 和 end 均为 RFC3339 格式。",
+	FlagMockSeedUsage:          "生成随机数据时采用的种子，相同的种子会生成相同的数据，为 0 时采用当前时间。",
+	FlagMockRetryUsage:         "读取远程文档失败时的重试次数，为 0 表示不重试。",
 	FlagDetectRecursiveUsage:   "detect 子命令是否检测子目录的值",
 	FlagDetectDirUsage:         "以 `URI` 形式表示检测项目地址",
 	FlagDetectWrite:            "是否将配置内容写入文件，如果为 true，会将配置内容写入检测目录下的 .apidoc.yaml 文件。",
+	FlagDetectNormalize:        "是否将配置内容转换成规范的格式，比如对 inputs 进行排序，去掉可选字段中的零值等。",
+	FlagDetectFormat:           "配置文件的格式，可以是 yaml 或 json，同时也影响 -w 写入的文件。",
 	FlagStaticPortUsage:        "指定 static 服务的端口号",
 	FlagStaticDocsUsage:        "指定 static 服务静态文件所在的 `URI`",
 	FlagStaticStylesheetUsage:  "指定 static 是否只启用样式文件内容",
@@ -57,22 +69,41 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	FlagLSPModeUsage:           "指定 LSP 的运行方式，可以是 stdio、tcp、unix 和 udp。",
 	FlagLSPHeaderUsage:         "指定 LSP 传递内容是否带报头信息",
 	FlagLSPTimeoutUsage:        "指定 LSP 每次读取客户端数据的超时时间，超时不会触发错误，只会再次读取。",
+	FlagLSPMaxConnsUsage:       "指定 LSP 允许的最大连接数量，仅对 tcp 和 unix 有效，0 表示不限制。",
 	FlagVersionKindUsage:       "只显示该类型的版本号，可以是 apidoc、doc、lsp、openapi 和 all",
 
-	VersionInCompatible: "当前程序与配置文件中指定的版本号不兼容",
-	Complete:            "完成！文档保存在：%s，总用时：%v",
-	ConfigWriteSuccess:  "配置内容成功写入 %s",
-	TestSuccess:         "语法没有问题！",
-	LangID:              "ID",
-	LangName:            "名称",
-	LangExts:            "扩展名",
-	LoadAPI:             "加载 API：%s %s",
-	RequestAPI:          "访问 API：%s %s",
-	DeprecatedWarn:      "%s %s 将于 %s 被废弃",
-	GeneratorBy:         "当前文档由 %s 生成",
-	ServerStart:         "服务启动，可通过 %s 访问",
-	UnimplementedRPC:    "未实现该 RPC 服务 %s",
-	PackFileHeader:      "文档由 %s 自动生成，请勿手动修改！",
+	VersionInCompatible:  "当前程序与配置文件中指定的版本号不兼容",
+	Complete:             "完成！文档保存在：%s，总用时：%v",
+	WarnSummary:          "共有 %d 条警告信息",
+	StatsTotalAPIs:       "接口数量",
+	StatsDeprecatedAPIs:  "已弃用的接口数量",
+	StatsTotalTags:       "标签数量",
+	StatsTotalServers:    "服务器数量",
+	StatsRequestTypes:    "请求内容的类型数量",
+	StatsResponseTypes:   "返回内容的类型数量",
+	StatsAPIsPerTag:      "标签 %s 下的接口数量",
+	ConfigWriteSuccess:   "配置内容成功写入 %s",
+	TestSuccess:          "语法没有问题！共检测了 %d 个文件中的 %d 个接口",
+	SyntaxFailed:         "语法检测发现 %d 个错误和 %d 个警告",
+	SyntaxPartial:        "已检测 %d 个文件中的 %d 个接口，存在错误的内容可能未被统计",
+	LangID:               "ID",
+	LangName:             "名称",
+	LangExts:             "扩展名",
+	LoadAPI:              "加载 API：%s %s",
+	RequestAPI:           "访问 API：%s %s",
+	DeprecatedWarn:       "%s %s 将于 %s 被废弃",
+	CommentedAPI:         "被注释的 api 元素，不会出现在文档中",
+	MaxFilesExceeded:     "查找到的文件数量超过了 max-files 的限制 %d，多余的文件将被忽略",
+	MaxFilesHint:         "可以调大配置文件中 inputs.max-files 的值，或是将其设置为 0 以取消限制",
+	ParseTimeout:         "分析超时，剩余的内容已被忽略",
+	LintPathNotLowercase: "路径中的 %s 包含大写字母",
+	LintPathUnderscore:   "路径中的 %s 包含下划线，应该使用连字符代替",
+	LintPathVerb:         "路径中的 %s 包含 HTTP 方法名称",
+	NoCommonBasePath:     "各个服务器地址之间不存在共同的路径",
+	GeneratorBy:          "当前文档由 %s 生成",
+	ServerStart:          "服务启动，可通过 %s 访问",
+	UnimplementedRPC:     "未实现该 RPC 服务 %s",
+	PackFileHeader:       "文档由 %s 自动生成，请勿手动修改！",
 
 	// 文档树中各个字段的介绍
 	UsageAPIDoc:              "用于描述整个文档的相关内容，只能出现一次。",
@@ -85,6 +116,7 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	UsageAPIDocDescription:   "文档的整体描述内容",
 	UsageAPIDocContact:       "文档作者的联系方式",
 	UsageAPIDocLicense:       "文档的版权信息",
+	UsageAPIDocExternalDocs:  "外部文档的链接，输出 openapi 时会作为顶层的 externalDocs 输出。",
 	UsageAPIDocTags:          "文档中定义的所有标签",
 	UsageAPIDocServers:       "API 基地址列表，每个 API 最少应该有一个 server。",
 	UsageAPIDocAPIs:          "文档中的 API 文档",
@@ -97,20 +129,22 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	UsageXMLNamespacePrefix: "命名空间的前缀，如果为空，则表示作为默认命名空间，命局只能有一个默认命名空间。",
 	UsageXMLNamespaceURN:    "命名空间的唯一标识，需要全局唯一，且区分大小写。",
 
-	UsageAPI:            "用于定义单个 API 接口的具体内容",
-	UsageAPIVersion:     "表示此接口在该版本中添加",
-	UsageAPIMethod:      "当前接口所支持的请求方法",
-	UsageAPIID:          "接口的唯一 ID",
-	UsageAPIPath:        "定义路径信息",
-	UsageAPISummary:     "简要介绍",
-	UsageAPIDescription: "该接口的详细介绍，为 HTML 内容。",
-	UsageAPIRequests:    "定义可用的请求信息",
-	UsageAPIResponses:   "定义可能的返回信息",
-	UsageAPICallback:    "定义回调接口内容",
-	UsageAPIDeprecated:  "在此版本之后将会被弃用",
-	UsageAPIHeaders:     "传递的报头内容，如果是某个 mimetype 专用的，可以放在 request 元素中。",
-	UsageAPITags:        "关联的标签",
-	UsageAPIServers:     "关联的服务",
+	UsageAPI:             "用于定义单个 API 接口的具体内容",
+	UsageAPIVersion:      "表示此接口在该版本中添加",
+	UsageAPIMethod:       "当前接口所支持的请求方法",
+	UsageAPIID:           "接口的唯一 ID",
+	UsageAPIPath:         "定义路径信息",
+	UsageAPISummary:      "简要介绍",
+	UsageAPIDescription:  "该接口的详细介绍，为 HTML 内容。",
+	UsageAPIExternalDocs: "与该接口相关的外部文档链接，比如 RFC 等。",
+	UsageAPIRequests:     "定义可用的请求信息",
+	UsageAPIResponses:    "定义可能的返回信息",
+	UsageAPICallback:     "定义回调接口内容",
+	UsageAPIDeprecated:   "在此版本之后将会被弃用",
+	UsageAPIHeaders:      "传递的报头内容，如果是某个 mimetype 专用的，可以放在 request 元素中。",
+	UsageAPITags:         "关联的标签",
+	UsageAPIServers:      "关联的服务",
+	UsageAPIExtensions:   "扩展字段，输出 openapi 时会作为 x- 开头的扩展字段输出。",
 
 	UsageLink:     "用于描述链接信息，一般转换为 HTML 的 <code>a</code> 标签。",
 	UsageLinkText: "链接的字面文字",
@@ -142,6 +176,10 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	UsageExampleSummary:  "示例代码的概要信息",
 	UsageExampleContent:  "示例代码的内容，需要使用 CDATA 包含代码。",
 
+	UsageExtension:      "扩展字段",
+	UsageExtensionName:  "扩展字段的名称，必须以 x- 开头。",
+	UsageExtensionValue: "扩展字段的值",
+
 	UsageParam:            "参数类型，基本上可以作为 request 的子集使用。",
 	UsageParamName:        "值的名称",
 	UsageParamType:        "值的类型",
@@ -182,14 +220,22 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	UsageTagName:       "标签的唯一 ID",
 	UsageTagTitle:      "标签的字面名称",
 	UsageTagDeprecated: "该标签在大于该版本时被弃用",
+	UsageTagColor:      "标签在界面中的颜色，可以是 #rrggbb 格式的颜色值或是 CSS 的基本颜色名称",
 
-	UsageServer:            "用于指定各个 API 的服务器地址",
-	UsageServerName:        "服务唯一 ID",
-	UsageServerTitle:       "服务的字面名称",
-	UsageServerURL:         "服务的基地址，与该服务关联的 API，访问地址都是相对于此地址的。",
-	UsageServerDeprecated:  "服务在大于该版本时被弃用",
-	UsageServerSummary:     "服务的摘要信息",
-	UsageServerDescription: "服务的详细描述",
+	UsageServer:                    "用于指定各个 API 的服务器地址",
+	UsageServerName:                "服务唯一 ID",
+	UsageServerTitle:               "服务的字面名称",
+	UsageServerURL:                 "服务的基地址，与该服务关联的 API，访问地址都是相对于此地址的。",
+	UsageServerDeprecated:          "服务在大于该版本时被弃用",
+	UsageServerSummary:             "服务的摘要信息",
+	UsageServerDescription:         "服务的详细描述",
+	UsageServerVariables:           "服务地址中的变量列表",
+	UsageServerVariable:            "服务地址中以 {} 包含的变量",
+	UsageServerVariableName:        "变量名称，需要与服务地址中的变量名相同。",
+	UsageServerVariableDefault:     "变量的默认值",
+	UsageServerVariableSummary:     "变量的摘要信息",
+	UsageServerVariableEnums:       "变量的可选值",
+	UsageServerVariableDescription: "变量的详细说明",
 
 	UsageXMLAttr:    "是否作为父元素的属性，仅作用于 XML 元素。是否作为父元素的属性，仅用于 XML 的请求。",
 	UsageXMLExtract: "将当前元素的内容作为父元素的内容，要求父元素必须为 <var>object</var>。",
@@ -226,21 +272,45 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	</ul>`,
 
 	// 以下是有关 build.Config 的字段说明
-	UsageConfigVersion:               "此配置文件的所使用的文档版本",
-	UsageConfigInputs:                "指定输入的数据，同一项目只能解析一种语言。",
-	UsageConfigInputsLang:            "源文件的解析方式。具体支持的类型可通过命令 <samp>apidoc lang</samp> 查看支持语言。",
-	UsageConfigInputsDir:             "需要解析的源文件所在目录",
-	UsageConfigInputsExts:            "只从这些扩展名的文件中查找文档",
-	UsageConfigInputsRecursive:       "是否解析子目录下的源文件",
-	UsageConfigInputsEncoding:        `编码，默认为 <var>utf-8</var>，值可以是 <a href="https://www.iana.org/assignments/character-sets/character-sets.xhtml">character-sets</a> 中的内容。`,
-	UsageConfigInputsIgnores:         "忽略的文件或目录，比如 node_modules 等。",
-	UsageConfigOutput:                "控制输出行为",
-	UsageConfigOutputType:            "输出的类型，目前可以 <var>apidoc+xml</var>、<var>openapi+json</var> 和 <var>openapi+yaml</var>。",
-	UsageConfigOutputPath:            "指定输出的文件名，包含路径信息。",
-	UsageConfigOutputTags:            "只输出与这些标签相关联的文档，默认为全部。",
-	UsageConfigOutputStyle:           "为 XML 文件指定的 XSL 文件",
-	UsageConfigOutputNamespace:       "是否输出命名空间",
-	UsageConfigOutputNamespacePrefix: "如果输出了命名空间，还可以指定命名空间前缀。",
+	UsageConfigVersion:                    "此配置文件的所使用的文档版本",
+	UsageConfigInputs:                     "指定输入的数据，同一项目只能解析一种语言。",
+	UsageConfigInputsLang:                 "源文件的解析方式。具体支持的类型可通过命令 <samp>apidoc lang</samp> 查看支持语言。",
+	UsageConfigInputsLangVersion:          "源码语言的版本号，部分语言在不同版本之间的语法有差别，比如 python 的 2 和 3，为空表示采用默认规则。",
+	UsageConfigInputsDir:                  "需要解析的源文件所在目录",
+	UsageConfigInputsExts:                 "只从这些扩展名的文件中查找文档，不区分大小写",
+	UsageConfigInputsRecursive:            "是否解析子目录下的源文件",
+	UsageConfigInputsEncoding:             `编码，默认为 <var>utf-8</var>，值可以是 <a href="https://www.iana.org/assignments/character-sets/character-sets.xhtml">character-sets</a> 中的内容。`,
+	UsageConfigInputsIgnores:              "忽略的文件或目录，比如 node_modules 等。",
+	UsageConfigInputsLangAlias:            "扩展名与语言的对应关系，匹配的文件采用指定的语言进行解析，比如 .jinja: php。",
+	UsageConfigInputsParseFrontMatter:     "是否解析文件头部以 --- 包含的 YAML 内容，并将其转换成 api 元素。",
+	UsageConfigInputsParseStructTags:      "是否解析 Go 源码中名为 apidoc 的结构体标签，并将其转换成 api 元素，仅对 go 语言有效。",
+	UsageConfigInputsMaxFiles:             "最多处理的文件数量，超过此数量的文件将被忽略并给出警告，0 表示不限制。",
+	UsageConfigInputsTimeout:              "分析单个文件的最长时间，比如 30s，超时后该文件剩余的内容将被忽略，0 表示不限制。",
+	UsageConfigOutput:                     "控制输出行为",
+	UsageConfigOutputType:                 "输出的类型，目前可以 <var>apidoc+xml</var>、<var>openapi+json</var> 和 <var>openapi+yaml</var>。",
+	UsageConfigOutputPath:                 "指定输出的文件名，包含路径信息。",
+	UsageConfigOutputPathTemplate:         "以 Go 模板的形式指定文档的保存路径，可用变量有 Title、Version、Date 和 Type，指定后会覆盖 path 的值。",
+	UsageConfigOutputTags:                 "只输出与这些标签相关联的文档，默认为全部。",
+	UsageConfigOutputSort:                 "接口在文档中的排列顺序，可以是 path、method、tag 和 none，默认为 path。",
+	UsageConfigOutputCheckWritable:        "是否在分析文档之前检测输出目录是否可写，默认为 true。",
+	UsageConfigOutputSkipServers:          "不需要输出的服务器名称，接口中对这些服务器的引用也会被删除。",
+	UsageConfigOutputExcludeDeprecated:    "是否不输出已经弃用的接口、标签和服务器，接口中对这些标签和服务器的引用也会被删除。",
+	UsageConfigOutputDeprecatedOnly:       "是否只输出已经弃用的接口、标签和服务器，不能与 exclude-deprecated 同时使用。",
+	UsageConfigOutputStyle:                "为 XML 文件指定的 XSL 文件",
+	UsageConfigOutputStylesheetVersion:    "指定默认 XSL 文件的版本，比如 v5，可用于在升级程序之后依然使用旧版本的 XSL 文件。仅在未指定 style 时有效。",
+	UsageConfigOutputNamespace:            "是否输出命名空间",
+	UsageConfigOutputNamespacePrefix:      "如果输出了命名空间，还可以指定命名空间前缀。",
+	UsageConfigOutputIndent:               "XML 文档每一级的缩进内容，只能由空格和制表符组成，默认为制表符。",
+	UsageConfigOutputExtractBasePath:      "提取所有服务器地址中共同的路径部分，添加到各个接口路径之前，仅对 openapi 有效。",
+	UsageConfigOutputGenerateOperationIDs: "为未指定 id 的接口自动生成 operationId，仅对 openapi 有效。",
+	UsageConfigOutputOperationIDStyle:     "自动生成的 operationId 的命名风格，可以是 camel、snake 或 kebab，默认为 camel。",
+	UsageConfigOutputDeduplicateSchemas:   "将结构相同的类型提取至 components.schemas 并以 $ref 引用，仅对 openapi 有效。",
+	UsageConfigOutputOpenAPIStrict:        "是否禁止输出 x- 开头的扩展字段，文档中存在无法以 OpenAPI 原生字段表示的内容时返回错误。仅对 openapi 类型的输出有效。",
+	UsageConfigOverrides:                  "需要合并到当前配置中的其它配置文件，按顺序合并。",
+	UsageConfigLint:                       "语法之外的规范性检测，检测结果以警告的形式输出。",
+	UsageConfigCreatedAt:                  "配置文件的创建时间，RFC3339 格式，由程序在第一次保存时写入。",
+	UsageConfigUpdatedAt:                  "配置文件的最后保存时间，RFC3339 格式，由程序在每次保存时写入。",
+	UsageConfigLintNamingConventions:      "检测接口路径的命名规范，路径中除参数之外的内容只能是小写字母，以连字符代替下划线，且不能包含 HTTP 方法名称。",
 
 	// 错误信息，可能在地方用到
 	ErrInvalidUTF8Character:      "无效的 UTF8 字符",
@@ -256,20 +326,27 @@ mock 服务会根据接口定义检测用户提交的数据是否合法，并生
 	ErrDirNotExists:              "目录不存在",
 	ErrNotFoundEndFlag:           "找不到结束符号",
 	ErrNotFoundEndTag:            "找不到结束标签",
+	ErrUndeclaredNamespacePrefix: "未声明的命名空间前缀 %s",
 	ErrNotFoundSupportedLang:     "该目录下没有支持的语言文件",
 	ErrNoFiles:                   "目录下没有需要解析的文件",
 	ErrInvalidValue:              "无效的值",
 	ErrInvalidTag:                "无效的标签",
 	ErrPathNotMatchParams:        "地址参数不匹配",
 	ErrDuplicateValue:            "重复的值",
+	ErrSameAs:                    "与 %s 的内容相同",
 	ErrMessage:                   "%s 位于 %s",
 	ErrNotFound:                  "未找到该值",
 	ErrReadRemoteFile:            "读取远程文件 %s 时返回状态码 %d",
+	ErrWriteRemoteFile:           "写入远程文件 %s 时返回状态码 %d",
 	ErrServerNotInitialized:      "服务未初始化",
 	ErrInvalidLSPState:           "无效的 LSP 状态",
 	ErrInvalidURIScheme:          "无效的 URI 协议：%s",
 	ErrInvalidURI:                "无效的 URI：%s",
 	ErrFileNotFound:              "未找到文件 %s",
+	ErrIncompleteDocs:            "内置的文档内容不完整：%s",
+	ErrTooManyConnections:        "连接数量已达上限 %d",
+	ErrUnsupportedLocale:         "不支持的本地化 %s",
+	ErrNotSupportedByOpenAPI:     "%s 无法在 OpenAPI 中表示",
 
 	// logs
 	InfoPrefix:    "[信息] ",
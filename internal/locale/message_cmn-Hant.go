@@ -29,7 +29,15 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	CmdNotFound:    "子命令 %s 未找到\n",
 
 	FlagSyntaxDirUsage:         "以 `URI` 形式表示的測試項目地址",
+	FlagSyntaxWarnAsErrorUsage: "是否將警告視為錯誤，如果為 true，在存在警告時程序會以狀態碼 2 退出。",
 	FlagBuildDirUsage:          "以 `URI` 形式表示的項目地址",
+	FlagBuildProfileUsage:      "將 CPU 性能數據寫入該文件，可通過 go tool pprof 進行分析",
+	FlagBuildMemProfileUsage:   "將內存分配數據寫入該文件，可通過 go tool pprof 進行分析",
+	FlagBuildStatsUsage:        "構建完成之後輸出文檔的統計信息",
+	FlagBuildMetricsFileUsage:  "構建完成之後將構建的度量數據以 JSON 格式寫入該文件，構建出錯時也會寫入",
+	FlagQuietUsage:             "不輸出提示和成功信息，僅輸出警告和錯誤信息",
+	FlagVerboseUsage:           "在結束時額外輸出警告信息的數量",
+	FlagLocaleJSONUsage:        "以 JSON 格式輸出本地化信息",
 	FlagMockPortUsage:          "指定 mock 服務的端口號",
 	FlagMockServersUsage:       "指定 mock 服務時，文檔中 server 名對應的路由前綴。",
 	FlagMockIndentUsage:        "指定縮進內容",
@@ -44,9 +52,13 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	FlagMockURLDomainsUsage:    "生成 URL 地址時所可用的域名列表，多個用半角逗號分隔。",
 	FlagMockImagePrefixUsage:   "生成圖片類型數據的基地址",
 	FlagMockDateRangeUsage:     "生成可用的日期範圍，格式為 [start,end]，start 和 end 均為 RFC3339 格式。",
+	FlagMockSeedUsage:          "生成隨機數據時採用的種子，相同的種子會生成相同的數據，為 0 時採用當前時間。",
+	FlagMockRetryUsage:         "讀取遠程文檔失敗時的重試次數，為 0 表示不重試。",
 	FlagDetectRecursiveUsage:   "detect 子命令是否檢測子目錄的值",
 	FlagDetectDirUsage:         "以 `URI` 形式表示的檢測項目地址",
 	FlagDetectWrite:            "是否將配置內容寫入文件，如果為 true，會將配置內容寫入檢測目錄下的 .apidoc.yaml 文件。",
+	FlagDetectNormalize:        "是否將配置內容轉換成規範的格式，比如對 inputs 進行排序，去掉可選字段中的零值等。",
+	FlagDetectFormat:           "配置文件的格式，可以是 yaml 或 json，同時也影響 -w 寫入的文件。",
 	FlagStaticPortUsage:        "指定 static 服務的端口號",
 	FlagStaticDocsUsage:        "指定 static 服務靜態文件所在的 `URI`",
 	FlagStaticStylesheetUsage:  "指定 static 是否只啟用樣式文件內容",
@@ -57,22 +69,41 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	FlagLSPModeUsage:           "指定 LSP 的運行方式，可以是 stdio、tcp、unix 和 udp。",
 	FlagLSPHeaderUsage:         "指定 LSP 傳遞內容是否帶報頭信息。",
 	FlagLSPTimeoutUsage:        "指定 LSP 每次讀取客戶端數據的超時時間，超時不會觸發錯誤，只會再次讀取。",
+	FlagLSPMaxConnsUsage:       "指定 LSP 允許的最大連接數量，僅對 tcp 和 unix 有效，0 表示不限制。",
 	FlagVersionKindUsage:       "只顯示該類型的版本號，可以是 apidoc、doc、lsp、openapi 和 all",
 
-	VersionInCompatible: "當前程序與配置文件中指定的版本號不兼容",
-	Complete:            "完成！文檔保存在：%s，總用時：%v",
-	ConfigWriteSuccess:  "配置內容成功寫入 %s",
-	TestSuccess:         "語法沒有問題！",
-	LangID:              "ID",
-	LangName:            "名稱",
-	LangExts:            "擴展名",
-	LoadAPI:             "加載 API：%s %s",
-	RequestAPI:          "訪問 API：%s %s",
-	DeprecatedWarn:      "%s %s 將於 %s 被廢棄",
-	GeneratorBy:         "當前文檔由 %s 生成",
-	ServerStart:         "服務啟動，可通過 %s 訪問",
-	UnimplementedRPC:    "未實現該 RPC 服務 %s",
-	PackFileHeader:      "文檔由 %s 自動生成，請勿手動修改！",
+	VersionInCompatible:  "當前程序與配置文件中指定的版本號不兼容",
+	Complete:             "完成！文檔保存在：%s，總用時：%v",
+	WarnSummary:          "共有 %d 條警告信息",
+	StatsTotalAPIs:       "接口數量",
+	StatsDeprecatedAPIs:  "已棄用的接口數量",
+	StatsTotalTags:       "標籤數量",
+	StatsTotalServers:    "服務器數量",
+	StatsRequestTypes:    "請求內容的類型數量",
+	StatsResponseTypes:   "返回內容的類型數量",
+	StatsAPIsPerTag:      "標籤 %s 下的接口數量",
+	ConfigWriteSuccess:   "配置內容成功寫入 %s",
+	TestSuccess:          "語法沒有問題！共檢測了 %d 個文件中的 %d 個接口",
+	SyntaxFailed:         "語法檢測發現 %d 個錯誤和 %d 個警告",
+	SyntaxPartial:        "已檢測 %d 個文件中的 %d 個接口，存在錯誤的內容可能未被統計",
+	LangID:               "ID",
+	LangName:             "名稱",
+	LangExts:             "擴展名",
+	LoadAPI:              "加載 API：%s %s",
+	RequestAPI:           "訪問 API：%s %s",
+	DeprecatedWarn:       "%s %s 將於 %s 被廢棄",
+	CommentedAPI:         "被註釋的 api 元素，不會出現在文檔中",
+	MaxFilesExceeded:     "查找到的文件數量超過了 max-files 的限制 %d，多餘的文件將被忽略",
+	MaxFilesHint:         "可以調大配置文件中 inputs.max-files 的值，或是將其設置為 0 以取消限制",
+	ParseTimeout:         "分析超時，剩餘的內容已被忽略",
+	LintPathNotLowercase: "路徑中的 %s 包含大寫字母",
+	LintPathUnderscore:   "路徑中的 %s 包含下劃線，應該使用連字符代替",
+	LintPathVerb:         "路徑中的 %s 包含 HTTP 方法名稱",
+	NoCommonBasePath:     "各個服務器地址之間不存在共同的路徑",
+	GeneratorBy:          "當前文檔由 %s 生成",
+	ServerStart:          "服務啟動，可通過 %s 訪問",
+	UnimplementedRPC:     "未實現該 RPC 服務 %s",
+	PackFileHeader:       "文檔由 %s 自動生成，請勿手動修改！",
 
 	// 文檔樹中各個字段的介紹
 	UsageAPIDoc:              "用於描述整個文檔的相關內容，只能出現壹次。",
@@ -85,6 +116,7 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	UsageAPIDocDescription:   "文檔的整體描述內容",
 	UsageAPIDocContact:       "文檔作者的聯系方式",
 	UsageAPIDocLicense:       "文檔的版權信息",
+	UsageAPIDocExternalDocs:  "外部文檔的鏈接，輸出 openapi 時會作為頂層的 externalDocs 輸出。",
 	UsageAPIDocTags:          "文檔中定義的所有標簽",
 	UsageAPIDocServers:       "API 基地址列表，每個 API 最少應該有壹個 server。",
 	UsageAPIDocAPIs:          "文檔中的 API 文檔",
@@ -97,20 +129,22 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	UsageXMLNamespacePrefix: "命名空間的前綴，如果為空，則表示作為默認命名空間，命局只能有壹個默認命名空間。",
 	UsageXMLNamespaceURN:    "命名空間的唯壹標識，需要全局唯壹，且區分大小寫。",
 
-	UsageAPI:            "用於定義單個 API 接口的具體內容",
-	UsageAPIVersion:     "表示此接口在該版本中添加",
-	UsageAPIMethod:      "當前接口所支持的請求方法",
-	UsageAPIID:          "接口的唯壹 ID",
-	UsageAPIPath:        "定義路徑信息",
-	UsageAPISummary:     "簡要介紹",
-	UsageAPIDescription: "該接口的詳細介紹，為 HTML 內容。",
-	UsageAPIRequests:    "定義可用的請求信息",
-	UsageAPIResponses:   "定義可能的返回信息",
-	UsageAPICallback:    "定義回調接口內容",
-	UsageAPIDeprecated:  "在此版本之後將會被棄用",
-	UsageAPIHeaders:     "傳遞的報頭內容，如果是某個 mimetype 專用的，可以放在 request 元素中。",
-	UsageAPITags:        "關聯的標簽",
-	UsageAPIServers:     "關聯的服務",
+	UsageAPI:             "用於定義單個 API 接口的具體內容",
+	UsageAPIVersion:      "表示此接口在該版本中添加",
+	UsageAPIMethod:       "當前接口所支持的請求方法",
+	UsageAPIID:           "接口的唯壹 ID",
+	UsageAPIPath:         "定義路徑信息",
+	UsageAPISummary:      "簡要介紹",
+	UsageAPIDescription:  "該接口的詳細介紹，為 HTML 內容。",
+	UsageAPIExternalDocs: "與該接口相關的外部文檔鏈接，比如 RFC 等。",
+	UsageAPIRequests:     "定義可用的請求信息",
+	UsageAPIResponses:    "定義可能的返回信息",
+	UsageAPICallback:     "定義回調接口內容",
+	UsageAPIDeprecated:   "在此版本之後將會被棄用",
+	UsageAPIHeaders:      "傳遞的報頭內容，如果是某個 mimetype 專用的，可以放在 request 元素中。",
+	UsageAPITags:         "關聯的標簽",
+	UsageAPIServers:      "關聯的服務",
+	UsageAPIExtensions:   "擴展字段，輸出 openapi 時會作為 x- 開頭的擴展字段輸出。",
 
 	UsageLink:     "用於描述鏈接信息，壹般轉換為 HTML 的 <code>a</code> 標簽。",
 	UsageLinkText: "鏈接的字面文字",
@@ -142,6 +176,10 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	UsageExampleSummary:  "示例代碼的概要信息",
 	UsageExampleContent:  "示例代碼的內容，需要使用 CDATA 包含代碼。",
 
+	UsageExtension:      "擴展字段",
+	UsageExtensionName:  "擴展字段的名稱，必須以 x- 開頭。",
+	UsageExtensionValue: "擴展字段的值",
+
 	UsageParam:            "參數類型，基本上可以作為 request 的子集使用。",
 	UsageParamName:        "值的名稱",
 	UsageParamType:        "值的類型",
@@ -182,14 +220,22 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	UsageTagName:       "標簽的唯壹 ID",
 	UsageTagTitle:      "標簽的字面名稱",
 	UsageTagDeprecated: "該標簽在大於該版本時被棄用",
+	UsageTagColor:      "標簽在界面中的顏色，可以是 #rrggbb 格式的顏色值或是 CSS 的基本顏色名稱",
 
-	UsageServer:            "用於指定各個 API 的服務器地址",
-	UsageServerName:        "服務唯壹 ID",
-	UsageServerTitle:       "服務的字面名稱",
-	UsageServerURL:         "服務的基地址，與該服務關聯的 API，訪問地址都是相對於此地址的。",
-	UsageServerDeprecated:  "服務在大於該版本時被棄用",
-	UsageServerSummary:     "服務的摘要信息",
-	UsageServerDescription: "服務的詳細描述",
+	UsageServer:                    "用於指定各個 API 的服務器地址",
+	UsageServerName:                "服務唯壹 ID",
+	UsageServerTitle:               "服務的字面名稱",
+	UsageServerURL:                 "服務的基地址，與該服務關聯的 API，訪問地址都是相對於此地址的。",
+	UsageServerDeprecated:          "服務在大於該版本時被棄用",
+	UsageServerSummary:             "服務的摘要信息",
+	UsageServerDescription:         "服務的詳細描述",
+	UsageServerVariables:           "服務地址中的變量列表",
+	UsageServerVariable:            "服務地址中以 {} 包含的變量",
+	UsageServerVariableName:        "變量名稱，需要與服務地址中的變量名相同。",
+	UsageServerVariableDefault:     "變量的默認值",
+	UsageServerVariableSummary:     "變量的摘要信息",
+	UsageServerVariableEnums:       "變量的可選值",
+	UsageServerVariableDescription: "變量的詳細說明",
 
 	UsageXMLAttr:    "是否作為父元素的屬性，僅作用於 XML 元素。是否作為父元素的屬性，僅用於 XML 的請求。",
 	UsageXMLExtract: "將當前元素的內容作為父元素的內容，要求父元素必須為 <var>object</var>。",
@@ -226,21 +272,45 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	</ul>`,
 
 	// 以下是有关 build.Config 的字段说明
-	UsageConfigVersion:               "此配置文件的所使用的文档版本",
-	UsageConfigInputs:                "指定輸入的數據，同壹項目只能解析壹種語言。",
-	UsageConfigInputsLang:            "源文件的解析方式。具體支持的類型可通過命令 <samp>apidoc lang</samp> 查看支持語言。",
-	UsageConfigInputsDir:             "需要解析的源文件所在目錄",
-	UsageConfigInputsExts:            "只從這些擴展名的文件中查找文檔",
-	UsageConfigInputsRecursive:       "是否解析子目錄下的源文件",
-	UsageConfigInputsEncoding:        `編碼，默認為 <var>utf-8</var>，值可以是 <a href="https://www.iana.org/assignments/character-sets/character-sets.xhtml">character-sets</a> 中的內容。`,
-	UsageConfigInputsIgnores:         "忽略的文件或目錄，比如 node_modules 等。",
-	UsageConfigOutput:                "控制輸出行為",
-	UsageConfigOutputType:            "輸出的類型，目前可以 <var>apidoc+xml</var>、<var>openapi+json</var> 和 <var>openapi+yaml</var>。",
-	UsageConfigOutputPath:            "指定輸出的文件名，包含路徑信息。",
-	UsageConfigOutputTags:            "只輸出與這些標簽相關聯的文檔，默認為全部。",
-	UsageConfigOutputStyle:           "為 XML 文件指定的 XSL 文件",
-	UsageConfigOutputNamespace:       "是否輸出命名空間",
-	UsageConfigOutputNamespacePrefix: "如果輸出了命名空間，還可以指定命名空間前綴。",
+	UsageConfigVersion:                    "此配置文件的所使用的文档版本",
+	UsageConfigInputs:                     "指定輸入的數據，同壹項目只能解析壹種語言。",
+	UsageConfigInputsLang:                 "源文件的解析方式。具體支持的類型可通過命令 <samp>apidoc lang</samp> 查看支持語言。",
+	UsageConfigInputsLangVersion:          "源碼語言的版本號，部分語言在不同版本之間的語法有差別，比如 python 的 2 和 3，為空表示採用默認規則。",
+	UsageConfigInputsDir:                  "需要解析的源文件所在目錄",
+	UsageConfigInputsExts:                 "只從這些擴展名的文件中查找文檔，不區分大小寫",
+	UsageConfigInputsRecursive:            "是否解析子目錄下的源文件",
+	UsageConfigInputsEncoding:             `編碼，默認為 <var>utf-8</var>，值可以是 <a href="https://www.iana.org/assignments/character-sets/character-sets.xhtml">character-sets</a> 中的內容。`,
+	UsageConfigInputsIgnores:              "忽略的文件或目錄，比如 node_modules 等。",
+	UsageConfigInputsLangAlias:            "擴展名與語言的對應關系，匹配的文件采用指定的語言進行解析，比如 .jinja: php。",
+	UsageConfigInputsParseFrontMatter:     "是否解析文件頭部以 --- 包含的 YAML 內容，並將其轉換成 api 元素。",
+	UsageConfigInputsParseStructTags:      "是否解析 Go 源碼中名為 apidoc 的結構體標籤，並將其轉換成 api 元素，僅對 go 語言有效。",
+	UsageConfigInputsMaxFiles:             "最多處理的文件數量，超過此數量的文件將被忽略並給出警告，0 表示不限制。",
+	UsageConfigInputsTimeout:              "分析單個文件的最長時間，比如 30s，超時後該文件剩餘的內容將被忽略，0 表示不限制。",
+	UsageConfigOutput:                     "控制輸出行為",
+	UsageConfigOutputType:                 "輸出的類型，目前可以 <var>apidoc+xml</var>、<var>openapi+json</var> 和 <var>openapi+yaml</var>。",
+	UsageConfigOutputPath:                 "指定輸出的文件名，包含路徑信息。",
+	UsageConfigOutputPathTemplate:         "以 Go 模板的形式指定文檔的保存路徑，可用變量有 Title、Version、Date 和 Type，指定後會覆蓋 path 的值。",
+	UsageConfigOutputTags:                 "只輸出與這些標簽相關聯的文檔，默認為全部。",
+	UsageConfigOutputSort:                 "接口在文檔中的排列順序，可以是 path、method、tag 和 none，默認為 path。",
+	UsageConfigOutputCheckWritable:        "是否在分析文檔之前檢測輸出目錄是否可寫，默認為 true。",
+	UsageConfigOutputSkipServers:          "不需要輸出的服務器名稱，接口中對這些服務器的引用也會被刪除。",
+	UsageConfigOutputExcludeDeprecated:    "是否不輸出已經棄用的接口、標籤和服務器，接口中對這些標籤和服務器的引用也會被刪除。",
+	UsageConfigOutputDeprecatedOnly:       "是否只輸出已經棄用的接口、標籤和服務器，不能與 exclude-deprecated 同時使用。",
+	UsageConfigOutputStyle:                "為 XML 文件指定的 XSL 文件",
+	UsageConfigOutputStylesheetVersion:    "指定默認 XSL 文件的版本，比如 v5，可用於在升級程序之後依然使用舊版本的 XSL 文件。僅在未指定 style 時有效。",
+	UsageConfigOutputNamespace:            "是否輸出命名空間",
+	UsageConfigOutputNamespacePrefix:      "如果輸出了命名空間，還可以指定命名空間前綴。",
+	UsageConfigOutputIndent:               "XML 文檔每一級的縮進內容，只能由空格和制表符組成，默認為制表符。",
+	UsageConfigOutputExtractBasePath:      "提取所有服務器地址中共同的路徑部分，添加到各個接口路徑之前，僅對 openapi 有效。",
+	UsageConfigOutputGenerateOperationIDs: "為未指定 id 的接口自動生成 operationId，僅對 openapi 有效。",
+	UsageConfigOutputOperationIDStyle:     "自動生成的 operationId 的命名風格，可以是 camel、snake 或 kebab，默認為 camel。",
+	UsageConfigOutputDeduplicateSchemas:   "將結構相同的類型提取至 components.schemas 並以 $ref 引用，僅對 openapi 有效。",
+	UsageConfigOutputOpenAPIStrict:        "是否禁止輸出 x- 開頭的擴展字段，文檔中存在無法以 OpenAPI 原生字段表示的內容時返回錯誤。僅對 openapi 類型的輸出有效。",
+	UsageConfigOverrides:                  "需要合並到當前配置中的其它配置文件，按順序合並。",
+	UsageConfigLint:                       "語法之外的規範性檢測，檢測結果以警告的形式輸出。",
+	UsageConfigCreatedAt:                  "配置文件的創建時間，RFC3339 格式，由程序在第一次保存時寫入。",
+	UsageConfigUpdatedAt:                  "配置文件的最後保存時間，RFC3339 格式，由程序在每次保存時寫入。",
+	UsageConfigLintNamingConventions:      "檢測接口路徑的命名規範，路徑中除參數之外的內容只能是小寫字母，以連字符代替下劃線，且不能包含 HTTP 方法名稱。",
 
 	// 錯誤信息，可能在地方用到
 	ErrInvalidUTF8Character:      "無效的 UTF8 字符",
@@ -256,20 +326,27 @@ mock 服務會根據接口定義檢測用戶提交的數據是否合法，並生
 	ErrDirNotExists:              "目錄不存在",
 	ErrNotFoundEndFlag:           "找不到結束符號",
 	ErrNotFoundEndTag:            "找不到結束標簽",
+	ErrUndeclaredNamespacePrefix: "未聲明的命名空間前綴 %s",
 	ErrNotFoundSupportedLang:     "該目錄下沒有支持的語言文件",
 	ErrNoFiles:                   "目錄下沒有需要解析的文件",
 	ErrInvalidValue:              "無效的值",
 	ErrInvalidTag:                "無效的標簽",
 	ErrPathNotMatchParams:        "地址參數不匹配",
 	ErrDuplicateValue:            "重復的值",
+	ErrSameAs:                    "與 %s 的內容相同",
 	ErrMessage:                   "%s 位於 %s",
 	ErrNotFound:                  "未找到該值",
 	ErrReadRemoteFile:            "讀取遠程文件 %s 時返回狀態碼 %d",
+	ErrWriteRemoteFile:           "寫入遠程文件 %s 時返回狀態碼 %d",
 	ErrServerNotInitialized:      "服務未初始化",
 	ErrInvalidLSPState:           "無效的 LSP 狀態",
 	ErrInvalidURIScheme:          "無效的 URI 協議：%s",
 	ErrInvalidURI:                "無效的 URI：%s",
 	ErrFileNotFound:              "未找到文件 %s",
+	ErrIncompleteDocs:            "內置的文檔內容不完整：%s",
+	ErrTooManyConnections:        "連接數量已達上限 %d",
+	ErrUnsupportedLocale:         "不支持的本地化 %s",
+	ErrNotSupportedByOpenAPI:     "%s 無法在 OpenAPI 中表示",
 
 	// logs
 	InfoPrefix:    "[信息] ",
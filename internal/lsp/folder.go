@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/issue9/sliceutil"
 
@@ -16,6 +17,11 @@ import (
 	"github.com/caixw/apidoc/v7/internal/lsp/protocol"
 )
 
+// 合并文件修改内容的时间间隔
+//
+// 在此时间内对同一文件的多次修改，只会处理最后一次。
+const changeDelay = 150 * time.Millisecond
+
 // 表示项目文件夹
 type folder struct {
 	protocol.WorkspaceFolder
@@ -29,10 +35,27 @@ type folder struct {
 	parsedMux sync.RWMutex // 解析 doc 时需要的锁
 
 	// 保存着错误和警告的信息
-	diagnostics map[core.URI]*protocol.PublishDiagnosticsParams
+	//
+	// 由 h 所在的 goroutine 写入，读取和清空则可能在其它 goroutine 中进行，
+	// 所有的访问都需要通过 diagnosticsMux 加锁。
+	diagnostics    map[core.URI]*protocol.PublishDiagnosticsParams
+	diagnosticsMux sync.Mutex
+
+	// 待处理的文件修改内容，由 run 串行处理。
+	changes    chan *protocol.DidChangeTextDocumentParams
+	changesMux sync.RWMutex
+	done       chan struct{}
 }
 
 func (f *folder) close() {
+	f.changesMux.Lock()
+	if f.changes != nil {
+		close(f.changes)
+		<-f.done
+		f.changes = nil
+	}
+	f.changesMux.Unlock()
+
 	f.clearDiagnostics()
 	if f.h != nil {
 		f.h.Stop()
@@ -46,6 +69,9 @@ func (f *folder) messageHandler(msg *core.Message) {
 		return
 	}
 
+	f.diagnosticsMux.Lock()
+	defer f.diagnosticsMux.Unlock()
+
 	if p, found := f.diagnostics[err.Location.URI]; found && p != nil {
 		cnt := sliceutil.Count(p.Diagnostics, func(i protocol.Diagnostic) bool {
 			return i.Range.Equal(err.Location.Range)
@@ -69,10 +95,94 @@ func (s *server) appendFolders(folders ...protocol.WorkspaceFolder) {
 			diagnostics:     make(map[core.URI]*protocol.PublishDiagnosticsParams, 5),
 		}
 		f.refresh(false)
+		f.start()
 		s.folders = append(s.folders, f)
 	}
 }
 
+// 启动处理文件修改内容的 goroutine
+func (f *folder) start() {
+	f.changes = make(chan *protocol.DidChangeTextDocumentParams, 20)
+	f.done = make(chan struct{})
+	go f.run()
+}
+
+// 将文件的修改内容加入队列
+//
+// 如果 f 已经关闭，则直接丢弃 in。
+func (f *folder) enqueue(in *protocol.DidChangeTextDocumentParams) {
+	f.changesMux.RLock()
+	defer f.changesMux.RUnlock()
+
+	if f.changes != nil {
+		f.changes <- in
+	}
+}
+
+// 串行处理 f.changes 中的内容
+//
+// 修改内容会在 changeDelay 时间内没有新的修改之后才统一处理，
+// f.changes 关闭之后，会处理完所有未处理的内容再退出。
+func (f *folder) run() {
+	defer close(f.done)
+
+	pending := make(map[core.URI]*protocol.DidChangeTextDocumentParams, 5)
+	timer := time.NewTimer(changeDelay)
+	timer.Stop()
+
+	for {
+		select {
+		case in, ok := <-f.changes:
+			if !ok {
+				timer.Stop()
+				f.applyChanges(pending)
+				return
+			}
+
+			pending[in.TextDocument.URI] = in
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(changeDelay)
+		case <-timer.C:
+			f.applyChanges(pending)
+			pending = make(map[core.URI]*protocol.DidChangeTextDocumentParams, 5)
+		}
+	}
+}
+
+func (f *folder) applyChanges(changes map[core.URI]*protocol.DidChangeTextDocumentParams) {
+	if len(changes) == 0 {
+		return
+	}
+
+	f.parsedMux.Lock()
+	defer f.parsedMux.Unlock()
+
+	var changed bool
+	for uri, in := range changes {
+		if !deleteURI(f.doc, uri) {
+			continue
+		}
+
+		if !changed {
+			f.clearDiagnostics()
+			changed = true
+		}
+
+		for _, blk := range in.Blocks() {
+			f.parseBlock(blk)
+		}
+	}
+
+	if changed {
+		f.srv.textDocumentPublishDiagnostics(f)
+	}
+}
+
 // 刷新项目
 //
 // 默认情况下，没有配置文件不会解析项目，但是在 force 为 true 时，会强制解析项目内容。
@@ -117,6 +227,26 @@ func (f *folder) refresh(force bool) {
 	f.srv.textDocumentPublishDiagnostics(f)
 }
 
+// 检测项目的语法，返回错误信息的数量
+func (f *folder) checkSyntax() int {
+	if f.cfg == nil {
+		return 0
+	}
+
+	var count int
+	h := core.NewMessageHandler(func(msg *core.Message) {
+		if msg.Type == core.Erro {
+			count++
+		}
+	})
+	if err := build.CheckSyntax(h, f.cfg.Inputs...); err != nil {
+		f.srv.printErr(err)
+	}
+	h.Stop()
+
+	return count
+}
+
 func (s *server) findFolder(uri core.URI) *folder {
 	s.workspaceMux.RLock()
 	defer s.workspaceMux.RUnlock()
@@ -62,3 +62,72 @@ func TestServer_textDocumentHover(t *testing.T) {
 	})
 	a.Equal(h.Contents.Value, locale.Sprintf("usage-apidoc-title"))
 }
+
+func TestServer_textDocumentHover_positions(t *testing.T) {
+	a := assert.New(t, false)
+	s := newTestServer(true, log.New(ioutil.Discard, "", 0), log.New(ioutil.Discard, "", 0))
+
+	const b = `<apidoc version="1.1.1">
+	<title>标题</title>
+	<tag name="t1" title="tag1" />
+	<server name="admin" url="https://example.com/admin" />
+	<mimetype>json</mimetype>
+	<api method="GET">
+		<path path="/users/{id}">
+			<param name="id" type="number" summary="id" />
+		</path>
+		<response status="200" type="string" />
+		<tag>t1</tag>
+		<server>admin</server>
+	</api>
+</apidoc>`
+	const uri core.URI = "file:///test/doc.go"
+	blk := core.Block{Data: []byte(b), Location: core.Location{URI: uri}}
+	rslt := messagetest.NewMessageHandler()
+	doc := &ast.APIDoc{}
+	doc.Parse(rslt.Handler, blk)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	s.folders = []*folder{
+		{
+			WorkspaceFolder: protocol.WorkspaceFolder{Name: "test", URI: "file:///test"},
+			doc:             doc,
+		},
+	}
+
+	api := doc.APIs[0]
+	data := []*struct {
+		name string
+		node usager
+	}{
+		{name: "tag.name", node: doc.Tags[0].Name},
+		{name: "tag.title", node: doc.Tags[0].Title},
+		{name: "server.name", node: doc.Servers[0].Name},
+		{name: "server.url", node: doc.Servers[0].URL},
+		{name: "api.method", node: api.Method},
+		{name: "path.path", node: api.Path.Path},
+		{name: "path.param", node: api.Path.Params[0]},
+		{name: "path.param.name", node: api.Path.Params[0].Name},
+		{name: "path.param.type", node: api.Path.Params[0].Type},
+		{name: "response.type", node: api.Responses[0].Type},
+		{name: "response.status", node: api.Responses[0].Status},
+		{name: "api.tag", node: api.Tags[0]},
+		{name: "api.server", node: api.Servers[0]},
+	}
+
+	for _, item := range data {
+		r := item.node.Loc().Range
+		a.False(r.IsEmpty(), "%s 的范围为空", item.name)
+
+		h := &protocol.Hover{}
+		err := s.textDocumentHover(false, &protocol.HoverParams{TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: uri},
+			Position:     r.Start,
+		}}, h)
+		a.NotError(err, "%s 返回了错误 %s", item.name, err).
+			NotEmpty(h.Contents.Value, "%s 的内容为空", item.name).
+			Equal(h.Contents.Value, item.node.Usage(), "%s 的内容不同", item.name).
+			Equal(h.Range, r, "%s 的范围不同 v1:%+v,v2:%+v", item.name, h.Range, r)
+	}
+}
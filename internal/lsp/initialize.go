@@ -33,8 +33,13 @@ func (s *server) initialize(notify bool, in *protocol.InitializeParams, out *pro
 		}
 	}
 
+	out.Capabilities.ExecuteCommandProvider = &protocol.ExecuteCommandOptions{
+		Commands: protocol.Commands(),
+	}
+
 	out.Capabilities.TextDocumentSync = &protocol.ServerCapabilitiesTextDocumentSyncOptions{
 		Change: protocol.TextDocumentSyncKindFull,
+		Save:   &protocol.SaveOptions{}, // 保存之后从磁盘读取内容，不需要客户端提供。
 	}
 
 	if in.Capabilities.TextDocument.Hover != nil && in.Capabilities.TextDocument.Hover.ContentFormat != nil {
@@ -23,7 +23,10 @@ func TestServer_initialize(t *testing.T) {
 	a.NotError(s.initialize(false, in, out))
 	a.Equal(out.ServerInfo.Name, core.Name)
 	a.Equal(s.clientParams, in).Equal(s.serverResult, out)
-	a.Equal(s.state, serverInitializing)
+	a.Equal(s.state, serverInitializing).
+		Equal(out.Capabilities.ExecuteCommandProvider.Commands, protocol.Commands())
+	a.NotNil(out.Capabilities.TextDocumentSync.Save).
+		False(out.Capabilities.TextDocumentSync.Save.IncludeText)
 
 	s = newTestServer(true, log.New(ioutil.Discard, "", 0), log.New(ioutil.Discard, "", 0))
 	in = &protocol.InitializeParams{
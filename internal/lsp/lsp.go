@@ -4,10 +4,14 @@
 package lsp
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/issue9/jsonrpc"
@@ -22,48 +26,105 @@ const Version = "3.16.0"
 // Serve 执行 LSP 服务
 //
 // t 表示服务的类型，可以是 stdio、udp、tcp 和 unix。
-func Serve(header bool, t string, addr string, timeout time.Duration, infolog, errlog *log.Logger) error {
+// maxConnections 表示 tcp 和 unix 允许的最大连接数量，0 表示不限制；
+// ctx 被取消时，会关闭所有的连接并返回。
+func Serve(ctx context.Context, header bool, t string, addr string, timeout time.Duration, maxConnections int, infolog, errlog *log.Logger) error {
 	switch strings.ToLower(t) {
 	case "stdio":
-		return serveStdio(header, infolog, errlog)
+		return serveStdio(ctx, header, infolog, errlog)
 	case "udp":
-		return serveUDP(header, addr, timeout, infolog, errlog)
+		return serveUDP(ctx, header, addr, timeout, infolog, errlog)
 	case "tcp", "unix":
-		return serveTCP(header, t, addr, timeout, infolog, errlog)
+		return serveTCP(ctx, header, t, addr, timeout, maxConnections, infolog, errlog)
 	}
 
 	return core.NewError(locale.ErrInvalidValue)
 }
 
-func serveStdio(header bool, infolog, errlog *log.Logger) error {
-	return serve(jsonrpc.NewStreamTransport(header, os.Stdin, os.Stdout, nil), infolog, errlog)
+func serveStdio(ctx context.Context, header bool, infolog, errlog *log.Logger) error {
+	return serve(ctx, jsonrpc.NewStreamTransport(header, os.Stdin, os.Stdout, nil), infolog, errlog)
 }
 
-func serveUDP(header bool, addr string, timeout time.Duration, infolog, errlog *log.Logger) error {
+func serveUDP(ctx context.Context, header bool, addr string, timeout time.Duration, infolog, errlog *log.Logger) error {
 	t, err := jsonrpc.NewUDPServerTransport(header, addr, timeout)
 	if err != nil {
 		return err
 	}
-	return serve(t, infolog, errlog)
+	return serve(ctx, t, infolog, errlog)
+}
+
+// 拒绝连接时发送给客户端的错误信息
+type rejectResponse struct {
+	Version string         `json:"jsonrpc"`
+	Error   *jsonrpc.Error `json:"error"`
 }
 
 // t 可以是 tcp 和 unix
-func serveTCP(header bool, t string, addr string, timeout time.Duration, infolog, errlog *log.Logger) error {
+func serveTCP(ctx context.Context, header bool, t string, addr string, timeout time.Duration, maxConnections int, infolog, errlog *log.Logger) error {
 	l, err := net.Listen(t, addr)
 	if err != nil {
 		return err
 	}
 
+	var count int32
+	conns := &sync.Map{} // 所有活动的连接
+	wg := &sync.WaitGroup{}
+
+	go func() {
+		<-ctx.Done()
+		if err := l.Close(); err != nil {
+			errlog.Println(err)
+		}
+		conns.Range(func(key, _ interface{}) bool {
+			// 连接可能已经由 jsonrpc.Conn 自行关闭，忽略重复关闭的错误。
+			key.(net.Conn).Close()
+			return true
+		})
+	}()
+
 	for {
 		conn, err := l.Accept()
-		if err != nil {
+		if errors.Is(err, net.ErrClosed) && ctx.Err() != nil {
+			wg.Wait()
+			return ctx.Err()
+		} else if err != nil {
 			errlog.Println(err)
 			continue
 		}
-		return serve(jsonrpc.NewSocketTransport(header, conn, timeout), infolog, errlog)
+
+		transport := jsonrpc.NewSocketTransport(header, conn, timeout)
+
+		if maxConnections > 0 && int(atomic.LoadInt32(&count)) >= maxConnections {
+			resp := &rejectResponse{
+				Version: jsonrpc.Version,
+				Error:   newError(ErrInternalError, locale.ErrTooManyConnections, maxConnections),
+			}
+			if err := transport.Write(resp); err != nil {
+				errlog.Println(err)
+			}
+			if err := transport.Close(); err != nil {
+				errlog.Println(err)
+			}
+			continue
+		}
+
+		atomic.AddInt32(&count, 1)
+		conns.Store(conn, struct{}{})
+		wg.Add(1)
+		go func(conn net.Conn) {
+			defer func() {
+				conns.Delete(conn)
+				atomic.AddInt32(&count, -1)
+				wg.Done()
+			}()
+
+			if err := serve(ctx, transport, infolog, errlog); err != nil && !errors.Is(err, context.Canceled) {
+				errlog.Println(err)
+			}
+		}(conn)
 	}
 }
 
-func serve(t jsonrpc.Transport, infolog, errlog *log.Logger) error {
-	return newServe(t, infolog, errlog).serve()
+func serve(ctx context.Context, t jsonrpc.Transport, infolog, errlog *log.Logger) error {
+	return newServe(t, infolog, errlog).serve(ctx)
 }
@@ -20,7 +20,7 @@ import (
 
 func TestServe(t *testing.T) {
 	a := assert.New(t, false)
-	a.Error(Serve(true, "not-exists-type", "", time.Second, nil, nil))
+	a.Error(Serve(context.Background(), true, "not-exists-type", "", time.Second, 0, nil, nil))
 }
 
 func TestServe_udp(t *testing.T) {
@@ -33,7 +33,7 @@ func TestServe_udp(t *testing.T) {
 	header := true
 
 	go func() {
-		err := Serve(header, "udp", ":8089", time.Second, infoLog, erroLog)
+		err := Serve(context.Background(), header, "udp", ":8089", time.Second, 0, infoLog, erroLog)
 		a.True(errors.Is(err, context.Canceled))
 		srvExit <- struct{}{}
 	}()
@@ -87,8 +87,9 @@ func TestServe_tcp(t *testing.T) {
 	srvExit := make(chan struct{}, 1)
 	header := true
 
+	srvCtx, srvCancel := context.WithCancel(context.Background())
 	go func() {
-		err := Serve(header, "tcp", ":8089", time.Second, infoLog, erroLog)
+		err := Serve(srvCtx, header, "tcp", ":8089", time.Second, 0, infoLog, erroLog)
 		a.True(errors.Is(err, context.Canceled))
 		srvExit <- struct{}{}
 	}()
@@ -130,6 +131,41 @@ func TestServe_tcp(t *testing.T) {
 
 	<-shutdown
 	clientCancel()
-	<-srvExit
 	<-clientExit
+	srvCancel()
+	<-srvExit
+}
+
+func TestServe_maxConnections(t *testing.T) {
+	a := assert.New(t, false)
+	erroLog := log.New(new(bytes.Buffer), "[ERRO]", 0)
+	infoLog := log.New(new(bytes.Buffer), "[INFO]", 0)
+	srvExit := make(chan struct{}, 1)
+	const max = 2
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		err := Serve(ctx, true, "tcp", ":8090", time.Second, max, infoLog, erroLog)
+		a.True(errors.Is(err, context.Canceled))
+		srvExit <- struct{}{}
+	}()
+	time.Sleep(500 * time.Millisecond) // 等待服务启动完成
+
+	for i := 0; i < max; i++ {
+		conn, err := net.Dial("tcp", "127.0.0.1:8090")
+		a.NotError(err).NotNil(conn)
+		defer conn.Close()
+	}
+	time.Sleep(500 * time.Millisecond) // 等待连接被服务端接收
+
+	conn, err := net.Dial("tcp", "127.0.0.1:8090")
+	a.NotError(err).NotNil(conn)
+	defer conn.Close()
+
+	resp := &rejectResponse{}
+	a.NotError(jsonrpc.NewSocketTransport(true, conn, time.Second).Read(resp))
+	a.NotNil(resp.Error).Equal(resp.Error.Code, ErrInternalError)
+
+	cancel()
+	<-srvExit
 }
@@ -7,6 +7,19 @@ import (
 	"github.com/caixw/apidoc/v7/internal/ast"
 )
 
+// 通过 workspace/executeCommand 可执行的命令
+//
+// 命令的第一个参数可以指定项目文件夹的 URI，若未指定，则作用于所有的项目文件夹。
+const (
+	CommandRebuild     = "apidoc.rebuild"      // 重新解析项目并下发诊断信息
+	CommandCheckSyntax = "apidoc.check-syntax" // 检测语法，返回错误信息的数量
+)
+
+// Commands 返回所有可用的命令
+func Commands() []string {
+	return []string{CommandRebuild, CommandCheckSyntax}
+}
+
 // APIDocDetectParams apidoc/detect 的请求参数
 type APIDocDetectParams struct {
 	// The text document.
@@ -5,7 +5,7 @@ package protocol
 // SetTraceParams.Value 可用的值
 const (
 	TraceValueOff     = "off"
-	TraceValueMessage = "message"
+	TraceValueMessage = "messages"
 	TraceValueVerbose = "verbose"
 )
 
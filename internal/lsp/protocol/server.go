@@ -40,6 +40,9 @@ type ServerCapabilities struct {
 	// The server provides workspace symbol support.
 	WorkspaceSymbolProvider bool `json:"workspaceSymbolProvider,omitempty"`
 
+	// The server provides execute command support.
+	ExecuteCommandProvider *ExecuteCommandOptions `json:"executeCommandProvider,omitempty"`
+
 	// Workspace specific server capabilities
 	Workspace *WorkspaceProvider `json:"workspace,omitempty"`
 
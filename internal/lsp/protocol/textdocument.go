@@ -89,6 +89,9 @@ type ServerCapabilitiesTextDocumentSyncOptions struct {
 	// Change notifications are sent to the server. See TextDocumentSyncKind.None, TextDocumentSyncKind.Full
 	// and TextDocumentSyncKind.Incremental. If omitted it defaults to TextDocumentSyncKind.None.
 	Change TextDocumentSyncKind `json:"change,omitempty"`
+
+	// If present save notifications are sent to the server. If omitted the notification should not be sent.
+	Save *SaveOptions `json:"save,omitempty"`
 }
 
 // TextDocumentRegistrationOptions General text document registration options
@@ -181,3 +184,13 @@ type TextDocumentContentChangeEvent struct {
 	// @deprecated use range instead.
 	RangeLength int `json:"rangeLength,omitempty"`
 }
+
+// DidSaveTextDocumentParams textDocument/didSave 的参数
+type DidSaveTextDocumentParams struct {
+	// The document that was saved.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+
+	// Optional the content when saved. Depends on the includeText value
+	// when the save notification was requested.
+	Text *string `json:"text,omitempty"`
+}
@@ -76,6 +76,34 @@ type WorkspaceClientCapabilities struct {
 	//
 	// Since 3.6.0
 	Configuration bool `json:"configuration,omitempty"`
+
+	// Capabilities specific to the `workspace/executeCommand` request.
+	ExecuteCommand *ExecuteCommandClientCapabilities `json:"executeCommand,omitempty"`
+}
+
+// ExecuteCommandClientCapabilities 客户端有关 workspace/executeCommand 的支持情况
+type ExecuteCommandClientCapabilities struct {
+	// Execute command supports dynamic registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// ExecuteCommandOptions 服务端有关 workspace/executeCommand 的支持情况
+type ExecuteCommandOptions struct {
+	WorkDoneProgressOptions
+
+	// The commands to be executed on the server
+	Commands []string `json:"commands"`
+}
+
+// ExecuteCommandParams workspace/executeCommand 的参数
+type ExecuteCommandParams struct {
+	WorkDoneProgressParams
+
+	// The identifier of the actual command handler.
+	Command string `json:"command"`
+
+	// Arguments that the command should be invoked with.
+	Arguments []interface{} `json:"arguments,omitempty"`
 }
 
 // WorkspaceProvider 服务端有关 workspace 的支持情况
@@ -4,7 +4,9 @@ package lsp
 
 import (
 	"context"
+	"encoding/json"
 	"log"
+	"reflect"
 	"strings"
 	"sync"
 
@@ -30,6 +32,7 @@ type server struct {
 	state        serverState
 	trace        string
 	stateMux     sync.RWMutex
+	traceMux     sync.RWMutex
 	workspaceMux sync.RWMutex
 
 	folders []*folder
@@ -44,12 +47,12 @@ func newServe(t jsonrpc.Transport, infolog, errlog *log.Logger) *server {
 	jsonrpcServer := jsonrpc.NewServer()
 
 	srv := &server{
-		Conn:  jsonrpcServer.NewConn(t, errlog),
 		state: serverCreated,
 		trace: protocol.TraceValueOff,
 		info:  infolog,
 		erro:  errlog,
 	}
+	srv.Conn = jsonrpcServer.NewConn(&traceTransport{Transport: t, srv: srv}, errlog)
 
 	jsonrpcServer.Registers(map[string]interface{}{
 		"initialize":      srv.initialize,
@@ -61,9 +64,11 @@ func newServe(t jsonrpc.Transport, infolog, errlog *log.Logger) *server {
 
 		// workspace
 		"workspace/didChangeWorkspaceFolders": srv.workspaceDidChangeWorkspaceFolders,
+		"workspace/executeCommand":            srv.workspaceExecuteCommand,
 
 		// textDocument
 		"textDocument/didChange":      srv.textDocumentDidChange,
+		"textDocument/didSave":        srv.textDocumentDidSave,
 		"textDocument/hover":          srv.textDocumentHover,
 		"textDocument/foldingRange":   srv.textDocumentFoldingRange,
 		"textDocument/completion":     srv.textDocumentCompletion,
@@ -83,8 +88,8 @@ func newServe(t jsonrpc.Transport, infolog, errlog *log.Logger) *server {
 	return srv
 }
 
-func (s *server) serve() error {
-	ctx, cancel := context.WithCancel(context.Background())
+func (s *server) serve(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
 	s.cancelFunc = cancel
 	return s.Serve(ctx)
 }
@@ -105,6 +110,9 @@ func (s *server) getState() serverState {
 //
 // https://microsoft.github.io/language-server-protocol/specifications/specification-current/#setTrace
 func (s *server) setTrace(notify bool, in *protocol.SetTraceParams, out *interface{}) error {
+	s.traceMux.Lock()
+	defer s.traceMux.Unlock()
+
 	if protocol.IsValidTraceValue(in.Value) {
 		s.trace = in.Value
 		return nil
@@ -114,11 +122,81 @@ func (s *server) setTrace(notify bool, in *protocol.SetTraceParams, out *interfa
 	return newError(ErrInvalidParams, locale.ErrInvalidValue)
 }
 
+func (s *server) getTrace() string {
+	s.traceMux.RLock()
+	defer s.traceMux.RUnlock()
+	return s.trace
+}
+
+// 根据 trace 的值将 v 通过 $/logTrace 发送给客户端
+//
+// verbose 发送完整的内容，messages 仅发送方法名，off 不发送任何内容。
+// 不能输出到 s.info，stdio 模式下 s.info 可能与传输数据共用同一个输出。
+func (s *server) traceMessage(v interface{}) {
+	trace := s.getTrace()
+	if trace == protocol.TraceValueOff {
+		return
+	}
+
+	method := messageMethod(v)
+	if method == "$/logTrace" { // 防止无限递归
+		return
+	}
+
+	var verbose string
+	if trace == protocol.TraceValueVerbose {
+		data, err := json.Marshal(v)
+		if err != nil {
+			s.erro.Println(err)
+			return
+		}
+		verbose = string(data)
+	} else if method == "" { // messages 模式下忽略没有方法名的返回内容
+		return
+	}
+
+	s.logTrace(method, verbose)
+}
+
+// 获取 jsonrpc 传递内容的方法名
+//
+// v 为 jsonrpc 内部的请求对象，只能通过反射获取其 Method 字段。
+func messageMethod(v interface{}) string {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+
+	if f := rv.FieldByName("Method"); f.IsValid() && f.Kind() == reflect.String {
+		return f.String()
+	}
+	return ""
+}
+
+// 对 jsonrpc.Transport 的封装，根据 server.trace 输出传递的内容。
+type traceTransport struct {
+	jsonrpc.Transport
+	srv *server
+}
+
+func (t *traceTransport) Read(v interface{}) error {
+	if err := t.Transport.Read(v); err != nil {
+		return err
+	}
+	t.srv.traceMessage(v)
+	return nil
+}
+
+func (t *traceTransport) Write(v interface{}) error {
+	t.srv.traceMessage(v)
+	return t.Transport.Write(v)
+}
+
 // $/logTrace
 //
 // https://microsoft.github.io/language-server-protocol/specifications/specification-current/#logTrace
 func (s *server) logTrace(message, verbose string) {
-	if p := protocol.BuildLogTrace(s.trace, message, verbose); p != nil {
+	if p := protocol.BuildLogTrace(s.getTrace(), message, verbose); p != nil {
 		if err := s.Notify("$/logTrace", p); err != nil {
 			s.erro.Println(err)
 		}
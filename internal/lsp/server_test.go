@@ -3,6 +3,7 @@
 package lsp
 
 import (
+	"bytes"
 	"io/ioutil"
 	"log"
 	"os"
@@ -34,3 +35,52 @@ func TestServer_setTrace(t *testing.T) {
 	err = s.setTrace(false, &protocol.SetTraceParams{Value: protocol.TraceValueVerbose}, nil)
 	a.NotError(err).Equal(s.trace, protocol.TraceValueVerbose)
 }
+
+func TestServer_traceMessage(t *testing.T) {
+	a := assert.New(t, false)
+	info := new(bytes.Buffer)
+	out := new(bytes.Buffer)
+	s := newServe(jsonrpc.NewStreamTransport(false, new(bytes.Buffer), out, nil), log.New(info, "", 0), log.New(ioutil.Discard, "", 0))
+	msg := &struct {
+		Version string            `json:"jsonrpc"`
+		Method  string            `json:"method"`
+		Params  map[string]string `json:"params"`
+	}{Version: "2.0", Method: "initialize", Params: map[string]string{"k": "v"}}
+
+	// 默认为 off
+	s.traceMessage(msg)
+	a.Empty(out.String())
+
+	a.NotError(s.setTrace(true, &protocol.SetTraceParams{Value: protocol.TraceValueMessage}, nil))
+	s.traceMessage(msg)
+	a.Equal(out.String(), `{"jsonrpc":"2.0","method":"$/logTrace","params":{"message":"initialize"}}`)
+
+	// 没有方法名的内容
+	out.Reset()
+	s.traceMessage(&struct{ ID int }{ID: 1})
+	a.Empty(out.String())
+
+	a.NotError(s.setTrace(true, &protocol.SetTraceParams{Value: protocol.TraceValueVerbose}, nil))
+	out.Reset()
+	s.traceMessage(msg)
+	a.Contains(out.String(), `"method":"$/logTrace"`).
+		Contains(out.String(), `"message":"initialize"`).
+		Contains(out.String(), `"verbose":"{\"jsonrpc\":\"2.0\",\"method\":\"initialize\",\"params\":{\"k\":\"v\"}}"`)
+
+	a.NotError(s.setTrace(true, &protocol.SetTraceParams{Value: protocol.TraceValueOff}, nil))
+	out.Reset()
+	s.traceMessage(msg)
+	a.Empty(out.String())
+
+	a.Empty(info.String()) // 不会输出到 s.info
+}
+
+func TestMessageMethod(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(messageMethod(&struct{ Method string }{Method: "m1"}), "m1").
+		Equal(messageMethod(struct{ Method string }{Method: "m2"}), "m2").
+		Equal(messageMethod(&struct{ ID int }{}), "").
+		Equal(messageMethod(nil), "").
+		Equal(messageMethod(5), "")
+}
@@ -3,7 +3,9 @@
 package lsp
 
 import (
+	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/issue9/sliceutil"
 
@@ -17,7 +19,21 @@ import (
 // textDocument/didChange
 //
 // https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_didChange
+//
+// 修改内容并不会立即处理，而是加入到项目的队列中，由项目串行处理。
 func (s *server) textDocumentDidChange(notify bool, in *protocol.DidChangeTextDocumentParams, out *interface{}) error {
+	if f := s.findFolder(in.TextDocument.URI); f != nil {
+		f.enqueue(in)
+	}
+	return nil
+}
+
+// textDocument/didSave
+//
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_didSave
+//
+// 与 didChange 不同，保存之后会立即从磁盘读取文件内容并重新解析，忽略客户端提供的内容。
+func (s *server) textDocumentDidSave(notify bool, in *protocol.DidSaveTextDocumentParams, out *interface{}) error {
 	f := s.findFolder(in.TextDocument.URI)
 	if f == nil {
 		return nil
@@ -26,34 +42,42 @@ func (s *server) textDocumentDidChange(notify bool, in *protocol.DidChangeTextDo
 	f.parsedMux.Lock()
 	defer f.parsedMux.Unlock()
 
-	if !deleteURI(f.doc, in.TextDocument.URI) {
+	if f.cfg == nil { // 配置文件加载失败
 		return nil
 	}
 
+	deleteURI(f.doc, in.TextDocument.URI)
 	f.clearDiagnostics()
-	for _, blk := range in.Blocks() {
-		f.parseBlock(blk)
-	}
-	f.srv.textDocumentPublishDiagnostics(f)
+	f.parseFile(in.TextDocument.URI)
+	s.textDocumentPublishDiagnostics(f)
 
 	return nil
 }
 
 func (f *folder) parseBlock(block core.Block) {
-	var input *build.Input
-	ext := filepath.Ext(block.Location.URI.String())
-	for _, i := range f.cfg.Inputs {
-		if sliceutil.Count(i.Exts, func(index string) bool { return index == ext }) > 0 {
-			input = i
-			break
-		}
+	input := f.findInput(block.Location.URI)
+	if input == nil { // 无需解析
+		return
+	}
+
+	f.doc.ParseBlocks(f.h, func(blocks chan core.Block) {
+		lang.Parse(f.h, input.Lang, input.LangVersion, block, blocks)
+	})
+
+	if err := f.srv.apidocOutline(f); err != nil {
+		f.srv.printErr(err)
 	}
+}
+
+// 从磁盘读取 uri 的内容并解析
+func (f *folder) parseFile(uri core.URI) {
+	input := f.findInput(uri)
 	if input == nil { // 无需解析
 		return
 	}
 
 	f.doc.ParseBlocks(f.h, func(blocks chan core.Block) {
-		lang.Parse(f.h, input.Lang, block, blocks)
+		input.ParseFile(blocks, f.h, uri)
 	})
 
 	if err := f.srv.apidocOutline(f); err != nil {
@@ -61,6 +85,17 @@ func (f *folder) parseBlock(block core.Block) {
 	}
 }
 
+// 根据扩展名查找 uri 对应的 build.Input
+func (f *folder) findInput(uri core.URI) *build.Input {
+	ext := strings.ToLower(filepath.Ext(uri.String()))
+	for _, i := range f.cfg.Inputs {
+		if sliceutil.Count(i.Exts, func(index string) bool { return index == ext }) > 0 {
+			return i
+		}
+	}
+	return nil
+}
+
 func deleteURI(doc *ast.APIDoc, uri core.URI) (deleted bool) {
 	l := len(doc.APIs)
 	doc.APIs = sliceutil.Delete(doc.APIs, func(i *ast.API) bool {
@@ -81,23 +116,55 @@ func deleteURI(doc *ast.APIDoc, uri core.URI) (deleted bool) {
 //
 // https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_publishDiagnostics
 func (s *server) textDocumentPublishDiagnostics(f *folder) {
+	f.diagnosticsMux.Lock()
+	params := make([]*protocol.PublishDiagnosticsParams, 0, len(f.diagnostics))
 	for _, p := range f.diagnostics {
+		params = append(params, uniqueDiagnostics(p))
+	}
+	f.diagnosticsMux.Unlock()
+
+	for _, p := range params {
 		if err := s.Notify("textDocument/publishDiagnostics", p); err != nil {
 			s.erro.Println(err)
 		}
 	}
 }
 
+// 返回去掉了重复内容的诊断信息
+//
+// uri、range 和 message 都相同的诊断信息被视为重复，只保留第一次出现的内容。
+// 同一文件在短时间内被多次解析时，可能会产生重复的诊断信息。
+func uniqueDiagnostics(p *protocol.PublishDiagnosticsParams) *protocol.PublishDiagnosticsParams {
+	exists := make(map[string]struct{}, len(p.Diagnostics))
+	diagnostics := make([]protocol.Diagnostic, 0, len(p.Diagnostics))
+	for _, d := range p.Diagnostics {
+		r := d.Range
+		key := fmt.Sprintf("%s|%d:%d-%d:%d|%s", p.URI, r.Start.Line, r.Start.Character, r.End.Line, r.End.Character, d.Message)
+		if _, found := exists[key]; found {
+			continue
+		}
+		exists[key] = struct{}{}
+		diagnostics = append(diagnostics, d)
+	}
+
+	ret := *p
+	ret.Diagnostics = diagnostics
+	return &ret
+}
+
 // 清空所有的诊断信息
 func (f *folder) clearDiagnostics() {
-	for _, p := range f.diagnostics {
-		p.Diagnostics = p.Diagnostics[:0]
+	f.diagnosticsMux.Lock()
+	diagnostics := f.diagnostics
+	f.diagnostics = make(map[core.URI]*protocol.PublishDiagnosticsParams, 0)
+	f.diagnosticsMux.Unlock()
+
+	for uri := range diagnostics {
+		p := protocol.NewPublishDiagnosticsParams(uri)
 		if err := f.srv.Notify("textDocument/publishDiagnostics", p); err != nil {
 			f.srv.erro.Println(err)
 		}
 	}
-
-	f.diagnostics = make(map[core.URI]*protocol.PublishDiagnosticsParams, 0)
 }
 
 // textDocument/foldingRange
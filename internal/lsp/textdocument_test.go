@@ -7,8 +7,10 @@ import (
 	"log"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/issue9/assert/v2"
+	"github.com/issue9/sliceutil"
 
 	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/core/messagetest"
@@ -38,17 +40,93 @@ func TestServer_textDocumentDidChange(t *testing.T) {
 		},
 	)
 
+	f := s.folders[0]
+	total := len(f.doc.APIs)
 	changeFile := core.FileURI(filepath.Join(path, "apis.cpp"))
+	count := func() int {
+		f.parsedMux.RLock()
+		defer f.parsedMux.RUnlock()
+		return sliceutil.Count(f.doc.APIs, func(api *ast.API) bool { return api.URI == changeFile })
+	}
+	apis := count()
+	a.True(apis > 0)
+
+	change := func(text string) {
+		err := s.textDocumentDidChange(true, &protocol.DidChangeTextDocumentParams{
+			TextDocument: protocol.VersionedTextDocumentIdentifier{
+				TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: changeFile},
+			},
+			ContentChanges: []protocol.TextDocumentContentChangeEvent{
+				{Text: text},
+			},
+		}, nil)
+		a.NotError(err)
+	}
+
 	text, err := changeFile.ReadAll(nil)
-	err = s.textDocumentDidChange(true, &protocol.DidChangeTextDocumentParams{
-		TextDocument: protocol.VersionedTextDocumentIdentifier{
-			TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: changeFile},
-		},
-		ContentChanges: []protocol.TextDocumentContentChangeEvent{
-			{Text: string(text)},
+	a.NotError(err)
+
+	// 连续的修改只处理最后一次，且在 changeDelay 之后才会处理。
+	change("")
+	change(string(text))
+	a.Equal(count(), apis)
+	time.Sleep(changeDelay * 3)
+	a.Equal(count(), apis).Equal(len(f.doc.APIs), total)
+
+	// 关闭时会处理完队列中的内容
+	change("")
+	f.close()
+	a.Equal(count(), 0).Equal(len(f.doc.APIs), total-apis)
+
+	// 关闭之后的修改会被丢弃
+	change(string(text))
+	a.Equal(count(), 0)
+}
+
+func TestServer_textDocumentDidSave(t *testing.T) {
+	a := assert.New(t, false)
+	s := newTestServer(true, log.New(ioutil.Discard, "", 0), log.New(ioutil.Discard, "", 0))
+	err := s.textDocumentDidSave(true, &protocol.DidSaveTextDocumentParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: "not-exists"},
+	}, nil)
+	a.NotError(err)
+
+	path, err := filepath.Abs("../../docs/example")
+	a.NotError(err)
+	path = filepath.FromSlash(path)
+
+	s.appendFolders(
+		protocol.WorkspaceFolder{
+			URI:  core.FileURI(path),
+			Name: "example",
 		},
+	)
+
+	f := s.folders[0]
+	defer f.close()
+	total := len(f.doc.APIs)
+	saveFile := core.FileURI(filepath.Join(path, "apis.cpp"))
+	count := func() int {
+		f.parsedMux.RLock()
+		defer f.parsedMux.RUnlock()
+		return sliceutil.Count(f.doc.APIs, func(api *ast.API) bool { return api.URI == saveFile })
+	}
+	apis := count()
+	a.True(apis > 0)
+
+	f.parsedMux.Lock()
+	a.True(deleteURI(f.doc, saveFile))
+	f.parsedMux.Unlock()
+	a.Equal(count(), 0)
+
+	// 立即从磁盘读取内容，忽略客户端提供的内容。
+	text := ""
+	err = s.textDocumentDidSave(true, &protocol.DidSaveTextDocumentParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: saveFile},
+		Text:         &text,
 	}, nil)
 	a.NotError(err)
+	a.Equal(count(), apis).Equal(len(f.doc.APIs), total)
 }
 
 func TestDeleteURI(t *testing.T) {
@@ -129,3 +207,32 @@ func TestServer_textDocumentFoldingRange(t *testing.T) {
 		},
 	})
 }
+
+func TestUniqueDiagnostics(t *testing.T) {
+	a := assert.New(t, false)
+
+	r1 := core.Range{End: core.Position{Line: 1, Character: 2}}
+	r2 := core.Range{End: core.Position{Line: 2, Character: 1}}
+	p := &protocol.PublishDiagnosticsParams{
+		URI: "uri",
+		Diagnostics: []protocol.Diagnostic{
+			{Range: r1, Message: "msg1"},
+			{Range: r1, Message: "msg1"}, // 重复
+			{Range: r1, Message: "msg2"},
+			{Range: r2, Message: "msg1"},
+			{Range: r2, Message: "msg1"}, // 重复
+		},
+	}
+
+	ret := uniqueDiagnostics(p)
+	a.Equal(ret.URI, p.URI).
+		Equal(ret.Diagnostics, []protocol.Diagnostic{
+			{Range: r1, Message: "msg1"},
+			{Range: r1, Message: "msg2"},
+			{Range: r2, Message: "msg1"},
+		}).
+		Equal(5, len(p.Diagnostics)) // 不会改变原始数据
+
+	p = &protocol.PublishDiagnosticsParams{URI: "uri"}
+	a.Empty(uniqueDiagnostics(p).Diagnostics)
+}
@@ -5,6 +5,7 @@ package lsp
 import (
 	"github.com/issue9/sliceutil"
 
+	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/internal/locale"
 	"github.com/caixw/apidoc/v7/internal/lsp/protocol"
 )
@@ -54,3 +55,62 @@ func (s *server) workspaceDidChangeWorkspaceFolders(notify bool, in *protocol.Di
 
 	return nil
 }
+
+// workspace/executeCommand
+//
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#workspace_executeCommand
+func (s *server) workspaceExecuteCommand(notify bool, in *protocol.ExecuteCommandParams, out *interface{}) error {
+	if s.getState() != serverInitialized {
+		return newError(ErrInvalidRequest, locale.ErrInvalidLSPState)
+	}
+
+	folders, err := s.commandFolders(in.Arguments)
+	if err != nil {
+		return err
+	}
+
+	switch in.Command {
+	case protocol.CommandRebuild:
+		for _, f := range folders {
+			f.parsedMux.Lock()
+			f.refresh(true)
+			f.parsedMux.Unlock()
+		}
+	case protocol.CommandCheckSyntax:
+		var count int
+		for _, f := range folders {
+			f.parsedMux.RLock()
+			count += f.checkSyntax()
+			f.parsedMux.RUnlock()
+		}
+		*out = count
+	default:
+		return newError(ErrInvalidParams, locale.UnimplementedRPC, in.Command)
+	}
+
+	return nil
+}
+
+// 根据命令的参数获取需要操作的项目文件夹
+//
+// 第一个参数表示项目文件夹的 URI，未指定则返回所有的项目文件夹。
+func (s *server) commandFolders(args []interface{}) ([]*folder, error) {
+	if len(args) == 0 {
+		s.workspaceMux.RLock()
+		defer s.workspaceMux.RUnlock()
+
+		folders := make([]*folder, len(s.folders))
+		copy(folders, s.folders)
+		return folders, nil
+	}
+
+	uri, ok := args[0].(string)
+	if !ok {
+		return nil, newError(ErrInvalidParams, locale.ErrInvalidValue)
+	}
+
+	if f := s.findFolder(core.URI(uri)); f != nil {
+		return []*folder{f}, nil
+	}
+	return nil, nil
+}
@@ -5,12 +5,17 @@ package lsp
 import (
 	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/issue9/assert/v2"
 	"github.com/issue9/jsonrpc"
 
+	"github.com/caixw/apidoc/v7/core"
 	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/docs"
 	"github.com/caixw/apidoc/v7/internal/lsp/protocol"
 )
 
@@ -58,3 +63,102 @@ func TestServer_workspaceDidChangeWorkspaceFolders(t *testing.T) {
 	a.NotError(s.workspaceDidChangeWorkspaceFolders(false, in, nil))
 	a.Equal(2, len(s.folders))
 }
+
+// 各个项目根据各自的配置文件独立解析
+func TestServer_workspaceDidChangeWorkspaceFolders_config(t *testing.T) {
+	a := assert.New(t, false)
+
+	const (
+		goSource  = "// <apidoc version=\"1.0.0\"><title>go</title><mimetype>application/json</mimetype></apidoc>\n"
+		cppSource = "// <api method=\"not-exists\" summary=\"cpp\"><path path=\"/cpp\" /></api>\n"
+	)
+	newFolder := func(name, lang string) protocol.WorkspaceFolder {
+		dir := t.TempDir()
+		cfg := "version: " + ast.Version + "\ninputs:\n- lang: " + lang + "\n  dir: .\noutput:\n  path: ./apidoc.xml\n"
+		a.NotError(os.WriteFile(filepath.Join(dir, ".apidoc.yaml"), []byte(cfg), os.ModePerm))
+		a.NotError(os.WriteFile(filepath.Join(dir, "main.go"), []byte(goSource), os.ModePerm))
+		a.NotError(os.WriteFile(filepath.Join(dir, "main.cpp"), []byte(cppSource), os.ModePerm))
+		return protocol.WorkspaceFolder{Name: name, URI: core.FileURI(dir)}
+	}
+
+	s := newTestServer(true, log.New(ioutil.Discard, "", 0), log.New(ioutil.Discard, "", 0))
+	s.setState(serverInitialized)
+	in := &protocol.DidChangeWorkspaceFoldersParams{
+		Event: protocol.WorkspaceFoldersChangeEvent{
+			Added: []protocol.WorkspaceFolder{newFolder("go", "go"), newFolder("cpp", "c++")},
+		},
+	}
+	a.NotError(s.workspaceDidChangeWorkspaceFolders(false, in, nil))
+	a.Equal(2, len(s.folders))
+
+	goFolder, cppFolder := s.folders[0], s.folders[1]
+	defer goFolder.close()
+	defer cppFolder.close()
+
+	// 结束 messageHandler，保证诊断信息已经全部处理。
+	for _, f := range []*folder{goFolder, cppFolder} {
+		f.h.Stop()
+		f.h = nil
+	}
+
+	a.NotNil(goFolder.cfg).NotNil(cppFolder.cfg).
+		Equal(goFolder.cfg.Inputs[0].Lang, "go").
+		Equal(cppFolder.cfg.Inputs[0].Lang, "c++")
+
+	a.Equal(goFolder.doc.Title.V(), "go").
+		Empty(goFolder.doc.APIs).
+		Empty(goFolder.diagnostics)
+
+	a.Nil(cppFolder.doc.Title).
+		Length(cppFolder.doc.APIs, 1).
+		Length(cppFolder.diagnostics, 1)
+	for uri, p := range cppFolder.diagnostics {
+		a.Equal(uri, cppFolder.URI.Append("main.cpp")).NotEmpty(p.Diagnostics)
+	}
+}
+
+func TestServer_workspaceExecuteCommand(t *testing.T) {
+	a := assert.New(t, false)
+
+	s := newTestServer(true, log.New(ioutil.Discard, "", 0), log.New(ioutil.Discard, "", 0))
+	in := &protocol.ExecuteCommandParams{Command: protocol.CommandRebuild}
+	var out interface{}
+	err := s.workspaceExecuteCommand(false, in, &out)
+	a.Error(err)
+	jerr, ok := err.(*jsonrpc.Error)
+	a.True(ok).Equal(jerr.Code, ErrInvalidRequest)
+
+	s = newTestServer(true, log.New(ioutil.Discard, "", 0), log.New(ioutil.Discard, "", 0))
+	s.setState(serverInitialized)
+	uri := docs.Dir().Append("example")
+	s.appendFolders(protocol.WorkspaceFolder{Name: "example", URI: uri})
+	a.Equal(1, len(s.folders))
+
+	// 不存在的命令
+	in = &protocol.ExecuteCommandParams{Command: "not-exists"}
+	err = s.workspaceExecuteCommand(false, in, &out)
+	a.Error(err)
+	jerr, ok = err.(*jsonrpc.Error)
+	a.True(ok).Equal(jerr.Code, ErrInvalidParams)
+
+	// 无效的参数
+	in = &protocol.ExecuteCommandParams{Command: protocol.CommandRebuild, Arguments: []interface{}{1}}
+	err = s.workspaceExecuteCommand(false, in, &out)
+	a.Error(err)
+	jerr, ok = err.(*jsonrpc.Error)
+	a.True(ok).Equal(jerr.Code, ErrInvalidParams)
+
+	in = &protocol.ExecuteCommandParams{Command: protocol.CommandRebuild, Arguments: []interface{}{string(uri)}}
+	a.NotError(s.workspaceExecuteCommand(false, in, &out))
+	a.NotNil(s.folders[0].cfg).Nil(s.folders[0].loadError)
+
+	in = &protocol.ExecuteCommandParams{Command: protocol.CommandCheckSyntax}
+	a.NotError(s.workspaceExecuteCommand(false, in, &out))
+	a.Equal(out, 0)
+
+	// 不存在的项目文件夹
+	out = nil
+	in = &protocol.ExecuteCommandParams{Command: protocol.CommandCheckSyntax, Arguments: []interface{}{"file:///not-exists"}}
+	a.NotError(s.workspaceExecuteCommand(false, in, &out))
+	a.Equal(out, 0)
+}
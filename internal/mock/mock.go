@@ -4,6 +4,7 @@
 package mock
 
 import (
+	"context"
 	"image"
 	"image/gif"
 	"image/jpeg"
@@ -81,8 +82,10 @@ func New(msg *core.MessageHandler, d *ast.APIDoc, indent, imageURL string, serve
 }
 
 // Load 从本地或是远程加载文档内容
-func Load(h *core.MessageHandler, path core.URI, indent, imageURL string, servers map[string]string, gen *GenOptions) (http.Handler, error) {
-	data, err := path.ReadAll(nil)
+//
+// ctx 用于读取远程文档，可以通过 core.WithRetryOptions 指定重试设置。
+func Load(ctx context.Context, h *core.MessageHandler, path core.URI, indent, imageURL string, servers map[string]string, gen *GenOptions) (http.Handler, error) {
+	data, err := path.ReadAllContext(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@ package mock
 
 import (
 	"bytes"
+	"context"
 	"image"
 	"image/jpeg"
 	"image/png"
@@ -741,13 +742,13 @@ func TestNew(t *testing.T) {
 func TestLoad(t *testing.T) {
 	a := assert.New(t, false)
 	rslt := messagetest.NewMessageHandler()
-	mock, err := Load(rslt.Handler, "./not-exists", indent, "/images", nil, testOptions)
+	mock, err := Load(context.Background(), rslt.Handler, "./not-exists", indent, "/images", nil, testOptions)
 	rslt.Handler.Stop()
 	a.Error(err).Nil(mock)
 
 	// LoadFromPath
 	rslt = messagetest.NewMessageHandler()
-	mock, err = Load(rslt.Handler, asttest.URI(a), indent, "/images", map[string]string{"admin": "/admin"}, testOptions)
+	mock, err = Load(context.Background(), rslt.Handler, asttest.URI(a), indent, "/images", map[string]string{"admin": "/admin"}, testOptions)
 	rslt.Handler.Stop()
 	a.NotError(err).NotNil(mock)
 
@@ -757,7 +758,7 @@ func TestLoad(t *testing.T) {
 	defer srv.Close()
 
 	rslt = messagetest.NewMessageHandler()
-	mock, err = Load(rslt.Handler, core.URI(srv.URL+"/index.xml"), indent, "/images", map[string]string{"admin": "/admin"}, testOptions)
+	mock, err = Load(context.Background(), rslt.Handler, core.URI(srv.URL+"/index.xml"), indent, "/images", map[string]string{"admin": "/admin"}, testOptions)
 	rslt.Handler.Stop()
 	a.NotError(err).NotNil(mock)
 }
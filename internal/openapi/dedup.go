@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+// 将结构完全相同的对象类型移至 components.schemas，并以 $ref 的形式引用
+//
+// 只有包含子元素的对象类型，且出现了两次及以上的才会被提取，
+// 名称优先采用对应参数的名称，不存在时则根据其内容生成。
+func deduplicateSchemas(openapi *OpenAPI) {
+	d := &deduplicator{
+		openapi: openapi,
+		counts:  make(map[string]int, 10),
+		refs:    make(map[string]string, 10),
+	}
+
+	// 保证生成的组件名称在每次输出时都是相同的
+	paths := make([]string, 0, len(openapi.Paths))
+	for p := range openapi.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var components []string
+	if openapi.Components != nil {
+		components = sortedKeys(openapi.Components.Schemas)
+	}
+
+	for _, visit := range []func(*Schema) *Schema{d.count, d.replace} {
+		for _, p := range paths {
+			d.pathItem(openapi.Paths[p], visit)
+		}
+
+		// 已有的组件本身不作处理，只处理其子元素。
+		for _, name := range components {
+			d.children(openapi.Components.Schemas[name], visit)
+		}
+	}
+}
+
+type deduplicator struct {
+	openapi *OpenAPI
+	counts  map[string]int    // 各类型出现的次数
+	refs    map[string]string // 已经提取的类型对应的 $ref 值
+}
+
+func (d *deduplicator) pathItem(p *PathItem, visit func(*Schema) *Schema) {
+	d.params(p.Parameters, visit)
+
+	for _, o := range []*Operation{p.Get, p.Put, p.Post, p.Delete, p.Options, p.Head, p.Patch, p.Trace} {
+		if o == nil {
+			continue
+		}
+
+		d.params(o.Parameters, visit)
+		if o.RequestBody != nil {
+			d.content(o.RequestBody.Content, visit)
+		}
+
+		for _, status := range sortedKeys(o.Responses) {
+			resp := o.Responses[status]
+			for _, name := range sortedKeys(resp.Headers) {
+				if h := resp.Headers[name]; h.Schema != nil {
+					h.Schema = visit(h.Schema)
+				}
+			}
+			d.content(resp.Content, visit)
+		}
+	}
+}
+
+func (d *deduplicator) params(params []*Parameter, visit func(*Schema) *Schema) {
+	for _, p := range params {
+		if p.Schema != nil {
+			p.Schema = visit(p.Schema)
+		}
+		d.content(p.Content, visit)
+	}
+}
+
+func (d *deduplicator) content(content map[string]*MediaType, visit func(*Schema) *Schema) {
+	for _, mimetype := range sortedKeys(content) {
+		if mt := content[mimetype]; mt.Schema != nil {
+			mt.Schema = visit(mt.Schema)
+		}
+	}
+}
+
+func (d *deduplicator) children(s *Schema, visit func(*Schema) *Schema) {
+	if s.Items != nil {
+		s.Items = visit(s.Items)
+	}
+	for _, name := range sortedKeys(s.Properties) {
+		s.Properties[name] = visit(s.Properties[name])
+	}
+	for _, list := range [][]*Schema{s.AllOf, s.AnyOf, s.OneOf} {
+		for index, item := range list {
+			list[index] = visit(item)
+		}
+	}
+}
+
+// 统计各类型出现的次数
+//
+// 重复出现的类型不再统计其子元素，子元素仅在第一次出现时统计，
+// 这样只在某一重复类型中出现的子元素不会被单独提取。
+func (d *deduplicator) count(s *Schema) *Schema {
+	key, ok := schemaKey(s)
+	if ok {
+		d.counts[key]++
+		if d.counts[key] > 1 {
+			return s
+		}
+	}
+
+	d.children(s, d.count)
+	return s
+}
+
+// 将重复出现的类型替换为 $ref
+func (d *deduplicator) replace(s *Schema) *Schema {
+	key, ok := schemaKey(s)
+	if !ok || d.counts[key] < 2 {
+		d.children(s, d.replace)
+		return s
+	}
+
+	ref, found := d.refs[key]
+	if !found {
+		var name string
+		if s.XML != nil {
+			name = s.XML.Name
+		}
+		if name == "" {
+			name = "schema-" + key[:8]
+		}
+
+		d.children(s, d.replace)
+		ref = schemaRefPrefix + addSchemaComponent(d.openapi, name, s)
+		d.refs[key] = ref
+	}
+	return &Schema{Ref: ref}
+}
+
+// 根据 s 的内容生成唯一的键值
+//
+// 只有包含子元素的对象类型才会返回 true。
+func schemaKey(s *Schema) (string, bool) {
+	if s.Ref != "" || len(s.Properties) == 0 {
+		return "", false
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), true
+}
+
+// 将 s 添加至 components.schemas，返回其在 components.schemas 中的名称。
+//
+// 如果 name 已经存在，会在末尾添加数字加以区分。
+func addSchemaComponent(openapi *OpenAPI, name string, s *Schema) string {
+	if openapi.Components == nil {
+		openapi.Components = &Components{}
+	}
+	if openapi.Components.Schemas == nil {
+		openapi.Components.Schemas = make(map[string]*Schema, 5)
+	}
+
+	n := name
+	for i := 1; ; i++ {
+		if _, found := openapi.Components.Schemas[n]; !found {
+			break
+		}
+		n = name + "-" + strconv.Itoa(i)
+	}
+
+	openapi.Components.Schemas[n] = s
+	return n
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+)
+
+func TestDeduplicateSchemas(t *testing.T) {
+	a := assert.New(t, false)
+
+	newPage := func() *Schema {
+		return &Schema{
+			XML: &XML{Name: "page"},
+			Properties: map[string]*Schema{
+				"page": {Type: TypeLong},
+				"size": {Type: TypeLong},
+			},
+		}
+	}
+	newUser := func() *Schema {
+		return &Schema{Properties: map[string]*Schema{
+			"id":   {Type: TypeLong},
+			"page": newPage(),
+		}}
+	}
+
+	users := newUser()
+	admins := newUser()
+	page := newPage()
+	single := &Schema{Properties: map[string]*Schema{"name": {Type: TypeString}}}
+	list := &Schema{Type: TypeArray, Items: newUser()}
+	openapi := &OpenAPI{Paths: map[string]*PathItem{
+		"/users": {
+			Get: &Operation{Responses: map[string]*Response{
+				"200": {Content: map[string]*MediaType{"application/json": {Schema: users}}},
+			}},
+			Post: &Operation{RequestBody: &RequestBody{
+				Content: map[string]*MediaType{"application/json": {Schema: single}},
+			}},
+		},
+		"/admins": {
+			Get: &Operation{Responses: map[string]*Response{
+				"200": {Content: map[string]*MediaType{"application/json": {Schema: admins}}},
+			}},
+			Parameters: []*Parameter{{Schema: page}},
+		},
+		"/list": {Get: &Operation{Responses: map[string]*Response{
+			"200": {Content: map[string]*MediaType{"application/json": {Schema: list}}},
+		}}},
+	}}
+	deduplicateSchemas(openapi)
+
+	schemas := openapi.Components.Schemas
+	a.Equal(2, len(schemas)).
+		NotNil(schemas["page"])
+
+	// 没有名称的类型，根据内容生成名称
+	var userRef string
+	for name := range schemas {
+		if name != "page" {
+			userRef = schemaRefPrefix + name
+			a.Equal(len(name), len("schema-")+8)
+		}
+	}
+	content := func(path string) *Schema {
+		return openapi.Paths[path].Get.Responses["200"].Content["application/json"].Schema
+	}
+	a.Equal(content("/users"), &Schema{Ref: userRef}).
+		Equal(content("/admins"), &Schema{Ref: userRef}).
+		Equal(content("/list").Items, &Schema{Ref: userRef}).
+		Equal(content("/list").Type, TypeArray)
+
+	// 组件中的子元素同样被替换
+	a.Equal(schemas[userRef[len(schemaRefPrefix):]].Properties["page"], &Schema{Ref: schemaRefPrefix + "page"}).
+		Equal(openapi.Paths["/admins"].Parameters[0].Schema, &Schema{Ref: schemaRefPrefix + "page"})
+
+	// 只出现一次的不提取
+	a.Equal(openapi.Paths["/users"].Post.RequestBody.Content["application/json"].Schema, single)
+
+	// 仅在重复的类型中出现的子元素不提取
+	inner := &Schema{Properties: map[string]*Schema{"id": {Type: TypeLong}}}
+	newOuter := func() *Schema {
+		return &Schema{Properties: map[string]*Schema{"inner": inner, "name": {Type: TypeString}}}
+	}
+	openapi = &OpenAPI{Paths: map[string]*PathItem{
+		"/a": {Parameters: []*Parameter{{Schema: newOuter()}}},
+		"/b": {Parameters: []*Parameter{{Schema: newOuter()}}},
+	}}
+	deduplicateSchemas(openapi)
+	a.Equal(1, len(openapi.Components.Schemas))
+	for _, s := range openapi.Components.Schemas {
+		a.Equal(s.Properties["inner"], inner)
+	}
+
+	// 没有重复的内容
+	openapi = &OpenAPI{Paths: map[string]*PathItem{
+		"/a": {Parameters: []*Parameter{{Schema: newPage()}}},
+	}}
+	deduplicateSchemas(openapi)
+	a.Nil(openapi.Components)
+}
@@ -19,6 +19,38 @@ import (
 // LatestVersion openapi 最新的版本号
 const LatestVersion = "3.0.3"
 
+// Options 将 ast.APIDoc 转换成 openapi 时的设置项
+type Options struct {
+	// 提取所有服务器地址中共同的路径部分
+	//
+	// 提取的路径会从服务器地址中删除，并添加到每一个 paths 的键名之前。
+	// 如果服务器地址之间不存在共同的路径，则会输出警告信息，并放弃提取。
+	ExtractBasePath bool
+
+	// 为未指定 id 的接口自动生成 operationId
+	//
+	// 由请求方法和路径组成，比如 GET /users/{id} 会生成 getUsersById。
+	GenerateOperationIDs bool
+
+	// 自动生成的 operationId 的命名风格
+	//
+	// 可以是 OperationIDCamel、OperationIDSnake 或 OperationIDKebab，
+	// 为空表示 OperationIDCamel。
+	OperationIDStyle string
+
+	// 将结构完全相同的对象类型提取至 components.schemas
+	//
+	// 多次出现的相同类型，比如多个接口中都用到的分页参数，
+	// 只会在 components.schemas 中定义一次，其它地方以 $ref 的形式引用。
+	DeduplicateSchemas bool
+
+	// 严格模式
+	//
+	// 不输出任何 x- 开头的扩展字段，文档中存在无法以 OpenAPI
+	// 原生字段表示的内容时直接返回错误。
+	Strict bool
+}
+
 // OpenAPI openAPI 的根对象
 type OpenAPI struct {
 	OpenAPI      string                 `json:"openapi" yaml:"openapi"`
@@ -67,6 +99,9 @@ type Tag struct {
 	Name         string                 `json:"name" yaml:"name"`
 	Description  string                 `json:"description,omitempty" yaml:"description,omitempty"`
 	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+
+	// 以 x- 开头的扩展字段，比如 x-deprecated
+	Extensions map[string]string `json:"-" yaml:",inline"`
 }
 
 // Example 示例代码
@@ -82,11 +117,43 @@ type Example struct {
 // ExampleValue 表示示例的内容类型。
 type ExampleValue string
 
+func newExternalDocs(l *ast.Link) *ExternalDocumentation {
+	return &ExternalDocumentation{
+		Description: l.Text.V(),
+		URL:         l.URL.V(),
+	}
+}
+
+// MarshalJSON json.Marshaler
+//
+// 将 Extensions 中的字段与其它字段输出在同一层级。
+func (tag *Tag) MarshalJSON() ([]byte, error) {
+	type tagAlias Tag
+	return marshalJSONWithExtensions((*tagAlias)(tag), tag.Extensions)
+}
+
+// 将 ast.Tag 转换成 Tag
+//
+// openapi 的 Tag 没有弃用和颜色的概念，
+// ast.Tag.Deprecated 和 ast.Tag.Color 的值分别写入 x-deprecated 和 x-color。
 func newTag(tag *ast.Tag) *Tag {
-	return &Tag{
+	t := &Tag{
 		Name:        tag.Name.V(),
 		Description: tag.Title.V(),
 	}
+
+	if tag.Deprecated != nil {
+		t.Extensions = map[string]string{"x-deprecated": tag.Deprecated.V()}
+	}
+
+	if tag.Color != nil {
+		if t.Extensions == nil {
+			t.Extensions = map[string]string{}
+		}
+		t.Extensions["x-color"] = tag.Color.V()
+	}
+
+	return t
 }
 
 func (oa *OpenAPI) sanitize() *core.Error {
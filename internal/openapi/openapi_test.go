@@ -3,10 +3,17 @@
 package openapi
 
 import (
+	"encoding/json"
+	"os"
 	"testing"
 
 	"github.com/issue9/assert/v2"
 	"github.com/issue9/version"
+	"gopkg.in/yaml.v3"
+
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/ast/asttest"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
 )
 
 func TestLatestVersion(t *testing.T) {
@@ -65,3 +72,33 @@ func TestTag_sanitize(t *testing.T) {
 	tag.ExternalDocs.URL = "https://example.com"
 	a.NotError(tag.sanitize())
 }
+
+func TestNewTag(t *testing.T) {
+	a := assert.New(t, false)
+
+	doc := asttest.Get()
+	doc.Tags[2].Deprecated = &ast.VersionAttribute{Value: xmlenc.String{Value: "1.0.1"}}
+	openapi, err := convert(nil, doc, nil)
+	a.NotError(err).NotNil(openapi)
+
+	data, err := json.MarshalIndent(openapi.Tags, "", "\t")
+	a.NotError(err)
+	golden, err := os.ReadFile("./testdata/tags.json")
+	a.NotError(err)
+	a.Equal(string(data)+"\n", string(golden))
+
+	data, err = yaml.Marshal(openapi.Tags[2])
+	a.NotError(err).Equal(string(data), "name: tag1\ndescription: tag1\nx-deprecated: 1.0.1\n")
+
+	// x-color
+	doc = asttest.Get()
+	doc.Tags[0].Color = &ast.Attribute{Value: xmlenc.String{Value: "#f00"}}
+	doc.Tags[2].Color = &ast.Attribute{Value: xmlenc.String{Value: "red"}}
+	doc.Tags[2].Deprecated = &ast.VersionAttribute{Value: xmlenc.String{Value: "1.0.1"}}
+	openapi, err = convert(nil, doc, nil)
+	a.NotError(err).NotNil(openapi)
+	data, err = yaml.Marshal(openapi.Tags[0])
+	a.NotError(err).Equal(string(data), "name: t1\ndescription: t1\nx-color: '#f00'\n")
+	data, err = yaml.Marshal(openapi.Tags[2])
+	a.NotError(err).Equal(string(data), "name: tag1\ndescription: tag1\nx-color: red\nx-deprecated: 1.0.1\n")
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/caixw/apidoc/v7/internal/ast"
+)
+
+// Options.OperationIDStyle 的可选值
+const (
+	OperationIDCamel = "camel" // getUsersById
+	OperationIDSnake = "snake" // get_users_by_id
+	OperationIDKebab = "kebab" // get-users-by-id
+)
+
+// IsOperationIDStyle 是否为合法的 operationId 命名风格
+//
+// 空值表示采用默认的 OperationIDCamel，也是合法的。
+func IsOperationIDStyle(style string) bool {
+	switch style {
+	case "", OperationIDCamel, OperationIDSnake, OperationIDKebab:
+		return true
+	default:
+		return false
+	}
+}
+
+// 为所有未指定 operationId 的接口生成 operationId
+//
+// 由请求方法和路径组成，路径参数 {id} 会被转换成 by id，
+// 比如 GET /users/{id} 会生成 getUsersById。
+// 与已有的值重复时，会在末尾添加数字加以区分。
+func generateOperationIDs(openapi *OpenAPI, d *ast.APIDoc, style string) {
+	ids := make(map[string]struct{}, len(d.APIs))
+	for _, api := range d.APIs {
+		if op := getOperation(openapi, api); op != nil && op.OperationID != "" {
+			ids[op.OperationID] = struct{}{}
+		}
+	}
+
+	for _, api := range d.APIs {
+		op := getOperation(openapi, api)
+		if op == nil || op.OperationID != "" {
+			continue
+		}
+
+		words := operationIDWords(api.Method.V(), api.Path.Path.V())
+		id := joinOperationID(style, words)
+		for i := 2; ; i++ {
+			if _, found := ids[id]; !found {
+				break
+			}
+			id = joinOperationID(style, append(words, strconv.Itoa(i)))
+		}
+
+		ids[id] = struct{}{}
+		op.OperationID = id
+	}
+}
+
+func getOperation(openapi *OpenAPI, api *ast.API) *Operation {
+	p := openapi.Paths[api.Path.Path.V()]
+	if p == nil {
+		return nil
+	}
+
+	switch strings.ToUpper(api.Method.V()) {
+	case "GET":
+		return p.Get
+	case "DELETE":
+		return p.Delete
+	case "POST":
+		return p.Post
+	case "PUT":
+		return p.Put
+	case "PATCH":
+		return p.Patch
+	case "OPTIONS":
+		return p.Options
+	case "HEAD":
+		return p.Head
+	case "TRACE":
+		return p.Trace
+	default:
+		return nil
+	}
+}
+
+// 将请求方法和路径拆分成小写的单词列表
+func operationIDWords(method, path string) []string {
+	words := []string{strings.ToLower(method)}
+
+	isSep := func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+	for _, seg := range strings.Split(path, "/") {
+		if len(seg) > 2 && seg[0] == '{' && seg[len(seg)-1] == '}' {
+			words = append(words, "by")
+		}
+
+		for _, w := range strings.FieldsFunc(seg, isSep) {
+			words = append(words, strings.ToLower(w))
+		}
+	}
+
+	return words
+}
+
+func joinOperationID(style string, words []string) string {
+	switch style {
+	case OperationIDSnake:
+		return strings.Join(words, "_")
+	case OperationIDKebab:
+		return strings.Join(words, "-")
+	default:
+		var b strings.Builder
+		for i, w := range words {
+			if i > 0 && w != "" {
+				r := []rune(w)
+				r[0] = unicode.ToUpper(r[0])
+				w = string(r)
+			}
+			b.WriteString(w)
+		}
+		return b.String()
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/ast/asttest"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func newOperationIDDoc() *ast.APIDoc {
+	newAPI := func(method, path, id string) *ast.API {
+		api := &ast.API{
+			Method: &ast.MethodAttribute{Value: xmlenc.String{Value: method}},
+			Path:   &ast.Path{Path: &ast.Attribute{Value: xmlenc.String{Value: path}}},
+		}
+		if id != "" {
+			api.ID = &ast.Attribute{Value: xmlenc.String{Value: id}}
+		}
+		return api
+	}
+
+	return &ast.APIDoc{APIs: []*ast.API{
+		newAPI("GET", "/users/{id}", ""),
+		newAPI("GET", "/users/by/id", ""),
+		newAPI("POST", "/users", "getUsers"),
+		newAPI("GET", "/users", ""),
+		newAPI("DELETE", "/user_groups/{group-id}/", ""),
+		newAPI("PUT", "/", ""),
+	}}
+}
+
+func TestGenerateOperationIDs(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		style string
+		ids   []string
+	}{
+		{
+			style: "",
+			ids:   []string{"getUsersById", "getUsersById2", "getUsers", "getUsers2", "deleteUserGroupsByGroupId", "put"},
+		},
+		{
+			style: OperationIDCamel,
+			ids:   []string{"getUsersById", "getUsersById2", "getUsers", "getUsers2", "deleteUserGroupsByGroupId", "put"},
+		},
+		{
+			style: OperationIDSnake,
+			ids:   []string{"get_users_by_id", "get_users_by_id_2", "getUsers", "get_users", "delete_user_groups_by_group_id", "put"},
+		},
+		{
+			style: OperationIDKebab,
+			ids:   []string{"get-users-by-id", "get-users-by-id-2", "getUsers", "get-users", "delete-user-groups-by-group-id", "put"},
+		},
+	}
+
+	for _, item := range data {
+		d := newOperationIDDoc()
+		openapi := &OpenAPI{Paths: map[string]*PathItem{}}
+		a.NotError(parsePaths(openapi, d))
+		generateOperationIDs(openapi, d, item.style)
+
+		for i, api := range d.APIs {
+			op := getOperation(openapi, api)
+			a.NotNil(op).Equal(op.OperationID, item.ids[i], "style=%s,index=%d", item.style, i)
+		}
+	}
+
+	a.True(IsOperationIDStyle("")).
+		True(IsOperationIDStyle(OperationIDKebab)).
+		False(IsOperationIDStyle("pascal"))
+}
+
+func TestJSON_GenerateOperationIDs(t *testing.T) {
+	a := assert.New(t, false)
+
+	// 默认不生成
+	data, err := JSON(nil, asttest.Get(), nil)
+	a.NotError(err).NotNil(data)
+	openapi := &OpenAPI{}
+	a.NotError(json.Unmarshal(data, openapi))
+	a.Empty(openapi.Paths["/users"].Get.OperationID)
+
+	data, err = JSON(nil, asttest.Get(), &Options{GenerateOperationIDs: true, OperationIDStyle: OperationIDSnake})
+	a.NotError(err).NotNil(data)
+	openapi = &OpenAPI{}
+	a.NotError(json.Unmarshal(data, openapi))
+	a.Equal(openapi.Paths["/users"].Get.OperationID, "get_users").
+		Equal(openapi.Paths["/users"].Post.OperationID, "post_users")
+}
@@ -15,7 +15,19 @@ import (
 )
 
 // 将 doc.APIDoc 转换成 openapi
-func convert(doc *ast.APIDoc) (*OpenAPI, error) {
+//
+// 转换过程中的警告信息会输出至 h。
+func convert(h *core.MessageHandler, doc *ast.APIDoc, o *Options) (*OpenAPI, error) {
+	if o == nil {
+		o = &Options{}
+	}
+
+	if o.Strict {
+		if err := checkStrict(doc); err != nil {
+			return nil, err
+		}
+	}
+
 	langID := doc.Lang.V()
 	if langID == "" {
 		langID = "und"
@@ -39,6 +51,10 @@ func convert(doc *ast.APIDoc) (*OpenAPI, error) {
 		},
 	}
 
+	if doc.ExternalDocs != nil {
+		openapi.ExternalDocs = newExternalDocs(doc.ExternalDocs)
+	}
+
 	for _, srv := range doc.Servers {
 		openapi.Servers = append(openapi.Servers, newServer(srv))
 	}
@@ -51,6 +67,18 @@ func convert(doc *ast.APIDoc) (*OpenAPI, error) {
 		return nil, err
 	}
 
+	if o.GenerateOperationIDs {
+		generateOperationIDs(openapi, doc, o.OperationIDStyle)
+	}
+
+	if o.ExtractBasePath {
+		extractBasePath(h, openapi, doc)
+	}
+
+	if o.DeduplicateSchemas {
+		deduplicateSchemas(openapi)
+	}
+
 	if err := openapi.sanitize(); err != nil {
 		return nil, err
 	}
@@ -87,8 +115,18 @@ func parsePaths(openapi *OpenAPI, d *ast.APIDoc) *core.Error {
 		if api.Description != nil {
 			operation.Description = api.Description.V()
 		}
+		if api.ExternalDocs != nil {
+			operation.ExternalDocs = newExternalDocs(api.ExternalDocs)
+		}
 		setOperationParams(d, operation, api)
 
+		if len(api.Extensions) > 0 {
+			operation.Extensions = make(map[string]string, len(api.Extensions))
+			for _, ext := range api.Extensions {
+				operation.Extensions[ext.Name.V()] = ext.Value.V()
+			}
+		}
+
 		// servers
 		// 不为 PathItem 设置 servers，直接写在 operation
 		operation.Servers = make([]*Server, 0, len(api.Servers))
@@ -124,10 +162,12 @@ func parsePaths(openapi *OpenAPI, d *ast.APIDoc) *core.Error {
 					}
 				}
 
-				content[r.Mimetype.V()] = &MediaType{
-					Schema:   newSchemaFromRequest(d, r, true),
-					Examples: examples,
-				}
+				setContent(content, d, r, func() *MediaType {
+					return &MediaType{
+						Schema:   newSchemaFromRequest(d, r, true),
+						Examples: examples,
+					}
+				})
 			}
 
 			operation.RequestBody = &RequestBody{
@@ -136,18 +176,22 @@ func parsePaths(openapi *OpenAPI, d *ast.APIDoc) *core.Error {
 		}
 
 		// responses
+		//
+		// 相同状态码的多个 response 合并为一个 Response 对象，各自的 mimetype 写入 Content。
 		operation.Responses = make(map[string]*Response, len(api.Responses))
 		for _, resp := range api.Responses {
 			status := strconv.Itoa(resp.Status.V())
 			r, found := operation.Responses[status]
 			if !found {
 				r = &Response{
-					Description: getDescription(resp.Description, resp.Summary),
-					Headers:     make(map[string]*Header, 10),
-					Content:     make(map[string]*MediaType, 10),
+					Headers: make(map[string]*Header, 10),
+					Content: make(map[string]*MediaType, 10),
 				}
 				operation.Responses[status] = r
 			}
+			if r.Description == "" { // 采用第一个不为空的描述内容
+				r.Description = getDescription(resp.Description, resp.Summary)
+			}
 
 			for _, h := range resp.Headers {
 				r.Headers[h.Name.V()] = &Header{
@@ -163,16 +207,36 @@ func parsePaths(openapi *OpenAPI, d *ast.APIDoc) *core.Error {
 					Value:   ExampleValue(exp.Content.Value.Value),
 				}
 			}
-			r.Content[resp.Mimetype.V()] = &MediaType{
-				Schema:   newSchemaFromRequest(d, resp, true),
-				Examples: examples,
-			}
+			setContent(r.Content, d, resp, func() *MediaType {
+				return &MediaType{
+					Schema:   newSchemaFromRequest(d, resp, true),
+					Examples: examples,
+				}
+			})
 		}
 	} // end for doc.Apis
 
 	return nil
 }
 
+// 将 r 的内容写入 content
+//
+// 如果 r 指定了 mimetype，则只写入该 mimetype，且会覆盖已有的值；
+// 否则为 d.Mimetypes 中的每一个 mimetype 写入一份内容，
+// 但不会覆盖其它 request 明确指定了 mimetype 的值。
+func setContent(content map[string]*MediaType, d *ast.APIDoc, r *ast.Request, newMediaType func() *MediaType) {
+	if mimetype := r.Mimetype.V(); mimetype != "" || len(d.Mimetypes) == 0 {
+		content[mimetype] = newMediaType()
+		return
+	}
+
+	for _, mt := range d.Mimetypes {
+		if _, found := content[mt.V()]; !found {
+			content[mt.V()] = newMediaType()
+		}
+	}
+}
+
 func setOperationParams(doc *ast.APIDoc, operation *Operation, api *ast.API) {
 	l := len(api.Path.Params) + len(api.Path.Queries)
 	operation.Parameters = make([]*Parameter, 0, l)
@@ -272,8 +336,10 @@ func setOperation(path *PathItem, method string) (*Operation, *core.Error) {
 }
 
 // JSON 输出 JSON 格式数据
-func JSON(doc *ast.APIDoc) ([]byte, error) {
-	openapi, err := convert(doc)
+//
+// o 为转换时的设置项，可以为空；转换过程中的警告信息会输出至 h，h 为空则忽略警告信息。
+func JSON(h *core.MessageHandler, doc *ast.APIDoc, o *Options) ([]byte, error) {
+	openapi, err := convert(h, doc, o)
 	if err != nil {
 		return nil, err
 	}
@@ -282,8 +348,10 @@ func JSON(doc *ast.APIDoc) ([]byte, error) {
 }
 
 // YAML 输出 YAML 格式数据
-func YAML(doc *ast.APIDoc) ([]byte, error) {
-	openapi, err := convert(doc)
+//
+// o 为转换时的设置项，可以为空；转换过程中的警告信息会输出至 h，h 为空则忽略警告信息。
+func YAML(h *core.MessageHandler, doc *ast.APIDoc, o *Options) ([]byte, error) {
+	openapi, err := convert(h, doc, o)
 	if err != nil {
 		return nil, err
 	}
@@ -6,17 +6,21 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/issue9/assert/v2"
 
 	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/ast/asttest"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
 )
 
 func TestJSON(t *testing.T) {
 	a := assert.New(t, false)
-	data, err := JSON(asttest.Get())
+	data, err := JSON(nil, asttest.Get(), nil)
 	a.NotError(err).NotNil(data)
 
 	openapi := &OpenAPI{}
@@ -38,8 +42,215 @@ func TestJSON(t *testing.T) {
 	a.Equal(len(get.Responses[strconv.Itoa(http.StatusOK)].Headers), 1)
 }
 
+func TestJSON_extensions(t *testing.T) {
+	a := assert.New(t, false)
+
+	doc := asttest.Get()
+	doc.APIs[0].Extensions = []*ast.Extension{{
+		Name:  &ast.Attribute{Value: xmlenc.String{Value: "x-internal"}},
+		Value: &ast.Attribute{Value: xmlenc.String{Value: "true"}},
+	}}
+	data, err := JSON(nil, doc, nil)
+	a.NotError(err).NotNil(data)
+
+	paths := map[string]map[string]map[string]interface{}{}
+	a.NotError(json.Unmarshal(data, &struct {
+		Paths interface{} `json:"paths"`
+	}{Paths: &paths}))
+	method := strings.ToLower(doc.APIs[0].Method.V())
+	a.Equal(paths[doc.APIs[0].Path.Path.V()][method]["x-internal"], "true")
+}
+
+func TestJSON_contactAndLicense(t *testing.T) {
+	a := assert.New(t, false)
+
+	doc := asttest.Get()
+	doc.Contact = &ast.Contact{
+		Name:  &ast.Attribute{Value: xmlenc.String{Value: "name"}},
+		URL:   &ast.Element{Content: ast.Content{Value: "https://example.com"}},
+		Email: &ast.Element{Content: ast.Content{Value: "user@example.com"}},
+	}
+	doc.License = &ast.Link{
+		Text: &ast.Attribute{Value: xmlenc.String{Value: "MIT"}},
+		URL:  &ast.Attribute{Value: xmlenc.String{Value: "https://opensource.org/licenses/MIT"}},
+	}
+	data, err := JSON(nil, doc, nil)
+	a.NotError(err).NotNil(data)
+
+	info := map[string]interface{}{}
+	a.NotError(json.Unmarshal(data, &struct {
+		Info interface{} `json:"info"`
+	}{Info: &info}))
+	a.Equal(info["contact"], map[string]interface{}{
+		"name":  "name",
+		"url":   "https://example.com",
+		"email": "user@example.com",
+	}).Equal(info["license"], map[string]interface{}{
+		"name": "MIT",
+		"url":  "https://opensource.org/licenses/MIT",
+	})
+
+	// 未指定 contact 和 license
+	doc = asttest.Get()
+	doc.Contact = nil
+	doc.License = nil
+	data, err = JSON(nil, doc, nil)
+	a.NotError(err).NotNil(data)
+	info = map[string]interface{}{}
+	a.NotError(json.Unmarshal(data, &struct {
+		Info interface{} `json:"info"`
+	}{Info: &info}))
+	_, found := info["contact"]
+	a.False(found)
+	_, found = info["license"]
+	a.False(found)
+}
+
 func TestYAML(t *testing.T) {
 	a := assert.New(t, false)
-	data, err := YAML(asttest.Get())
+	data, err := YAML(nil, asttest.Get(), nil)
 	a.NotError(err).NotNil(data)
 }
+
+func TestJSON_ExtractBasePath(t *testing.T) {
+	a := assert.New(t, false)
+
+	// asttest 中的服务器地址不存在共同路径
+	rslt := messagetest.NewMessageHandler()
+	data, err := JSON(rslt.Handler, asttest.Get(), &Options{ExtractBasePath: true})
+	rslt.Handler.Stop()
+	a.NotError(err).NotNil(data).
+		Equal(1, len(rslt.Warns))
+
+	openapi := &OpenAPI{}
+	a.NotError(json.Unmarshal(data, openapi))
+	a.NotNil(openapi.Paths["/users"])
+}
+
+func TestJSON_ExternalDocs(t *testing.T) {
+	a := assert.New(t, false)
+
+	newLink := func(text, url string) *ast.Link {
+		return &ast.Link{
+			Text: &ast.Attribute{Value: xmlenc.String{Value: text}},
+			URL:  &ast.Attribute{Value: xmlenc.String{Value: url}},
+		}
+	}
+
+	doc := asttest.Get()
+	doc.License = newLink("MIT", "https://opensource.org/licenses/MIT")
+	doc.ExternalDocs = newLink("docs", "https://example.com/docs")
+	doc.APIs[0].ExternalDocs = newLink("RFC 7231", "https://tools.ietf.org/html/rfc7231")
+	data, err := JSON(nil, doc, nil)
+	a.NotError(err).NotNil(data)
+
+	openapi := &OpenAPI{}
+	a.NotError(json.Unmarshal(data, openapi))
+	a.Equal(openapi.Info.License, &License{Name: "MIT", URL: "https://opensource.org/licenses/MIT"}).
+		Equal(openapi.ExternalDocs, &ExternalDocumentation{Description: "docs", URL: "https://example.com/docs"})
+
+	op := openapi.Paths["/users"].Get
+	a.Equal(op.ExternalDocs, &ExternalDocumentation{Description: "RFC 7231", URL: "https://tools.ietf.org/html/rfc7231"})
+	a.Nil(openapi.Paths["/users"].Post.ExternalDocs)
+
+	// 无效的 URL
+	doc = asttest.Get()
+	doc.APIs[0].ExternalDocs = newLink("RFC 7231", "not-url")
+	data, err = JSON(nil, doc, nil)
+	a.Error(err).Nil(data)
+
+	// 未指定 externalDocs，采用默认的链接
+	data, err = JSON(nil, asttest.Get(), nil)
+	a.NotError(err).NotNil(data)
+	openapi = &OpenAPI{}
+	a.NotError(json.Unmarshal(data, openapi))
+	a.Equal(openapi.ExternalDocs.URL, core.OfficialURL)
+}
+
+// 相同状态码但 mimetype 不同的 response 合并为同一个 Response 对象
+func TestJSON_responses(t *testing.T) {
+	a := assert.New(t, false)
+
+	doc := asttest.Get()
+	api := doc.APIs[0]
+	api.Responses = []*ast.Request{
+		{
+			Type:     &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeString}},
+			Status:   &ast.StatusAttribute{Value: ast.Number{Int: http.StatusOK}},
+			Mimetype: &ast.Attribute{Value: xmlenc.String{Value: "text/plain"}},
+		},
+		{
+			Summary:  &ast.Attribute{Value: xmlenc.String{Value: "json"}},
+			Type:     &ast.TypeAttribute{Value: xmlenc.String{Value: ast.TypeBool}},
+			Status:   &ast.StatusAttribute{Value: ast.Number{Int: http.StatusOK}},
+			Mimetype: &ast.Attribute{Value: xmlenc.String{Value: "application/json"}},
+		},
+	}
+	data, err := JSON(nil, doc, nil)
+	a.NotError(err).NotNil(data)
+
+	openapi := &OpenAPI{}
+	a.NotError(json.Unmarshal(data, openapi))
+	responses := openapi.Paths[api.Path.Path.V()].Get.Responses
+	a.Length(responses, 1)
+
+	ok := responses[strconv.Itoa(http.StatusOK)]
+	a.NotNil(ok).
+		Equal(ok.Description, "json").
+		Length(ok.Content, 2).
+		Equal(ok.Content["text/plain"].Schema.Type, TypeString).
+		Equal(ok.Content["application/json"].Schema.Type, TypeBool)
+}
+
+func TestJSON_mimetypes(t *testing.T) {
+	a := assert.New(t, false)
+
+	newResponse := func(status int, mimetype string, typ string) *ast.Request {
+		r := &ast.Request{
+			Summary: &ast.Attribute{Value: xmlenc.String{Value: "summary"}},
+			Type:    &ast.TypeAttribute{Value: xmlenc.String{Value: typ}},
+			Status:  &ast.StatusAttribute{Value: ast.Number{Int: status}},
+		}
+		if mimetype != "" {
+			r.Mimetype = &ast.Attribute{Value: xmlenc.String{Value: mimetype}}
+		}
+		return r
+	}
+
+	doc := asttest.Get()
+	api := doc.APIs[0]
+	api.Requests = []*ast.Request{newResponse(0, "", ast.TypeString)}
+	api.Responses = []*ast.Request{
+		newResponse(http.StatusOK, "", ast.TypeBool),
+		newResponse(http.StatusOK, "application/json", ast.TypeString), // 覆盖全局的 application/json
+		newResponse(http.StatusNotFound, "text/plain", ast.TypeString),
+		newResponse(http.StatusNotFound, "", ast.TypeNumber), // 不覆盖 text/plain
+	}
+	openapi, err := convert(nil, doc, nil)
+	a.NotError(err).NotNil(openapi)
+	op := openapi.Paths[api.Path.Path.V()].Get
+
+	// 未指定 mimetype 的 request 采用全局的 mimetype
+	a.Equal(2, len(op.RequestBody.Content)).
+		NotNil(op.RequestBody.Content["application/json"]).
+		NotNil(op.RequestBody.Content["application/xml"])
+
+	ok := op.Responses[strconv.Itoa(http.StatusOK)].Content
+	a.Equal(2, len(ok)).
+		Equal(ok["application/json"].Schema.Type, TypeString).
+		Equal(ok["application/xml"].Schema.Type, TypeBool)
+
+	notFound := op.Responses[strconv.Itoa(http.StatusNotFound)].Content
+	a.Equal(3, len(notFound)).
+		Equal(notFound["text/plain"].Schema.Type, TypeString).
+		Equal(notFound["application/json"].Schema.Type, TypeDouble).
+		Equal(notFound["application/xml"].Schema.Type, TypeDouble)
+
+	// 全局 mimetype 为空
+	doc.Mimetypes = nil
+	doc.APIs[0].Responses = []*ast.Request{newResponse(http.StatusOK, "", ast.TypeString)}
+	openapi, err = convert(nil, doc, nil)
+	a.NotError(err).NotNil(openapi)
+	ok = openapi.Paths[api.Path.Path.V()].Get.Responses[strconv.Itoa(http.StatusOK)].Content
+	a.Equal(1, len(ok)).NotNil(ok[""])
+}
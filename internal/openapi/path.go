@@ -3,6 +3,7 @@
 package openapi
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/caixw/apidoc/v7/core"
@@ -40,6 +41,9 @@ type Operation struct {
 	Deprecated   bool                   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 	Security     []*SecurityRequirement `json:"security,omitempty" yaml:"security,omitempty"`
 	Servers      []*Server              `json:"servers,omitempty" yaml:"servers,omitempty"`
+
+	// 以 x- 开头的扩展字段，输出时与其它字段处于同一层级。
+	Extensions map[string]string `json:"-" yaml:",inline"`
 }
 
 // RequestBody 请求内容
@@ -81,6 +85,35 @@ type Response struct {
 	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 }
 
+// MarshalJSON json.Marshaler
+//
+// 将 Extensions 中的字段与其它字段输出在同一层级。
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	return marshalJSONWithExtensions((*operation)(o), o.Extensions)
+}
+
+// 将 v 转换成 JSON，并将 ext 中的字段与 v 的字段输出在同一层级。
+//
+// v 本身不能实现 json.Marshaler，否则会无限递归。
+func marshalJSONWithExtensions(v interface{}, ext map[string]string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(ext) == 0 {
+		return data, err
+	}
+
+	fields := make(map[string]json.RawMessage, 10+len(ext))
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for k, v := range ext {
+		if fields[k], err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
 func (path *PathItem) sanitize() *core.Error {
 	var o *Operation
 	var method string
@@ -149,6 +182,13 @@ func (o *Operation) sanitize() *core.Error {
 		}
 	}
 
+	if o.ExternalDocs != nil {
+		if err := o.ExternalDocs.sanitize(); err != nil {
+			err.Field = "externalDocs." + err.Field
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"gopkg.in/yaml.v3"
+)
+
+func TestOperation_Marshal(t *testing.T) {
+	a := assert.New(t, false)
+
+	o := &Operation{Summary: "summary"}
+	data, err := json.Marshal(o)
+	a.NotError(err).Equal(string(data), `{"summary":"summary","responses":null}`)
+
+	o.Extensions = map[string]string{"x-internal": "true", "x-rate-limit": "100"}
+	data, err = json.Marshal(o)
+	a.NotError(err).
+		Equal(string(data), `{"responses":null,"summary":"summary","x-internal":"true","x-rate-limit":"100"}`)
+
+	data, err = yaml.Marshal(o)
+	a.NotError(err)
+	m := map[string]interface{}{}
+	a.NotError(yaml.Unmarshal(data, m)).
+		Equal(m["summary"], "summary").
+		Equal(m["x-internal"], "true").
+		Equal(m["x-rate-limit"], "100")
+}
@@ -33,10 +33,40 @@ func newServer(srv *ast.Server) *Server {
 		desc = srv.Description.V()
 	}
 
-	return &Server{
+	s := &Server{
 		URL:         srv.URL.V(),
 		Description: desc,
 	}
+
+	if len(srv.Variables) > 0 {
+		s.Variables = make(map[string]*ServerVariable, len(srv.Variables))
+		for _, v := range srv.Variables {
+			s.Variables[v.Name.V()] = newServerVariable(v)
+		}
+	}
+
+	return s
+}
+
+func newServerVariable(v *ast.ServerVariable) *ServerVariable {
+	desc := v.Summary.V()
+	if v.Description != nil && v.Description.Text != nil {
+		desc = v.Description.V()
+	}
+
+	sv := &ServerVariable{
+		Default:     v.Default.V(),
+		Description: desc,
+	}
+
+	if len(v.Enums) > 0 {
+		sv.Enum = make([]string, 0, len(v.Enums))
+		for _, e := range v.Enums {
+			sv.Enum = append(sv.Enum, e.Value.V())
+		}
+	}
+
+	return sv
 }
 
 func (srv *Server) sanitize() *core.Error {
@@ -83,3 +113,85 @@ func (v *ServerVariable) sanitize() *core.Error {
 
 	return nil
 }
+
+// 提取所有服务器地址中共同的路径部分，并将其添加到 paths 的各个键名之前。
+//
+// h 为空时，不输出警告信息。
+func extractBasePath(h *core.MessageHandler, openapi *OpenAPI, doc *ast.APIDoc) {
+	if len(openapi.Servers) == 0 {
+		return
+	}
+
+	var base []string
+	for index, srv := range openapi.Servers {
+		_, p := splitServerURL(srv.URL)
+		segments := splitPath(p)
+
+		if index == 0 {
+			base = segments
+			continue
+		}
+
+		size := 0
+		for size < len(base) && size < len(segments) && base[size] == segments[size] {
+			size++
+		}
+		if size == 0 && (len(base) > 0 || len(segments) > 0) { // 存在路径但是没有共同的部分
+			if h != nil {
+				h.Warning(doc.Servers[0].Location.NewError(locale.NoCommonBasePath).WithField("servers"))
+			}
+			return
+		}
+		base = base[:size]
+	}
+
+	if len(base) == 0 {
+		return
+	}
+	basePath := "/" + strings.Join(base, "/")
+
+	for _, srv := range openapi.Servers {
+		host, p := splitServerURL(srv.URL)
+		p = strings.TrimPrefix("/"+strings.Join(splitPath(p), "/"), basePath)
+		if p == "" && host == "" {
+			p = "/"
+		}
+		srv.URL = host + p
+	}
+
+	paths := make(map[string]*PathItem, len(openapi.Paths))
+	for k, v := range openapi.Paths {
+		if k == "" || k[0] != '/' {
+			k = "/" + k
+		}
+		paths[basePath+k] = v
+	}
+	openapi.Paths = paths
+}
+
+// 将服务器地址拆分成协议加域名和路径两部分
+//
+// 地址可能包含 {} 模板参数，无法采用 url.Parse 进行解析。
+func splitServerURL(url string) (host, path string) {
+	index := strings.Index(url, "://")
+	if index < 0 {
+		return "", url
+	}
+
+	start := index + 3
+	if index = strings.IndexByte(url[start:], '/'); index < 0 {
+		return url, ""
+	}
+	return url[:start+index], url[start+index:]
+}
+
+func splitPath(path string) []string {
+	segments := strings.Split(path, "/")
+	ret := make([]string, 0, len(segments))
+	for _, seg := range segments {
+		if seg != "" {
+			ret = append(ret, seg)
+		}
+	}
+	return ret
+}
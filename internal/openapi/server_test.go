@@ -7,6 +7,7 @@ import (
 
 	"github.com/issue9/assert/v2"
 
+	"github.com/caixw/apidoc/v7/core/messagetest"
 	"github.com/caixw/apidoc/v7/internal/ast"
 	"github.com/caixw/apidoc/v7/internal/xmlenc"
 )
@@ -29,7 +30,32 @@ func TestNewServer(t *testing.T) {
 	output = newServer(input)
 	a.NotNil(output).
 		Equal(output.URL, "https://example.com").
-		Equal(output.Description, "desc")
+		Equal(output.Description, "desc").
+		Nil(output.Variables)
+
+	input.URL = &ast.Attribute{Value: xmlenc.String{Value: "https://{env}.example.com/{version}"}}
+	input.Variables = []*ast.ServerVariable{
+		{
+			Name:    &ast.Attribute{Value: xmlenc.String{Value: "env"}},
+			Default: &ast.Attribute{Value: xmlenc.String{Value: "api"}},
+			Summary: &ast.Attribute{Value: xmlenc.String{Value: "env"}},
+			Enums: []*ast.Enum{
+				{Value: &ast.Attribute{Value: xmlenc.String{Value: "api"}}},
+				{Value: &ast.Attribute{Value: xmlenc.String{Value: "dev"}}},
+			},
+		},
+		{
+			Name:        &ast.Attribute{Value: xmlenc.String{Value: "version"}},
+			Default:     &ast.Attribute{Value: xmlenc.String{Value: "v1"}},
+			Description: &ast.Richtext{Text: &ast.CData{Value: xmlenc.String{Value: "version desc"}}},
+		},
+	}
+	output = newServer(input)
+	a.NotNil(output).
+		Equal(2, len(output.Variables)).
+		Equal(output.Variables["env"], &ServerVariable{Default: "api", Description: "env", Enum: []string{"api", "dev"}}).
+		Equal(output.Variables["version"], &ServerVariable{Default: "v1", Description: "version desc"})
+	a.NotError(output.sanitize())
 }
 
 func TestServer_sanitize(t *testing.T) {
@@ -75,3 +101,55 @@ func TestServerVariable_sanitize(t *testing.T) {
 	sv.Default = "e1"
 	a.NotError(sv.sanitize())
 }
+
+func TestExtractBasePath(t *testing.T) {
+	a := assert.New(t, false)
+
+	doc := &ast.APIDoc{Servers: []*ast.Server{{}}}
+
+	newOpenAPI := func(urls ...string) *OpenAPI {
+		srvs := make([]*Server, 0, len(urls))
+		for _, url := range urls {
+			srvs = append(srvs, &Server{URL: url})
+		}
+		return &OpenAPI{
+			Servers: srvs,
+			Paths:   map[string]*PathItem{"/users": {}, "/users/{id}": {}},
+		}
+	}
+
+	rslt := messagetest.NewMessageHandler()
+	o := newOpenAPI("https://example.com/api/v1", "https://{domain}/api/v2/", "/api")
+	extractBasePath(rslt.Handler, o, doc)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Warns).
+		Equal(o.Servers[0].URL, "https://example.com/v1").
+		Equal(o.Servers[1].URL, "https://{domain}/v2").
+		Equal(o.Servers[2].URL, "/").
+		Equal(2, len(o.Paths)).
+		NotNil(o.Paths["/api/users"]).
+		NotNil(o.Paths["/api/users/{id}"])
+
+	// 不存在共同路径
+	rslt = messagetest.NewMessageHandler()
+	o = newOpenAPI("https://example.com/api/v1", "https://example.com/v2")
+	extractBasePath(rslt.Handler, o, doc)
+	rslt.Handler.Stop()
+	a.Equal(1, len(rslt.Warns)).
+		Equal(o.Servers[0].URL, "https://example.com/api/v1").
+		NotNil(o.Paths["/users"])
+
+	// h 为空
+	o = newOpenAPI("https://example.com/api/v1", "https://example.com/v2")
+	a.NotPanic(func() { extractBasePath(nil, o, doc) })
+	a.Equal(o.Servers[0].URL, "https://example.com/api/v1")
+
+	// 都不存在路径
+	rslt = messagetest.NewMessageHandler()
+	o = newOpenAPI("https://example.com", "https://example.org/")
+	extractBasePath(rslt.Handler, o, doc)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Warns).
+		Equal(o.Servers[0].URL, "https://example.com").
+		NotNil(o.Paths["/users"])
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"strconv"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/locale"
+)
+
+// 检测 doc 中是否存在只能以 x- 扩展字段表示的内容
+//
+// 严格模式下使用，返回第一个无法以 OpenAPI 原生字段表示的内容。
+func checkStrict(doc *ast.APIDoc) *core.Error {
+	for index, tag := range doc.Tags {
+		field := "tags[" + strconv.Itoa(index) + "]"
+
+		if tag.Deprecated != nil {
+			return tag.Deprecated.NewError(locale.ErrNotSupportedByOpenAPI, "deprecated").WithField(field + ".deprecated")
+		}
+
+		if tag.Color != nil {
+			return tag.Color.NewError(locale.ErrNotSupportedByOpenAPI, "color").WithField(field + ".color")
+		}
+	}
+
+	for index, api := range doc.APIs {
+		if len(api.Extensions) > 0 {
+			field := "apis[" + strconv.Itoa(index) + "].ext"
+			return api.Extensions[0].NewError(locale.ErrNotSupportedByOpenAPI, "ext").WithField(field)
+		}
+	}
+
+	return nil
+}
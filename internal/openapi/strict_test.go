@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/internal/ast"
+	"github.com/caixw/apidoc/v7/internal/ast/asttest"
+	"github.com/caixw/apidoc/v7/internal/xmlenc"
+)
+
+func TestCheckStrict(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Nil(checkStrict(asttest.Get()))
+
+	// tag.deprecated
+	doc := asttest.Get()
+	doc.Tags[1].Deprecated = &ast.VersionAttribute{Value: xmlenc.String{Value: "1.0.0"}}
+	err := checkStrict(doc)
+	a.NotNil(err).Equal(err.Field, "tags[1].deprecated")
+
+	// tag.color
+	doc = asttest.Get()
+	doc.Tags[0].Color = &ast.Attribute{Value: xmlenc.String{Value: "red"}}
+	err = checkStrict(doc)
+	a.NotNil(err).Equal(err.Field, "tags[0].color")
+
+	// api.ext
+	doc = asttest.Get()
+	doc.APIs[1].Extensions = []*ast.Extension{{
+		Name:  &ast.Attribute{Value: xmlenc.String{Value: "x-internal"}},
+		Value: &ast.Attribute{Value: xmlenc.String{Value: "true"}},
+	}}
+	err = checkStrict(doc)
+	a.NotNil(err).Equal(err.Field, "apis[1].ext")
+}
+
+func TestJSON_strict(t *testing.T) {
+	a := assert.New(t, false)
+
+	doc := asttest.Get()
+	data, err := JSON(nil, doc, &Options{Strict: true})
+	a.NotError(err).NotNil(data)
+
+	doc.Tags[0].Color = &ast.Attribute{Value: xmlenc.String{Value: "red"}}
+	data, err = JSON(nil, doc, &Options{Strict: true})
+	a.Error(err).Nil(data)
+
+	// 非严格模式下以 x-color 输出
+	data, err = JSON(nil, doc, nil)
+	a.NotError(err).Contains(string(data), `"x-color"`)
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+
+// Package structtag 从 Go 源码的结构体标签中提取文档内容
+//
+// 标签名为 apidoc，其值由请求方法、路径以及可选的摘要组成，以空格分隔：
+//  type GetUsers struct {
+//      Handler `apidoc:"GET /users 获取用户列表"`
+//  }
+// 会被转换成等价的 <api> 元素：
+//  <api method="GET" summary="获取用户列表"><path path="/users" /></api>
+package structtag
+
+import (
+	"bytes"
+	"encoding/xml"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/locale"
+)
+
+// Key 结构体标签中表示文档内容的键名
+const Key = "apidoc"
+
+type xmlAPI struct {
+	XMLName struct{} `xml:"api"`
+	Method  string   `xml:"method,attr"`
+	Summary string   `xml:"summary,attr,omitempty"`
+	Path    *xmlPath `xml:"path"`
+}
+
+type xmlPath struct {
+	Path string `xml:"path,attr"`
+}
+
+// Blocks 从 Go 源码 data 中提取所有包含 apidoc 键名的结构体标签并转换成 core.Block
+//
+// 如果 data 不是合法的 Go 源码，或是标签的格式不正确，则返回错误信息。
+func Blocks(uri core.URI, data []byte) ([]*core.Block, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, string(uri), data, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, (core.Location{URI: uri}).WithError(err)
+	}
+
+	blocks := make([]*core.Block, 0, 5)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if err != nil {
+			return false
+		}
+
+		field, ok := n.(*ast.Field)
+		if !ok || field.Tag == nil {
+			return true
+		}
+
+		var block *core.Block
+		if block, err = tagBlock(uri, data, fset, field.Tag); block != nil {
+			blocks = append(blocks, block)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+func tagBlock(uri core.URI, data []byte, fset *token.FileSet, lit *ast.BasicLit) (*core.Block, error) {
+	tag, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return nil, nil // 由编译器处理此类错误
+	}
+
+	val, found := reflect.StructTag(tag).Lookup(Key)
+	if !found {
+		return nil, nil
+	}
+
+	loc := core.Location{
+		URI: uri,
+		Range: core.Range{
+			Start: position(data, fset.Position(lit.Pos()).Offset),
+			End:   position(data, fset.Position(lit.End()).Offset),
+		},
+	}
+
+	x, err := ToXML(val)
+	if err != nil {
+		return nil, loc.WithError(err)
+	}
+	return &core.Block{Location: loc, Data: x}, nil
+}
+
+// 将字节偏移量转换成 core.Position
+func position(data []byte, offset int) core.Position {
+	data = data[:offset]
+	start := bytes.LastIndexByte(data, '\n') + 1
+	return core.Position{
+		Line:      bytes.Count(data, []byte{'\n'}),
+		Character: utf8.RuneCount(data[start:]),
+	}
+}
+
+// ToXML 将标签的值转换成 <api> 元素
+func ToXML(val string) ([]byte, error) {
+	fields := strings.Fields(val)
+	if len(fields) < 2 {
+		return nil, locale.NewError(locale.ErrInvalidFormat)
+	}
+
+	return xml.Marshal(&xmlAPI{
+		Method:  strings.ToUpper(fields[0]),
+		Summary: strings.Join(fields[2:], " "),
+		Path:    &xmlPath{Path: fields[1]},
+	})
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+
+package structtag
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+)
+
+func TestToXML(t *testing.T) {
+	a := assert.New(t, false)
+
+	x, err := ToXML("get /users")
+	a.NotError(err).
+		Equal(string(x), `<api method="GET"><path path="/users"></path></api>`)
+
+	x, err = ToXML(" POST  /users  添加 <用户> ")
+	a.NotError(err).
+		Equal(string(x), `<api method="POST" summary="添加 &lt;用户&gt;"><path path="/users"></path></api>`)
+
+	x, err = ToXML("GET")
+	a.Error(err).Nil(x)
+
+	x, err = ToXML("")
+	a.Error(err).Nil(x)
+}
+
+func TestBlocks(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []byte("package main\n\n" +
+		"type T struct {\n" +
+		"\t名称 int `json:\"-\" apidoc:\"GET /users\"`\n" +
+		"\tB  int `json:\"b\"`\n" +
+		"\tC  int\n" +
+		"\tD  struct {\n" +
+		"\t\tE int `apidoc:\"DELETE /users/{id} delete\"`\n" +
+		"\t}\n" +
+		"}\n")
+	blocks, err := Blocks("main.go", data)
+	a.NotError(err).Length(blocks, 2)
+
+	b := blocks[0]
+	a.Equal(b.Location, core.Location{
+		URI: "main.go",
+		Range: core.Range{
+			Start: core.Position{Line: 3, Character: 8},
+			End:   core.Position{Line: 3, Character: 38},
+		},
+	}).Equal(string(b.Data), `<api method="GET"><path path="/users"></path></api>`)
+
+	b = blocks[1]
+	a.Equal(b.Location.Range.Start, core.Position{Line: 7, Character: 8}).
+		Equal(string(b.Data), `<api method="DELETE" summary="delete"><path path="/users/{id}"></path></api>`)
+
+	// 格式错误
+	blocks, err = Blocks("main.go", []byte("package main\ntype T struct{ A int `apidoc:\"GET\"` }"))
+	a.Error(err).Nil(blocks)
+
+	// 语法错误
+	blocks, err = Blocks("main.go", []byte("package main\ntype T struct{"))
+	a.Error(err).Nil(blocks)
+
+	// 没有相关内容
+	blocks, err = Blocks("main.go", []byte("package main\n"))
+	a.NotError(err).Empty(blocks)
+}
@@ -341,22 +341,37 @@ func (d *decoder) decodeSlice(start *StartElement, slice *node.Value) (ok bool)
 // 当 impl 为 true 时，err 表示的是 DecodeXML 接口返回的错误，否则 err 永远为 nil
 func callDecodeXML(v reflect.Value, p *Parser, start *StartElement) (end *EndElement, impl bool, err error) {
 	if v.CanInterface() && v.Type().Implements(decoderType) {
-		if !start.SelfClose {
-			end, err = v.Interface().(Decoder).DecodeXML(p, start)
-		}
+		end, err = decodeXML(v.Interface().(Decoder), p, start)
 		return end, true, err
 	} else if v.CanAddr() {
 		pv := v.Addr()
 		if pv.CanInterface() && pv.Type().Implements(decoderType) {
-			if !start.SelfClose {
-				end, err = pv.Interface().(Decoder).DecodeXML(p, start)
-			}
+			end, err = decodeXML(pv.Interface().(Decoder), p, start)
 			return end, true, err
 		}
 	}
 	return nil, false, nil
 }
 
+// 调用 d.DecodeXML
+//
+// 如果 start 为自闭合元素，则 DecodeXML 中的 Parser.Token 会直接返回一个与 start 相匹配的 EndElement，
+// 不会读取之后的内容。该 EndElement 仅用于 DecodeXML，返回值 end 依然为 nil。
+func decodeXML(d Decoder, p *Parser, start *StartElement) (end *EndElement, err error) {
+	if !start.SelfClose {
+		return d.DecodeXML(p, start)
+	}
+
+	pos := start.Location.Range.End
+	p.pending = &EndElement{
+		Location: core.Location{URI: start.Location.URI, Range: core.Range{Start: pos, End: pos}},
+		Name:     start.Name,
+	}
+	_, err = d.DecodeXML(p, start)
+	p.pending = nil // DecodeXML 未读取该值
+	return nil, err
+}
+
 func (d *decoder) setTagValue(v reflect.Value, usage string, start *StartElement, end *EndElement) {
 	v.Addr().Interface().(tagSetter).setTag(usage, start, end)
 	callSanitizer(v, d.p) // Sanitize 在最后调用，可以确保能取到 v.Range
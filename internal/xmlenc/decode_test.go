@@ -1656,3 +1656,61 @@ func TestDecode_decodeAttributes(t *testing.T) {
 		})
 	})
 }
+
+// 记录 DecodeXML 中读取到的 EndElement
+type endTag struct {
+	BaseTag
+	end *EndElement
+}
+
+func (t *endTag) DecodeXML(p *Parser, start *StartElement) (*EndElement, error) {
+	tok, _, err := p.Token()
+	if err != nil {
+		return nil, err
+	}
+	t.end = tok.(*EndElement)
+	return t.end, nil
+}
+
+func TestDecode_selfClose(t *testing.T) {
+	a := assert.New(t, false)
+
+	v := &struct {
+		BaseTag
+		RootName struct{}  `apidoc:"apidoc,meta,usage-apidoc"`
+		Attr1    intAttr   `apidoc:"attr1,attr,usage"`
+		Elem1    intTag    `apidoc:"elem1,elem,usage"`
+		Elem2    []*endTag `apidoc:"elem2,elem,usage"`
+		Elem3    intTag    `apidoc:"elem3,elem,usage"`
+	}{}
+	b := `<apidoc attr1="5"><elem2 /><elem1 /><elem2></elem2><elem3>7</elem3></apidoc>`
+	rslt := decodeObject(a, b, v, "")
+	a.Empty(rslt.Errors).Empty(rslt.Warns)
+
+	a.Equal(v.Attr1.Value, 5).
+		Equal(v.Elem1.Value, 0).
+		Equal(v.Elem3.Value, 7).
+		Length(v.Elem2, 2)
+
+	// 自闭合元素由 DecodeXML 读取到一个模拟的 EndElement，但不会保存至 EndTag。
+	elem := v.Elem2[0]
+	a.NotNil(elem.end).
+		Equal(elem.end.Name.Local.Value, "elem2").
+		Equal(elem.end.Location.Range, core.Range{
+			Start: core.Position{Character: 27},
+			End:   core.Position{Character: 27},
+		}).
+		Empty(elem.EndTag.Local.Value).
+		Equal(elem.Location.Range, core.Range{
+			Start: core.Position{Character: 18},
+			End:   core.Position{Character: 27},
+		})
+
+	elem = v.Elem2[1]
+	a.NotNil(elem.end).
+		Equal(elem.EndTag.Local.Value, "elem2").
+		Equal(elem.end.Location.Range, core.Range{
+			Start: core.Position{Character: 43},
+			End:   core.Position{Character: 51},
+		})
+}
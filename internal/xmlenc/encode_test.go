@@ -284,6 +284,32 @@ func TestEncode(t *testing.T) {
 			},
 			xml: `<apidoc><object></object></apidoc>`,
 		},
+
+		{ // 命名空间只在根元素中声明
+			object: &struct {
+				RootName string        `apidoc:"apidoc,meta,usage-apidoc"`
+				Objects  []*nestObject `apidoc:"object,elem,usage"`
+			}{
+				Objects: []*nestObject{
+					{ID: &intTag{Value: 1}, Name: stringAttr{Value: "n1"}},
+					{ID: &intTag{Value: 2}},
+				},
+			},
+			namespace: core.XMLNamespace,
+			prefix:    "aa",
+			xml:       `<aa:apidoc xmlns:aa="` + core.XMLNamespace + `"><aa:object aa:name="n1"><aa:id>1</aa:id></aa:object><aa:object><aa:id>2</aa:id></aa:object></aa:apidoc>`,
+		},
+
+		{ // 默认命名空间只在根元素中声明
+			object: &struct {
+				RootName string      `apidoc:"apidoc,meta,usage-apidoc"`
+				Object   *nestObject `apidoc:"object,elem,usage"`
+			}{
+				Object: &nestObject{ID: &intTag{Value: 1}},
+			},
+			namespace: core.XMLNamespace,
+			xml:       `<apidoc xmlns="` + core.XMLNamespace + `"><object><id>1</id></object></apidoc>`,
+		},
 	}
 
 	for i, item := range data {
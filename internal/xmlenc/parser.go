@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"regexp"
 	"unicode"
 	"unicode/utf8"
 
@@ -26,8 +27,25 @@ const (
 type Parser struct {
 	*lexer.Lexer
 	*core.MessageHandler
+
+	// 下一次调用 Token 时直接返回的内容
+	//
+	// 用于在自闭合元素中模拟一个结束元素。
+	pending *EndElement
+
+	recovery     bool
+	skipComments bool
+
+	// 已经声明的命名空间，键名为前缀，默认命名空间的前缀为空字符串。
+	//
+	// 每个未结束的元素对应其中一层，解析起始元素时压入，解析结束元素时弹出，
+	// 第一层为预定义的 xml 前缀。
+	namespaces []map[string]string
 }
 
+// 预定义的 xml 前缀所对应的命名空间
+const xmlPrefixNamespace = "http://www.w3.org/XML/1998/namespace"
+
 // NewParser 声明新的 Parser 实例
 func NewParser(h *core.MessageHandler, b core.Block) (*Parser, error) {
 	l, err := lexer.New(b)
@@ -38,9 +56,23 @@ func NewParser(h *core.MessageHandler, b core.Block) (*Parser, error) {
 	return &Parser{
 		Lexer:          l,
 		MessageHandler: h,
+		namespaces:     []map[string]string{{"xml": xmlPrefixNamespace}},
 	}, nil
 }
 
+// Namespace 返回前缀 prefix 所对应的命名空间
+//
+// 只有在当前位置可见的命名空间才能找到，即已经解析但尚未结束的元素中声明的命名空间，
+// prefix 为空表示默认命名空间。
+func (p *Parser) Namespace(prefix string) (namespace string, found bool) {
+	for i := len(p.namespaces) - 1; i >= 0; i-- {
+		if namespace, found = p.namespaces[i][prefix]; found {
+			return namespace, true
+		}
+	}
+	return "", false
+}
+
 // Token 返回下一个 token 对象
 //
 // token 可能的类型为 *StartElement、*EndElement、*Instruction、*Attribute、*CData、*Comment 和 *String。
@@ -49,6 +81,65 @@ func NewParser(h *core.MessageHandler, b core.Block) (*Parser, error) {
 // loc 表示返回的 token 所占的范围；
 // 当返回 nil, {}, io.EOF 时，表示已经结束
 func (p *Parser) Token() (token interface{}, loc core.Location, err error) {
+	if end := p.pending; end != nil {
+		p.pending = nil
+		return end, end.Location, nil
+	}
+
+	for {
+		token, loc, err = p.token()
+		if err == nil {
+			if _, ok := token.(*Comment); ok && p.skipComments {
+				continue
+			}
+			return token, loc, nil
+		}
+
+		if !p.recovery || errors.Is(err, io.EOF) {
+			return token, loc, err
+		}
+
+		p.Error(err)
+		if !p.sync() {
+			return nil, core.Location{}, io.EOF
+		}
+	}
+}
+
+// SetRecovery 设置是否启用错误恢复模式
+//
+// 在恢复模式下，Token 遇到语法错误时并不会返回该错误，
+// 而是将错误输出至 p.MessageHandler，之后跳至下一个起始元素继续解析，
+// 这样可以在一次解析中报告多个错误。
+func (p *Parser) SetRecovery(recovery bool) { p.recovery = recovery }
+
+// SetSkipComments 设置 Token 是否忽略注释内容
+//
+// 默认情况下，注释会以 *Comment 的形式返回。
+func (p *Parser) SetSkipComments(skip bool) { p.skipComments = skip }
+
+// 匹配起始元素的开始部分
+var startElementPrefix = regexp.MustCompile(`^<[^/!?\s>]`)
+
+// 跳过当前的内容，直到下一个起始元素的位置
+//
+// 如果直到文件末尾都没有找到起始元素，则返回 false。
+func (p *Parser) sync() bool {
+	for !p.AtEOF() {
+		p.Next(1)
+		if _, found := p.Delim('<', false); !found {
+			p.All()
+			return false
+		}
+
+		if p.MatchRegexpAt(startElementPrefix, p.Current().Offset) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Parser) token() (token interface{}, loc core.Location, err error) {
 	for {
 		if p.AtEOF() {
 			return nil, core.Location{}, io.EOF
@@ -141,8 +232,14 @@ func (p *Parser) parseStartElement(pos lexer.Position) (*StartElement, core.Loca
 	}
 	elem.Attributes = attrs
 
+	if err := p.resolveNamespaces(elem); err != nil {
+		p.popNamespaces()
+		return nil, core.Location{}, err
+	}
+
 	p.Spaces(0)
 	if p.Match("/>") {
+		p.popNamespaces() // 自闭合元素不会有对应的结束元素
 		elem.Range = core.Range{Start: pos.Position, End: p.Current().Position}
 		elem.SelfClose = true
 		return elem, elem.Location, nil
@@ -152,9 +249,65 @@ func (p *Parser) parseStartElement(pos lexer.Position) (*StartElement, core.Loca
 		return elem, elem.Location, nil
 	}
 
+	p.popNamespaces()
 	return nil, core.Location{}, p.newError(p.Current().Position, p.Current().Position, string(name), locale.ErrNotFoundEndTag)
 }
 
+// 为 elem 压入新的一层命名空间，并检测 elem 及其属性中使用的前缀是否都已经声明。
+func (p *Parser) resolveNamespaces(elem *StartElement) error {
+	var scope map[string]string
+	for _, attr := range elem.Attributes {
+		var prefix string
+		switch {
+		case attr.Name.Prefix.Value == "xmlns":
+			prefix = attr.Name.Local.Value
+		case attr.Name.Prefix.Value == "" && attr.Name.Local.Value == "xmlns":
+			prefix = ""
+		default:
+			continue
+		}
+
+		if scope == nil {
+			scope = make(map[string]string, 2)
+		}
+		scope[prefix] = attr.Value.Value
+	}
+	p.namespaces = append(p.namespaces, scope)
+
+	if err := p.checkPrefix(elem.Name.Prefix); err != nil {
+		return err
+	}
+	for _, attr := range elem.Attributes {
+		if attr.Name.Prefix.Value == "xmlns" {
+			continue
+		}
+
+		if err := p.checkPrefix(attr.Name.Prefix); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Parser) checkPrefix(prefix String) error {
+	if prefix.Value == "" {
+		return nil
+	}
+
+	if _, found := p.Namespace(prefix.Value); found {
+		return nil
+	}
+	return p.newError(prefix.Range.Start, prefix.Range.End, prefix.Value, locale.ErrUndeclaredNamespacePrefix, prefix.Value)
+}
+
+// 弹出最后一层命名空间，第一层的预定义命名空间始终保留。
+func (p *Parser) popNamespaces() {
+	if l := len(p.namespaces); l > 1 {
+		p.namespaces = p.namespaces[:l-1]
+	}
+}
+
 func parseName(name []byte, uri core.URI, start, end core.Position) Name {
 	index := bytes.IndexByte(name, ':')
 	if index < 0 {
@@ -192,6 +345,7 @@ func (p *Parser) parseEndElement(pos lexer.Position) (*EndElement, core.Location
 	}
 	end := p.Current()
 	p.Next(1) // 去掉 > 符号
+	p.popNamespaces()
 
 	loc := core.Location{
 		URI:   p.Location.URI,
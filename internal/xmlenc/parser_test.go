@@ -3,6 +3,8 @@
 package xmlenc
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"testing"
 
@@ -878,6 +880,158 @@ func TestParser_Token(t *testing.T) {
 	}
 }
 
+func TestParser_SetRecovery(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []byte(`<a x=1><b y="2" /><!-- <c --><c z=">3</c><d></d></a>`)
+
+	// 未启用
+	rslt := messagetest.NewMessageHandler()
+	p, err := NewParser(rslt.Handler, core.Block{Data: data})
+	a.NotError(err).NotNil(p)
+	tok, _, err := p.Token()
+	a.Error(err).Nil(tok)
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+
+	// 启用
+	rslt = messagetest.NewMessageHandler()
+	p, err = NewParser(rslt.Handler, core.Block{Data: data})
+	a.NotError(err).NotNil(p)
+	p.SetRecovery(true)
+
+	names := make([]string, 0, 5)
+	for {
+		tok, _, err = p.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		a.NotError(err)
+
+		switch elem := tok.(type) {
+		case *StartElement:
+			names = append(names, elem.Name.Local.Value)
+		case *EndElement:
+			names = append(names, "/"+elem.Name.Local.Value)
+		}
+	}
+	rslt.Handler.Stop()
+	a.Length(rslt.Errors, 2). // x=1 和 z="
+					Equal(names, []string{"b", "d", "/d", "/a"})
+
+	// 之后没有起始元素
+	rslt = messagetest.NewMessageHandler()
+	p, err = NewParser(rslt.Handler, core.Block{Data: []byte(`<a x=1></a>`)})
+	a.NotError(err).NotNil(p)
+	p.SetRecovery(true)
+	tok, _, err = p.Token()
+	a.Equal(err, io.EOF).Nil(tok).True(p.AtEOF())
+	rslt.Handler.Stop()
+	a.Length(rslt.Errors, 1)
+}
+
+func TestParser_SetSkipComments(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []byte(`<a><!-- comment --><b /></a>`)
+
+	rslt := messagetest.NewMessageHandler()
+	p, err := NewParser(rslt.Handler, core.Block{Data: data})
+	a.NotError(err).NotNil(p)
+	types := make([]string, 0, 4)
+	for {
+		tok, _, err := p.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		a.NotError(err)
+		types = append(types, fmt.Sprintf("%T", tok))
+	}
+	a.Equal(types, []string{"*xmlenc.StartElement", "*xmlenc.Comment", "*xmlenc.StartElement", "*xmlenc.EndElement"})
+
+	p, err = NewParser(rslt.Handler, core.Block{Data: data})
+	a.NotError(err).NotNil(p)
+	p.SetSkipComments(true)
+	types = types[:0]
+	for {
+		tok, _, err := p.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		a.NotError(err)
+		types = append(types, fmt.Sprintf("%T", tok))
+	}
+	a.Equal(types, []string{"*xmlenc.StartElement", "*xmlenc.StartElement", "*xmlenc.EndElement"})
+
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+}
+
+func TestParser_Namespace(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []byte(`<aa:apidoc xmlns:aa="ns1" xmlns="ns2" xml:lang="cmn-Hans"><aa:tag aa:name="t1" /><bb:tag /></aa:apidoc>`)
+	rslt := messagetest.NewMessageHandler()
+	p, err := NewParser(rslt.Handler, core.Block{Data: data})
+	a.NotError(err).NotNil(p)
+
+	ns, found := p.Namespace("aa")
+	a.False(found).Empty(ns)
+	ns, found = p.Namespace("xml")
+	a.True(found).Equal(ns, xmlPrefixNamespace)
+
+	tok, _, err := p.Token()
+	a.NotError(err)
+	elem, ok := tok.(*StartElement)
+	a.True(ok).
+		Equal(elem.Name.Prefix.Value, "aa").
+		Equal(elem.Name.Local.Value, "apidoc")
+	ns, found = p.Namespace("aa")
+	a.True(found).Equal(ns, "ns1")
+	ns, found = p.Namespace("")
+	a.True(found).Equal(ns, "ns2")
+
+	tok, _, err = p.Token()
+	a.NotError(err)
+	elem, ok = tok.(*StartElement)
+	a.True(ok).
+		Equal(elem.Name.String(), "aa:tag").
+		Equal(elem.Attributes[0].Name.String(), "aa:name")
+
+	// 未声明的前缀 bb
+	_, _, err = p.Token()
+	a.Error(err)
+	serr, ok := err.(*core.Error)
+	a.True(ok).Equal(serr.Field, "bb")
+
+	// 属性中未声明的前缀
+	p, err = NewParser(rslt.Handler, core.Block{Data: []byte(`<apidoc bb:name="1" />`)})
+	a.NotError(err).NotNil(p)
+	_, _, err = p.Token()
+	a.Error(err)
+
+	// 命名空间仅在声明的元素内有效
+	p, err = NewParser(rslt.Handler, core.Block{Data: []byte(`<apidoc><a xmlns:aa="ns1"><aa:tag /></a><b xmlns:bb="ns2" /><aa:tag /></apidoc>`)})
+	a.NotError(err).NotNil(p)
+	for i := 0; i < 4; i++ { // <apidoc>、<a>、<aa:tag /> 和 </a>
+		_, _, err = p.Token()
+		a.NotError(err)
+	}
+	_, found = p.Namespace("aa")
+	a.False(found)
+	_, _, err = p.Token() // <b xmlns:bb="ns2" />
+	a.NotError(err)
+	_, found = p.Namespace("bb")
+	a.False(found)
+	_, _, err = p.Token()
+	a.Error(err)
+	serr, ok = err.(*core.Error)
+	a.True(ok).Equal(serr.Field, "aa")
+
+	rslt.Handler.Stop()
+	a.Empty(rslt.Errors)
+}
+
 func TestParser_parseStartElement(t *testing.T) {
 	a := assert.New(t, false)
 	start := core.Position{
@@ -990,46 +1144,16 @@ func TestParser_parseStartElement(t *testing.T) {
 			},
 		},
 
-		{
+		{ // 未声明的命名空间前缀
 			input: `aa:tag/>`,
-			elem: &StartElement{
+			err: &core.Error{
 				Location: core.Location{
 					URI: uri,
 					Range: core.Range{
 						Start: core.Position{Line: 11, Character: 22},
-						End:   core.Position{Line: 11, Character: 30},
-					},
-				},
-				Name: Name{
-					Location: core.Location{
-						URI: uri,
-						Range: core.Range{
-							Start: core.Position{Line: 11, Character: 22},
-							End:   core.Position{Line: 11, Character: 28},
-						},
-					},
-					Local: String{
-						Location: core.Location{
-							URI: uri,
-							Range: core.Range{
-								Start: core.Position{Line: 11, Character: 25},
-								End:   core.Position{Line: 11, Character: 28},
-							},
-						},
-						Value: "tag",
-					},
-					Prefix: String{
-						Location: core.Location{
-							URI: uri,
-							Range: core.Range{
-								Start: core.Position{Line: 11, Character: 22},
-								End:   core.Position{Line: 11, Character: 24},
-							},
-						},
-						Value: "aa",
+						End:   core.Position{Line: 11, Character: 24},
 					},
 				},
-				SelfClose: true,
 			},
 		},
 
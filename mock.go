@@ -3,8 +3,10 @@
 package apidoc
 
 import (
+	"context"
 	"math/rand"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/issue9/rands"
@@ -48,6 +50,46 @@ type MockOptions struct {
 	DateStart time.Time // 指定生成与时间相关的数值时的最小值
 	DateEnd   time.Time // 指定生成与时间相关的数值时的最大值
 	dateSize  int64     // 根据 DateStart 和 DateEnd 生成
+
+	// 生成随机数据时采用的种子
+	//
+	// 相同的种子在请求顺序相同的情况下，会生成相同的数据，可用于测试。
+	// 为 0 时表示采用当前时间作为种子。
+	Seed int64
+
+	// 读取远程文档时的重试设置，仅对 MockFile 有效，为空表示不重试。
+	Retry *core.RetryOptions
+}
+
+// 可在多个 goroutine 中同时使用的 rand.Source
+type lockedSource struct {
+	mux sync.Mutex
+	src rand.Source64
+}
+
+func newRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(&lockedSource{src: rand.NewSource(seed).(rand.Source64)})
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.src.Seed(seed)
 }
 
 var defaultMockOptions = &MockOptions{
@@ -126,104 +168,114 @@ func (o *MockOptions) gen() (*mock.GenOptions, error) {
 		return nil, err
 	}
 
+	r := newRand(o.Seed)
 	return &mock.GenOptions{
 		Number: func(p *ast.Param) interface{} {
 			switch p.Type.V() {
 			case ast.TypeFloat:
-				return o.float()
+				return o.float(r)
 			case ast.TypeInt:
-				return o.integer()
+				return o.integer(r)
 			}
 
 			if !o.EnableFloat {
-				return o.integer()
+				return o.integer(r)
 			}
 
-			if rand.Int()%2 == 0 {
-				return o.integer()
+			if r.Int()%2 == 0 {
+				return o.integer(r)
 			}
-			return o.float()
+			return o.float(r)
 		},
 
 		String: func(p *ast.Param) string {
 			switch p.Type.V() {
 			case ast.TypeEmail:
-				return o.email()
+				return o.email(r)
 			case ast.TypeURL:
-				return o.url()
+				return o.url(r)
 			case ast.TypeImage:
-				return o.image()
+				return o.image(r)
 			case ast.TypeDate:
-				return o.date()
+				return o.date(r)
 			case ast.TypeTime:
-				return o.time()
+				return o.time(r)
 			case ast.TypeDateTime:
-				return o.dateTime()
+				return o.dateTime(r)
 			}
-			return rands.String(o.StringSize.Min, o.StringSize.Max, o.StringAlpha)
+			return randString(r, o.StringSize.Min, o.StringSize.Max, o.StringAlpha)
 		},
 
 		Bool: func() bool {
-			return rand.Int()%2 == 0
+			return r.Int()%2 == 0
 		},
 
 		SliceSize: func() int {
-			return rand.Intn(o.SliceSize.Max-o.SliceSize.Min) + o.SliceSize.Min
+			return r.Intn(o.SliceSize.Max-o.SliceSize.Min) + o.SliceSize.Min
 		},
 
 		Index: func(max int) int {
-			return rand.Intn(max)
+			return r.Intn(max)
 		},
 	}, nil
 }
 
-func (o *MockOptions) integer() int {
-	return rand.Intn(o.NumberSize.Max-o.NumberSize.Min) + o.NumberSize.Min
+func (o *MockOptions) integer(r *rand.Rand) int {
+	return r.Intn(o.NumberSize.Max-o.NumberSize.Min) + o.NumberSize.Min
 }
 
-func (o *MockOptions) float() float32 {
-	return float32(o.NumberSize.Min) + rand.Float32()*float32(o.NumberSize.Max-o.NumberSize.Min)
+func (o *MockOptions) float(r *rand.Rand) float32 {
+	return float32(o.NumberSize.Min) + r.Float32()*float32(o.NumberSize.Max-o.NumberSize.Min)
 }
 
-func (o *MockOptions) url() string {
-	url := o.URLDomains[rand.Intn(len(o.URLDomains))]
+func (o *MockOptions) url(r *rand.Rand) string {
+	url := o.URLDomains[r.Intn(len(o.URLDomains))]
 	if url[len(url)-1] != '/' {
 		url += "/"
 	}
 
-	size := rand.Intn(4)
+	size := r.Intn(4)
 	for i := 0; i < size; i++ {
-		url += rands.String(1, 5, rands.AlphaNumber) + "/"
+		url += randString(r, 1, 5, rands.AlphaNumber) + "/"
 	}
 	return url
 }
 
-func (o *MockOptions) email() string {
-	domain := o.EmailDomains[rand.Intn(len(o.EmailDomains))]
-	username := rands.String(o.EmailUsernameSize.Min, o.EmailUsernameSize.Max, rands.AlphaNumber)
+func (o *MockOptions) email(r *rand.Rand) string {
+	domain := o.EmailDomains[r.Intn(len(o.EmailDomains))]
+	username := randString(r, o.EmailUsernameSize.Min, o.EmailUsernameSize.Max, rands.AlphaNumber)
 	return username + "@" + domain
 }
 
-func (o *MockOptions) image() string {
+func (o *MockOptions) image(r *rand.Rand) string {
 	path := o.ImageBasePrefix
 	if path[len(path)-1] != '/' {
 		path += "/"
 	}
-	return path + rands.String(1, 5, rands.AlphaNumber)
+	return path + randString(r, 1, 5, rands.AlphaNumber)
 }
 
-func (o *MockOptions) date() string {
-	s := rand.Int63n(o.dateSize)
+func (o *MockOptions) date(r *rand.Rand) string {
+	s := r.Int63n(o.dateSize)
 	return o.DateStart.Add(time.Duration(s) * time.Second).Format(ast.DateFormat)
 }
 
-func (o *MockOptions) time() string {
-	d := rand.Int63n(86400)
+func (o *MockOptions) time(r *rand.Rand) string {
+	d := r.Int63n(86400)
 	return o.DateStart.Add(time.Duration(d) * time.Second).Format(ast.TimeFormat)
 }
 
-func (o *MockOptions) dateTime() string {
-	return o.date() + "T" + o.time()
+func (o *MockOptions) dateTime(r *rand.Rand) string {
+	return o.date(r) + "T" + o.time(r)
+}
+
+// 生成长度介于 [min, max) 之间的随机字符串，字符从 alpha 中获取。
+func randString(r *rand.Rand, min, max int, alpha []byte) string {
+	bs := make([]byte, r.Intn(max-min)+min)
+	for i := range bs {
+		bs[i] = alpha[r.Intn(len(alpha))]
+	}
+	return string(bs)
 }
 
 // Mock 根据文档数据生成 Mock 中间件
@@ -251,5 +303,9 @@ func MockFile(h *core.MessageHandler, path core.URI, o *MockOptions) (http.Handl
 		return nil, err
 	}
 
-	return mock.Load(h, path, o.Indent, o.ImageBasePrefix, o.Servers, g)
+	ctx := context.Background()
+	if o.Retry != nil {
+		ctx = core.WithRetryOptions(ctx, o.Retry)
+	}
+	return mock.Load(ctx, h, path, o.Indent, o.ImageBasePrefix, o.Servers, g)
 }
@@ -4,6 +4,7 @@ package apidoc
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -24,12 +25,12 @@ func TestMockOptions_url(t *testing.T) {
 	o := &MockOptions{
 		URLDomains: []string{"https://apidoc.tools/"},
 	}
-	url := o.url()
+	url := o.url(newRand(0))
 	a.True(strings.HasPrefix(url, o.URLDomains[0])).
 		True(is.URL(url))
 
 	o.URLDomains[0] = "https://apidoc.tools"
-	url = o.url()
+	url = o.url(newRand(0))
 	a.True(strings.HasPrefix(url, o.URLDomains[0])).
 		True(is.URL(url))
 }
@@ -41,7 +42,7 @@ func TestMockOptions_email(t *testing.T) {
 		EmailDomains:      []string{"apidoc.tools"},
 		EmailUsernameSize: Range{Min: 5, Max: 11},
 	}
-	email := o.email()
+	email := o.email(newRand(0))
 	a.True(strings.HasSuffix(email, o.EmailDomains[0])).
 		True(is.Email(email))
 	index := strings.IndexByte(email, '@')
@@ -201,6 +202,40 @@ func TestMock(t *testing.T) {
 	rslt.Handler.Stop()
 }
 
+func TestMockOptions_Seed(t *testing.T) {
+	a := assert.New(t, false)
+
+	get := func(seed int64) []string {
+		rslt := messagetest.NewMessageHandler()
+		opt := &MockOptions{}
+		*opt = *defaultMockOptions
+		opt.Servers = map[string]string{"admin": "/admin"}
+		opt.Seed = seed
+		mock, err := Mock(rslt.Handler, asttest.XML(a), opt)
+		a.NotError(err).NotNil(mock)
+
+		bodies := make([]string, 0, 3)
+		for i := 0; i < 3; i++ {
+			r := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+			r.Header.Set("authorization", "xxx")
+			r.Header.Set("Content-Type", "application/json")
+			r.Header.Set("Accept", "application/json")
+			w := httptest.NewRecorder()
+			mock.ServeHTTP(w, r)
+			a.Equal(w.Code, http.StatusOK)
+			bodies = append(bodies, w.Body.String())
+		}
+
+		rslt.Handler.Stop()
+		return bodies
+	}
+
+	b1 := get(1)
+	a.NotEqual(b1[0], b1[1]) // 同一个实例的多次请求，内容不同
+	a.Equal(b1, get(1))      // 相同的种子，内容相同
+	a.NotEqual(b1, get(2))
+}
+
 func TestMockFile(t *testing.T) {
 	a := assert.New(t, false)
 
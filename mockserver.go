@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: MIT
+
+package apidoc
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// MockRequest 表示 MockServer 录制的一次请求
+//
+// 可以序列化为 JSON 保存，之后通过 MockServer.Replay 回放。
+type MockRequest struct {
+	Method       string `json:"method"`
+	Path         string `json:"path"` // 包含查询参数
+	RequestBody  string `json:"requestBody,omitempty"`
+	ResponseBody string `json:"responseBody,omitempty"`
+	StatusCode   int    `json:"statusCode"`
+	ContentType  string `json:"contentType,omitempty"` // 返回内容的 Content-Type
+}
+
+// MockServer 为 mock 中间件添加录制和回放功能
+//
+// 录制状态下，所有经过的请求及其返回内容都会被记录下来；
+// 回放状态下，则直接返回与请求的 Method 和 Path 相匹配的录制内容，
+// 可以在没有真实服务的情况下进行契约测试。
+type MockServer struct {
+	http.Handler
+
+	mux       sync.Mutex
+	recording bool
+	records   []MockRequest
+	fixtures  map[string][]MockRequest // 回放的内容，为 nil 表示不处于回放状态
+}
+
+// NewMockServer 声明 MockServer 实例
+//
+// h 一般为 Mock 或 MockFile 返回的对象。
+func NewMockServer(h http.Handler) *MockServer {
+	return &MockServer{Handler: h}
+}
+
+// StartRecording 开始录制
+//
+// 之前录制的内容会被清除。
+func (srv *MockServer) StartRecording() {
+	srv.mux.Lock()
+	defer srv.mux.Unlock()
+
+	srv.recording = true
+	srv.records = srv.records[:0]
+}
+
+// StopRecording 停止录制并返回录制的内容
+func (srv *MockServer) StopRecording() []MockRequest {
+	srv.mux.Lock()
+	defer srv.mux.Unlock()
+
+	srv.recording = false
+	records := make([]MockRequest, len(srv.records))
+	copy(records, srv.records)
+	return records
+}
+
+// Replay 以 fixtures 的内容进行回放
+//
+// 回放状态下不会再访问 MockServer.Handler，找不到匹配项的请求返回 404。
+// 同一请求有多条记录时，按顺序依次返回，之后一直返回最后一条。
+// fixtures 为空表示退出回放状态。
+func (srv *MockServer) Replay(fixtures []MockRequest) {
+	srv.mux.Lock()
+	defer srv.mux.Unlock()
+
+	if len(fixtures) == 0 {
+		srv.fixtures = nil
+		return
+	}
+
+	srv.fixtures = make(map[string][]MockRequest, len(fixtures))
+	for _, f := range fixtures {
+		key := fixtureKey(f.Method, f.Path)
+		srv.fixtures[key] = append(srv.fixtures[key], f)
+	}
+}
+
+func fixtureKey(method, path string) string {
+	return method + " " + path
+}
+
+func (srv *MockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.mux.Lock()
+	fixtures, recording := srv.fixtures, srv.recording
+	srv.mux.Unlock()
+
+	if fixtures != nil {
+		srv.replay(w, r)
+		return
+	}
+
+	if !recording {
+		srv.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	var body []byte
+	if r.Body != nil {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = data
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	rw := &recordWriter{ResponseWriter: w, status: http.StatusOK}
+	srv.Handler.ServeHTTP(rw, r)
+
+	srv.mux.Lock()
+	defer srv.mux.Unlock()
+	if srv.recording { // 处理请求的过程中可能已经停止录制
+		srv.records = append(srv.records, MockRequest{
+			Method:       r.Method,
+			Path:         r.URL.RequestURI(),
+			RequestBody:  string(body),
+			ResponseBody: rw.body.String(),
+			StatusCode:   rw.status,
+			ContentType:  rw.Header().Get("Content-Type"),
+		})
+	}
+}
+
+func (srv *MockServer) replay(w http.ResponseWriter, r *http.Request) {
+	srv.mux.Lock()
+	key := fixtureKey(r.Method, r.URL.RequestURI())
+	list, found := srv.fixtures[key]
+	var f MockRequest
+	if found {
+		f = list[0]
+		if len(list) > 1 {
+			srv.fixtures[key] = list[1:]
+		}
+	}
+	srv.mux.Unlock()
+
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	if f.ContentType != "" {
+		w.Header().Set("Content-Type", f.ContentType)
+	}
+	w.WriteHeader(f.StatusCode)
+	io.WriteString(w, f.ResponseBody)
+}
+
+// 记录输出内容的 http.ResponseWriter
+type recordWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: MIT
+
+package apidoc
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core/messagetest"
+	"github.com/caixw/apidoc/v7/internal/ast/asttest"
+)
+
+func TestMockServer(t *testing.T) {
+	a := assert.New(t, false)
+
+	rslt := messagetest.NewMessageHandler()
+	opt := &MockOptions{}
+	*opt = *defaultMockOptions
+	opt.Servers = map[string]string{"admin": "/admin", "client": "/c"}
+	mock, err := Mock(rslt.Handler, asttest.XML(a), opt)
+	a.NotError(err).NotNil(mock)
+	srv := NewMockServer(mock)
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	do := func(method, path, body string) (int, string) {
+		r, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		a.NotError(err)
+		r.Header.Set("authorization", "xxx")
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Accept", "application/json")
+		resp, err := http.DefaultClient.Do(r)
+		a.NotError(err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		a.NotError(err)
+		return resp.StatusCode, string(data)
+	}
+
+	// 未录制
+	status, _ := do(http.MethodGet, "/admin/users", "")
+	a.Equal(status, http.StatusOK)
+	a.Empty(srv.StopRecording())
+
+	// 录制
+	srv.StartRecording()
+	status1, body1 := do(http.MethodGet, "/admin/users", "")
+	status2, body2 := do(http.MethodPost, "/c/users", `{"id":1,"name":"name"}`)
+	status3, _ := do(http.MethodDelete, "/admin/users", "")
+	records := srv.StopRecording()
+	a.Equal(3, len(records)).
+		Equal(records[0], MockRequest{
+			Method:       http.MethodGet,
+			Path:         "/admin/users",
+			ResponseBody: body1,
+			StatusCode:   status1,
+			ContentType:  "application/json",
+		}).
+		Equal(records[1].RequestBody, `{"id":1,"name":"name"}`).
+		Equal(records[1].StatusCode, status2).
+		Equal(records[1].ResponseBody, body2).
+		Equal(records[2].StatusCode, status3)
+	a.Equal(status1, http.StatusOK).NotEmpty(body1)
+
+	// 停止录制之后不再记录
+	do(http.MethodGet, "/admin/users", "")
+	a.Equal(3, len(srv.StopRecording()))
+
+	// 以 JSON 保存之后回放
+	data, err := json.Marshal(records)
+	a.NotError(err)
+	fixtures := make([]MockRequest, 0, len(records))
+	a.NotError(json.Unmarshal(data, &fixtures))
+
+	replay := NewMockServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("回放状态下不应该访问 Handler")
+	}))
+	replay.Replay(fixtures)
+	ts2 := httptest.NewServer(replay)
+	defer ts2.Close()
+	ts.URL = ts2.URL // do 使用 ts.URL 作为地址
+
+	status, body := do(http.MethodGet, "/admin/users", "")
+	a.Equal(status, status1).Equal(body, body1) // 内容与录制时完全相同
+	status, body = do(http.MethodGet, "/admin/users", "")
+	a.Equal(status, status1).Equal(body, body1) // 只有一条记录时，一直返回该记录
+	status, body = do(http.MethodPost, "/c/users", "")
+	a.Equal(status, status2).Equal(body, body2)
+	status, _ = do(http.MethodDelete, "/admin/users", "")
+	a.Equal(status, status3)
+	status, _ = do(http.MethodGet, "/not-exists", "")
+	a.Equal(status, http.StatusNotFound)
+
+	// 退出回放状态
+	srv.Replay(nil)
+	a.Nil(srv.fixtures)
+
+	rslt.Handler.Stop()
+}
+
+func TestMockServer_Replay(t *testing.T) {
+	a := assert.New(t, false)
+
+	srv := NewMockServer(http.NotFoundHandler())
+	srv.Replay([]MockRequest{
+		{Method: http.MethodGet, Path: "/users?page=1", StatusCode: http.StatusOK, ResponseBody: "1"},
+		{Method: http.MethodGet, Path: "/users?page=1", StatusCode: http.StatusCreated, ResponseBody: "2"},
+	})
+
+	get := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users?page=1", nil))
+		return w
+	}
+
+	// 按顺序返回，之后一直返回最后一条
+	w := get()
+	a.Equal(w.Code, http.StatusOK).Equal(w.Body.String(), "1")
+	w = get()
+	a.Equal(w.Code, http.StatusCreated).Equal(w.Body.String(), "2")
+	w = get()
+	a.Equal(w.Code, http.StatusCreated).Equal(w.Body.String(), "2")
+
+	// 查询参数不同
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users?page=2", nil))
+	a.Equal(w.Code, http.StatusNotFound)
+}
@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: MIT
+
+package apidoc
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/locale"
+)
+
+// ServerBuilder 以链式调用的方式构建文档的静态文件服务
+//
+//  h, err := apidoc.NewServerBuilder().
+//      WithDir(dir).
+//      WithAuth("user", "pass").
+//      WithRateLimit(100).
+//      WithCORS("https://example.com").
+//      Build()
+//
+// 中间件的执行顺序依次为：身份验证、限流、CORS，最后才是文件服务。
+// 由于身份验证在 CORS 之前，跨域的预检请求同样需要带上验证信息。
+type ServerBuilder struct {
+	dir        core.URI
+	stylesheet bool
+	erro       *log.Logger
+
+	username, password string
+	auth               bool
+
+	certFile, keyFile string
+	cert              *tls.Certificate
+
+	rateLimit int
+	origins   []string
+}
+
+// NewServerBuilder 声明 ServerBuilder 实例
+func NewServerBuilder() *ServerBuilder {
+	return &ServerBuilder{}
+}
+
+// WithDir 指定静态文件的目录
+//
+// 与 Static 的参数相同，为空表示采用内置的文档内容。
+func (b *ServerBuilder) WithDir(dir core.URI) *ServerBuilder {
+	b.dir = dir
+	return b
+}
+
+// WithStylesheet 是否只展示 XSL 及相关的内容
+func (b *ServerBuilder) WithStylesheet(stylesheet bool) *ServerBuilder {
+	b.stylesheet = stylesheet
+	return b
+}
+
+// WithErrorLog 指定服务出错时的错误信息输出通道
+func (b *ServerBuilder) WithErrorLog(erro *log.Logger) *ServerBuilder {
+	b.erro = erro
+	return b
+}
+
+// WithAuth 以 HTTP Basic 的方式进行身份验证
+func (b *ServerBuilder) WithAuth(username, password string) *ServerBuilder {
+	b.username = username
+	b.password = password
+	b.auth = true
+	return b
+}
+
+// WithTLS 指定 TLS 的证书和私钥文件
+//
+// 证书会在 Build 时加载，之后可通过 TLSConfig 获取对应的 tls.Config，
+// 用于初始化 http.Server.TLSConfig。
+func (b *ServerBuilder) WithTLS(certFile, keyFile string) *ServerBuilder {
+	b.certFile = certFile
+	b.keyFile = keyFile
+	return b
+}
+
+// WithRateLimit 限制每秒最多处理的请求数量
+//
+// 超出的请求返回 429，0 表示不作限制。
+func (b *ServerBuilder) WithRateLimit(perSecond int) *ServerBuilder {
+	b.rateLimit = perSecond
+	return b
+}
+
+// WithCORS 指定允许跨域访问的域名
+//
+// * 表示允许所有的域名。
+func (b *ServerBuilder) WithCORS(origins ...string) *ServerBuilder {
+	b.origins = origins
+	return b
+}
+
+// TLSConfig 返回由 WithTLS 指定的证书生成的 tls.Config
+//
+// 只有在 Build 成功之后才有值，未指定证书时返回 nil。
+func (b *ServerBuilder) TLSConfig() *tls.Config {
+	if b.cert == nil {
+		return nil
+	}
+	return &tls.Config{Certificates: []tls.Certificate{*b.cert}}
+}
+
+// Build 生成 http.Handler
+//
+// 如果配置项有问题，则以 *core.Error 类型返回错误信息。
+// 采用内置的文档内容时，如果内置内容不完整，会直接 panic。
+func (b *ServerBuilder) Build() (http.Handler, error) {
+	if b.auth && b.username == "" {
+		return nil, core.NewError(locale.ErrIsEmpty, "username").WithField("username")
+	}
+
+	if b.rateLimit < 0 {
+		return nil, core.NewError(locale.ErrInvalidValue).WithField("rateLimit")
+	}
+
+	if b.certFile != "" || b.keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(b.certFile, b.keyFile)
+		if err != nil {
+			return nil, core.WithError(err).WithField("tls")
+		}
+		b.cert = &cert
+	}
+
+	erro := b.erro
+	if erro == nil {
+		erro = log.Default()
+	}
+	h := Static(b.dir, b.stylesheet, erro)
+
+	// 按执行顺序的倒序包装
+	if len(b.origins) > 0 {
+		h = corsHandler(h, b.origins)
+	}
+	if b.rateLimit > 0 {
+		h = rateLimitHandler(h, b.rateLimit)
+	}
+	if b.auth {
+		h = basicAuthHandler(h, b.username, b.password)
+	}
+
+	return h, nil
+}
+
+func basicAuthHandler(next http.Handler, username, password string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(u), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(p), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="`+core.Name+`"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// 令牌桶算法实现的限流
+type rateLimiter struct {
+	mux      sync.Mutex
+	rate     float64 // 每秒生成的令牌数量，同时也是桶的容量
+	tokens   float64
+	lastTime time.Time
+}
+
+func (l *rateLimiter) allow(now time.Time) bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.tokens += now.Sub(l.lastTime).Seconds() * l.rate
+	if l.tokens > l.rate {
+		l.tokens = l.rate
+	}
+	l.lastTime = now
+
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+func rateLimitHandler(next http.Handler, perSecond int) http.Handler {
+	l := &rateLimiter{
+		rate:     float64(perSecond),
+		tokens:   float64(perSecond),
+		lastTime: time.Now(),
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !l.allow(time.Now()) {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func corsHandler(next http.Handler, origins []string) http.Handler {
+	allowed := func(origin string) bool {
+		for _, o := range origins {
+			if o == "*" || o == origin {
+				return true
+			}
+		}
+		return false
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !allowed(origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+
+		// 预检请求
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
+			if h := r.Header.Get("Access-Control-Request-Headers"); h != "" {
+				w.Header().Set("Access-Control-Allow-Headers", h)
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: MIT
+
+package apidoc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/caixw/apidoc/v7/core"
+	"github.com/caixw/apidoc/v7/internal/docs"
+)
+
+// 生成自签名的证书，返回证书和私钥的文件路径。
+func writeTestCert(a *assert.Assertion, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	a.NotError(err)
+
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	a.NotError(err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	a.NotError(err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	a.NotError(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), os.ModePerm))
+	a.NotError(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), os.ModePerm))
+	return certFile, keyFile
+}
+
+func TestServerBuilder(t *testing.T) {
+	a := assert.New(t, false)
+	certFile, keyFile := writeTestCert(a, t.TempDir())
+
+	b := NewServerBuilder().
+		WithDir(docs.Dir()).
+		WithAuth("user", "pass").
+		WithTLS(certFile, keyFile).
+		WithRateLimit(3).
+		WithCORS("https://example.com")
+	h, err := b.Build()
+	a.NotError(err).NotNil(h).NotNil(b.TLSConfig())
+
+	srv := httptest.NewUnstartedServer(h)
+	srv.TLS = b.TLSConfig()
+	srv.StartTLS()
+	defer srv.Close()
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}}
+
+	do := func(method, path string, header map[string]string, auth bool) *http.Response {
+		r, err := http.NewRequest(method, srv.URL+path, nil)
+		a.NotError(err)
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+		if auth {
+			r.SetBasicAuth("user", "pass")
+		}
+		resp, err := client.Do(r)
+		a.NotError(err)
+		resp.Body.Close()
+		a.NotNil(resp.TLS)
+		return resp
+	}
+
+	// 身份验证在限流之前，验证失败的请求不消耗令牌。
+	for i := 0; i < 5; i++ {
+		resp := do(http.MethodGet, "/icon.svg", nil, false)
+		a.Equal(resp.StatusCode, http.StatusUnauthorized).
+			NotEmpty(resp.Header.Get("WWW-Authenticate")).
+			Empty(resp.Header.Get("Access-Control-Allow-Origin"))
+	}
+	r, err := http.NewRequest(http.MethodGet, srv.URL+"/icon.svg", nil)
+	a.NotError(err)
+	r.SetBasicAuth("user", "invalid")
+	resp, err := client.Do(r)
+	a.NotError(err)
+	resp.Body.Close()
+	a.Equal(resp.StatusCode, http.StatusUnauthorized)
+
+	// CORS
+	resp = do(http.MethodGet, "/icon.svg", map[string]string{"Origin": "https://example.com"}, true)
+	a.Equal(resp.StatusCode, http.StatusOK).
+		Equal(resp.Header.Get("Access-Control-Allow-Origin"), "https://example.com")
+
+	resp = do(http.MethodOptions, "/icon.svg", map[string]string{
+		"Origin":                        "https://example.com",
+		"Access-Control-Request-Method": http.MethodGet,
+	}, true)
+	a.Equal(resp.StatusCode, http.StatusNoContent).
+		Equal(resp.Header.Get("Access-Control-Allow-Origin"), "https://example.com").
+		NotEmpty(resp.Header.Get("Access-Control-Allow-Methods"))
+
+	// 不允许的域名
+	resp = do(http.MethodGet, "/icon.svg", map[string]string{"Origin": "https://other.com"}, true)
+	a.Equal(resp.StatusCode, http.StatusOK).
+		Empty(resp.Header.Get("Access-Control-Allow-Origin"))
+
+	// 限流，令牌已经用完，且在 CORS 之前处理。
+	resp = do(http.MethodGet, "/icon.svg", map[string]string{"Origin": "https://example.com"}, true)
+	a.Equal(resp.StatusCode, http.StatusTooManyRequests).
+		Equal(resp.Header.Get("Retry-After"), "1").
+		Empty(resp.Header.Get("Access-Control-Allow-Origin"))
+
+	time.Sleep(400 * time.Millisecond) // 生成新的令牌
+	resp = do(http.MethodGet, "/icon.svg", nil, true)
+	a.Equal(resp.StatusCode, http.StatusOK)
+}
+
+func TestServerBuilder_Build(t *testing.T) {
+	a := assert.New(t, false)
+
+	// 默认值
+	b := NewServerBuilder()
+	h, err := b.Build()
+	a.NotError(err).NotNil(h).Nil(b.TLSConfig())
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/icon.svg", nil))
+	a.Equal(w.Code, http.StatusOK)
+
+	// stylesheet
+	h, err = NewServerBuilder().WithStylesheet(true).WithCORS("*").Build()
+	a.NotError(err).NotNil(h)
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/index.xml", nil)
+	r.Header.Set("Origin", "https://example.com")
+	h.ServeHTTP(w, r)
+	a.Equal(w.Code, http.StatusNotFound).
+		Equal(w.Header().Get("Access-Control-Allow-Origin"), "https://example.com")
+
+	// 用户名为空
+	h, err = NewServerBuilder().WithAuth("", "pass").Build()
+	a.Nil(h)
+	cerr, ok := err.(*core.Error)
+	a.True(ok).Equal(cerr.Field, "username")
+
+	// 无效的限流值
+	h, err = NewServerBuilder().WithRateLimit(-1).Build()
+	a.Nil(h)
+	cerr, ok = err.(*core.Error)
+	a.True(ok).Equal(cerr.Field, "rateLimit")
+
+	// 证书不存在
+	h, err = NewServerBuilder().WithTLS("not-exists.pem", "not-exists.key").Build()
+	a.Nil(h)
+	cerr, ok = err.(*core.Error)
+	a.True(ok).Equal(cerr.Field, "tls")
+}
+
+func TestRateLimiter(t *testing.T) {
+	a := assert.New(t, false)
+
+	now := time.Now()
+	l := &rateLimiter{rate: 2, tokens: 2, lastTime: now}
+	a.True(l.allow(now)).
+		True(l.allow(now)).
+		False(l.allow(now))
+
+	// 半秒生成一个令牌
+	now = now.Add(500 * time.Millisecond)
+	a.True(l.allow(now)).False(l.allow(now))
+
+	// 不会超过桶的容量
+	now = now.Add(10 * time.Second)
+	a.True(l.allow(now)).
+		True(l.allow(now)).
+		False(l.allow(now))
+}